@@ -3,21 +3,26 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // timezones of due dates are resolved without the system zoneinfo
 
 	"github.com/andredubov/todo-backend/internal/config"
 	"github.com/andredubov/todo-backend/internal/repository"
 	"github.com/andredubov/todo-backend/internal/server"
 	"github.com/andredubov/todo-backend/internal/service"
 	transport "github.com/andredubov/todo-backend/internal/transport/http/v1"
+	"github.com/andredubov/todo-backend/internal/worker"
 	"github.com/andredubov/todo-backend/pkg/auth"
+	"github.com/andredubov/todo-backend/pkg/blob"
 	"github.com/andredubov/todo-backend/pkg/database"
 	"github.com/andredubov/todo-backend/pkg/hash"
 	"github.com/andredubov/todo-backend/pkg/logger"
+	"github.com/andredubov/todo-backend/pkg/notify"
 )
 
 const (
@@ -54,14 +59,38 @@ func main() {
 		return
 	}
 
+	notifier, err := newNotifier(cfg.Reminders)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	// anyone could sign a download link with an empty key
+	if cfg.Attachments.SigningKey == "" {
+		logger.Errorf("%s must be set to sign the download links of attachments", config.AttachmentsSigningKey)
+		return
+	}
+
+	store, err := newBlobStore(cfg.Attachments)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
 	hasher := hash.NewSHA1Hasher(cfg.Auth.PasswordSalt)
 
 	respository := repository.New(db)
-	services := service.New(respository, hasher)
+	services := service.New(respository, hasher, store, cfg)
 	handler := transport.NewHandler(services, tokenManager, cfg.Auth.JWT).InitRoutes(cfg)
 
 	srv := server.New(cfg, handler)
 
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go worker.NewTrashPurger(services.Trash, store, cfg.Trash).Run(workersCtx)
+	go worker.NewReminderDispatcher(services.Reminders, notifier, cfg.Reminders).Run(workersCtx)
+
 	go func() {
 		if err := srv.Run(); !errors.Is(err, http.ErrServerClosed) {
 			logger.Errorf("error occurred while running http server: %s\n", err.Error())
@@ -82,3 +111,29 @@ func main() {
 		logger.Errorf("failed to stop server: %v", err)
 	}
 }
+
+// newNotifier creates the notifier the reminders are delivered through.
+func newNotifier(cfg config.RemindersConfig) (notify.Notifier, error) {
+
+	switch cfg.Notifier {
+	case config.EmailNotifier:
+		return notify.NewEmailNotifier(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From), nil
+	case config.WebhookNotifier:
+		return notify.NewWebhookNotifier(cfg.Webhook.URL, cfg.Webhook.Timeout), nil
+	}
+
+	return nil, fmt.Errorf("unknown notifier %q", cfg.Notifier)
+}
+
+// newBlobStore creates the store the attached files are kept in.
+func newBlobStore(cfg config.AttachmentsConfig) (blob.BlobStore, error) {
+
+	switch cfg.Storage {
+	case config.LocalStorage:
+		return blob.NewLocalStore(cfg.Local.Dir), nil
+	case config.S3Storage:
+		return blob.NewS3Store(cfg.S3.Endpoint, cfg.S3.Region, cfg.S3.Bucket, cfg.S3.AccessKey, cfg.S3.SecretKey, cfg.S3.PathStyle, cfg.S3.Timeout)
+	}
+
+	return nil, fmt.Errorf("unknown attachments storage %q", cfg.Storage)
+}
@@ -8,21 +8,25 @@ require (
 	github.com/gorilla/mux v1.8.0
 	github.com/jmoiron/sqlx v1.3.5
 	github.com/lib/pq v1.10.9
+	github.com/microcosm-cc/bluemonday v1.0.24
 	github.com/pkg/errors v0.9.1
 	github.com/sirupsen/logrus v1.9.3
 	github.com/spf13/viper v1.16.0
 	github.com/swaggo/http-swagger v1.3.4
 	github.com/swaggo/swag v1.16.1
+	github.com/yuin/goldmark v1.6.0
 	gopkg.in/validator.v2 v2.0.1
 )
 
 require (
 	github.com/dvln/go-difflib v0.0.0-20160110105554-792786c7400a // indirect
 	github.com/dvln/go-spew v0.0.0-20161022190105-ab0ae842c130 // indirect
+	github.com/gorilla/css v1.0.0 // indirect
 )
 
 require (
 	github.com/KyleBanks/depth v1.2.1 // indirect
+	github.com/aymerick/douceur v0.2.0 // indirect
 	github.com/dvln/testify v0.0.0-20161024040450-c9680faee19e
 	github.com/fsnotify/fsnotify v1.6.0 // indirect
 	github.com/go-openapi/jsonpointer v0.19.6 // indirect
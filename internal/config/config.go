@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
 	"time"
@@ -16,10 +18,30 @@ const (
 	defaultRefreshTokenTTL        = 24 * time.Hour * 30
 	defaultVerificationCodeLength = 8
 	defaultSSLMode                = "disable"
+	defaultTrashRetention         = 24 * time.Hour * 30
+	defaultTrashPurgeInterval     = time.Hour
+	defaultReminderInterval       = 30 * time.Second
+	defaultReminderBatchSize      = 100
+	defaultSMTPPort               = 587
+	defaultWebhookTimeout         = 10 * time.Second
+	defaultSubtasksMaxDepth       = 3
+	defaultAttachmentsStorage     = LocalStorage
+	defaultAttachmentsMaxSize     = 10 << 20
+	defaultAttachmentsQuota       = 100 << 20
+	defaultAttachmentsURLTTL      = 15 * time.Minute
+	defaultAttachmentsDir         = "./data/attachments"
+	defaultS3Region               = "us-east-1"
+	defaultS3Timeout              = time.Minute
 
 	Local = "local"
 	Prod  = "prod"
 
+	EmailNotifier   = "email"
+	WebhookNotifier = "webhook"
+
+	LocalStorage = "local"
+	S3Storage    = "s3"
+
 	PostgresHost           = "DB_HOST"
 	PostgresPort           = "DB_PORT"
 	PostgresDatabaseName   = "DB_NAME"
@@ -28,6 +50,10 @@ const (
 	PostgresSSLMode        = "DB_SSL_MODE"
 	PasswordSalt           = "PASSWORD_SALT"
 	JwtSigningKey          = "JWT_SIGNING_KEY"
+	SmtpPassword           = "SMTP_PASSWORD"
+	AttachmentsSigningKey  = "ATTACHMENTS_SIGNING_KEY"
+	S3AccessKey            = "S3_ACCESS_KEY"
+	S3SecretKey            = "S3_SECRET_KEY"
 	HttpHost               = "HTTP_HOST"
 	HttpPort               = "HTTP_PORT"
 	ApplicationEnvironment = "APP_ENV"
@@ -35,11 +61,16 @@ const (
 
 type (
 	Config struct {
-		Environment string
-		Postgres    PostgresConfig
-		Auth        AuthConfig
-		HTTP        HTTPConfig
-		CacheTTL    time.Duration `mapstructure:"ttl"`
+		Environment  string
+		Postgres     PostgresConfig
+		Auth         AuthConfig
+		HTTP         HTTPConfig
+		Trash        TrashConfig
+		Reminders    RemindersConfig
+		Subtasks     SubtasksConfig
+		Dependencies DependenciesConfig
+		Attachments  AttachmentsConfig
+		CacheTTL     time.Duration `mapstructure:"ttl"`
 	}
 
 	PostgresConfig struct {
@@ -70,6 +101,80 @@ type (
 		WriteTimeout       time.Duration `mapstructure:"writeTimeout"`
 		MaxHeaderMegabytes int           `mapstructure:"maxHeaderMegaBytes"`
 	}
+
+	TrashConfig struct {
+		Retention     time.Duration `mapstructure:"retention"`
+		PurgeInterval time.Duration `mapstructure:"purgeInterval"`
+	}
+
+	// RemindersConfig chooses how often the fired reminders are looked for
+	// and which notifier, email or webhook, delivers them.
+	RemindersConfig struct {
+		DispatchInterval time.Duration `mapstructure:"dispatchInterval"`
+		BatchSize        int           `mapstructure:"batchSize"`
+		Notifier         string        `mapstructure:"notifier"`
+		SMTP             SMTPConfig    `mapstructure:"smtp"`
+		Webhook          WebhookConfig `mapstructure:"webhook"`
+	}
+
+	SMTPConfig struct {
+		Host     string `mapstructure:"host"`
+		Port     int    `mapstructure:"port"`
+		Username string `mapstructure:"username"`
+		Password string
+		From     string `mapstructure:"from"`
+	}
+
+	WebhookConfig struct {
+		URL     string        `mapstructure:"url"`
+		Timeout time.Duration `mapstructure:"timeout"`
+	}
+
+	// SubtasksConfig limits how many levels of subtasks an item may have and
+	// chooses whether completing the last open subtask completes the parent
+	// and whether completing the parent completes its subtasks.
+	SubtasksConfig struct {
+		MaxDepth         int  `mapstructure:"maxDepth"`
+		CompleteParent   bool `mapstructure:"completeParent"`
+		CompleteChildren bool `mapstructure:"completeChildren"`
+	}
+
+	// DependenciesConfig chooses whether an item can be completed while
+	// it is blocked by open items.
+	DependenciesConfig struct {
+		BlockCompletion bool `mapstructure:"blockCompletion"`
+	}
+
+	// AttachmentsConfig chooses the storage of the attached files, local or s3,
+	// limits the size and the content types of a file and the total size of
+	// the files of a user, both in bytes, and tells how long a signed download
+	// link stays valid.
+	AttachmentsConfig struct {
+		Storage      string        `mapstructure:"storage"`
+		MaxSize      int64         `mapstructure:"maxSize"`
+		Quota        int64         `mapstructure:"quota"`
+		ContentTypes []string      `mapstructure:"contentTypes"`
+		URLTTL       time.Duration `mapstructure:"urlTTL"`
+		SigningKey   string
+		Local        LocalStorageConfig `mapstructure:"local"`
+		S3           S3Config           `mapstructure:"s3"`
+	}
+
+	LocalStorageConfig struct {
+		Dir string `mapstructure:"dir"`
+	}
+
+	// S3Config points to a bucket of Amazon S3 or of an S3-compatible storage,
+	// MinIO needs path-style addressing.
+	S3Config struct {
+		Endpoint  string        `mapstructure:"endpoint"`
+		Region    string        `mapstructure:"region"`
+		Bucket    string        `mapstructure:"bucket"`
+		PathStyle bool          `mapstructure:"pathStyle"`
+		Timeout   time.Duration `mapstructure:"timeout"`
+		AccessKey string
+		SecretKey string
+	}
 )
 
 // Init populates Config struct with values from config file
@@ -92,9 +197,58 @@ func Init(configsDir string) (Config, error) {
 		return cfg, err
 	}
 
+	if err := validate(cfg); err != nil {
+		return cfg, err
+	}
+
 	return cfg, nil
 }
 
+// validate rejects the settings the application can not work with.
+func validate(cfg Config) error {
+
+	// the workers tick at these intervals, a ticker can not tick at zero
+	if cfg.Trash.PurgeInterval <= 0 {
+		return errors.New("trash.purgeInterval must be positive")
+	}
+
+	// a zero retention would purge the items the moment they are trashed
+	if cfg.Trash.Retention <= 0 {
+		return errors.New("trash.retention must be positive")
+	}
+
+	if cfg.Reminders.DispatchInterval <= 0 {
+		return errors.New("reminders.dispatchInterval must be positive")
+	}
+
+	// the dispatcher claims the reminders in batches of this size
+	if cfg.Reminders.BatchSize <= 0 {
+		return errors.New("reminders.batchSize must be positive")
+	}
+
+	return validateNotifier(cfg.Reminders)
+}
+
+// validateNotifier makes sure the reminders are delivered somewhere: a claimed
+// reminder is marked as sent, so it must not be dropped by a missing notifier.
+func validateNotifier(cfg RemindersConfig) error {
+
+	switch cfg.Notifier {
+	case EmailNotifier:
+		if cfg.SMTP.Host == "" || cfg.SMTP.From == "" {
+			return errors.New("reminders.smtp.host and reminders.smtp.from must be set for the email notifier")
+		}
+	case WebhookNotifier:
+		if cfg.Webhook.URL == "" {
+			return errors.New("reminders.webhook.url must be set for the webhook notifier")
+		}
+	default:
+		return fmt.Errorf("reminders.notifier must be %s or %s, not %q", EmailNotifier, WebhookNotifier, cfg.Notifier)
+	}
+
+	return nil
+}
+
 func unmarshal(cfg *Config) error {
 
 	if err := viper.UnmarshalKey("cache.ttl", &cfg.CacheTTL); err != nil {
@@ -117,6 +271,42 @@ func unmarshal(cfg *Config) error {
 		return err
 	}
 
+	if err := viper.UnmarshalKey("trash", &cfg.Trash); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("reminders", &cfg.Reminders); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("reminders.smtp", &cfg.Reminders.SMTP); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("reminders.webhook", &cfg.Reminders.Webhook); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("subtasks", &cfg.Subtasks); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("dependencies", &cfg.Dependencies); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("attachments", &cfg.Attachments); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("attachments.local", &cfg.Attachments.Local); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("attachments.s3", &cfg.Attachments.S3); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -136,6 +326,10 @@ func setFromEnv(cfg *Config) error {
 	cfg.Postgres.SSLMode = os.Getenv(PostgresSSLMode)
 	cfg.Auth.PasswordSalt = os.Getenv(PasswordSalt)
 	cfg.Auth.JWT.SigningKey = os.Getenv(JwtSigningKey)
+	cfg.Reminders.SMTP.Password = os.Getenv(SmtpPassword)
+	cfg.Attachments.SigningKey = os.Getenv(AttachmentsSigningKey)
+	cfg.Attachments.S3.AccessKey = os.Getenv(S3AccessKey)
+	cfg.Attachments.S3.SecretKey = os.Getenv(S3SecretKey)
 	cfg.HTTP.Host = os.Getenv(HttpHost)
 	cfg.HTTP.Port = os.Getenv(HttpPort)
 	cfg.Environment = os.Getenv(ApplicationEnvironment)
@@ -170,4 +364,19 @@ func populateDefaults() {
 	viper.SetDefault("auth.refreshTokenTTL", defaultRefreshTokenTTL)
 	viper.SetDefault("auth.verificationCodeLength", defaultVerificationCodeLength)
 	viper.SetDefault("postgres.sslmode", defaultSSLMode)
+	viper.SetDefault("trash.retention", defaultTrashRetention)
+	viper.SetDefault("trash.purgeInterval", defaultTrashPurgeInterval)
+	viper.SetDefault("reminders.dispatchInterval", defaultReminderInterval)
+	viper.SetDefault("reminders.batchSize", defaultReminderBatchSize)
+	viper.SetDefault("reminders.smtp.port", defaultSMTPPort)
+	viper.SetDefault("reminders.webhook.timeout", defaultWebhookTimeout)
+	viper.SetDefault("subtasks.maxDepth", defaultSubtasksMaxDepth)
+	viper.SetDefault("attachments.storage", defaultAttachmentsStorage)
+	viper.SetDefault("attachments.maxSize", defaultAttachmentsMaxSize)
+	viper.SetDefault("attachments.quota", defaultAttachmentsQuota)
+	viper.SetDefault("attachments.contentTypes", []string{"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain"})
+	viper.SetDefault("attachments.urlTTL", defaultAttachmentsURLTTL)
+	viper.SetDefault("attachments.local.dir", defaultAttachmentsDir)
+	viper.SetDefault("attachments.s3.region", defaultS3Region)
+	viper.SetDefault("attachments.s3.timeout", defaultS3Timeout)
 }
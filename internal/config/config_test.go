@@ -95,6 +95,40 @@ func TestMain(t *testing.T) {
 					},
 					VerificationCodeLength: 10,
 				},
+				Trash: config.TrashConfig{
+					Retention:     time.Hour * 720,
+					PurgeInterval: time.Hour,
+				},
+				Reminders: config.RemindersConfig{
+					DispatchInterval: time.Second * 30,
+					BatchSize:        100,
+					Notifier:         config.EmailNotifier,
+					SMTP: config.SMTPConfig{
+						Host: "localhost",
+						Port: 587,
+						From: "todo@localhost",
+					},
+					Webhook: config.WebhookConfig{
+						Timeout: time.Second * 10,
+					},
+				},
+				Subtasks: config.SubtasksConfig{
+					MaxDepth: 3,
+				},
+				Attachments: config.AttachmentsConfig{
+					Storage:      config.LocalStorage,
+					MaxSize:      10 << 20,
+					Quota:        100 << 20,
+					ContentTypes: []string{"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain"},
+					URLTTL:       time.Minute * 15,
+					Local: config.LocalStorageConfig{
+						Dir: "./data/attachments",
+					},
+					S3: config.S3Config{
+						Region:  "us-east-1",
+						Timeout: time.Minute,
+					},
+				},
 			},
 		},
 	}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dvln/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+
+	reminders := RemindersConfig{DispatchInterval: 30 * time.Second, BatchSize: 100, Notifier: WebhookNotifier, Webhook: WebhookConfig{URL: "https://example.com/hook"}}
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			cfg:  Config{Trash: TrashConfig{PurgeInterval: time.Hour, Retention: time.Hour}, Reminders: reminders},
+		},
+		{
+			name:    "Zero purge interval",
+			cfg:     Config{Reminders: reminders},
+			wantErr: true,
+		},
+		{
+			name:    "Zero retention",
+			cfg:     Config{Trash: TrashConfig{PurgeInterval: time.Hour}, Reminders: reminders},
+			wantErr: true,
+		},
+		{
+			name:    "Negative dispatch interval",
+			cfg:     Config{Trash: TrashConfig{PurgeInterval: time.Hour, Retention: time.Hour}, Reminders: RemindersConfig{DispatchInterval: -time.Second, BatchSize: 100, Notifier: WebhookNotifier, Webhook: reminders.Webhook}},
+			wantErr: true,
+		},
+		{
+			name:    "Zero batch size",
+			cfg:     Config{Trash: TrashConfig{PurgeInterval: time.Hour, Retention: time.Hour}, Reminders: RemindersConfig{DispatchInterval: 30 * time.Second, Notifier: WebhookNotifier, Webhook: reminders.Webhook}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validate(test.cfg)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateNotifier(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		cfg     RemindersConfig
+		wantErr bool
+	}{
+		{
+			name: "Email",
+			cfg:  RemindersConfig{Notifier: EmailNotifier, SMTP: SMTPConfig{Host: "smtp.example.com", Port: 587, From: "todo@example.com"}},
+		},
+		{
+			name: "Webhook",
+			cfg:  RemindersConfig{Notifier: WebhookNotifier, Webhook: WebhookConfig{URL: "https://example.com/hook"}},
+		},
+		{
+			name:    "Email without host",
+			cfg:     RemindersConfig{Notifier: EmailNotifier, SMTP: SMTPConfig{From: "todo@example.com"}},
+			wantErr: true,
+		},
+		{
+			name:    "Webhook without URL",
+			cfg:     RemindersConfig{Notifier: WebhookNotifier},
+			wantErr: true,
+		},
+		{
+			name:    "Memory",
+			cfg:     RemindersConfig{Notifier: "memory"},
+			wantErr: true,
+		},
+		{
+			name:    "Missing",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateNotifier(test.cfg)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
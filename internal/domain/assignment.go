@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Assignment records a change of the assignee of an item: who assigned the
+// item to whom. The assignee is missing when the item was unassigned, which
+// also happens when its assignee is removed from the list.
+type Assignment struct {
+	Id             int       `json:"id,omitempty" db:"id"`
+	ItemId         int       `json:"item_id,omitempty" db:"item_id"`
+	AssigneeId     *int      `json:"assignee_id,omitempty" db:"assignee_id"`
+	AssigneeName   *string   `json:"assignee_name,omitempty" db:"assignee_name"`
+	AssignedById   *int      `json:"assigned_by_id,omitempty" db:"assigned_by"`
+	AssignedByName *string   `json:"assigned_by_name,omitempty" db:"assigned_by_name"`
+	AssignedAt     time.Time `json:"assigned_at" db:"assigned_at"`
+}
+
+// TodoItemAssigneeInput assigns the item to a member of its list,
+// the item is unassigned when the assignee id is missing.
+type TodoItemAssigneeInput struct {
+	AssigneeId *int `json:"assignee_id"`
+}
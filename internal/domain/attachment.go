@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"io"
+	"time"
+)
+
+// Attachment is a file attached to the item by one of the users sharing its
+// list. The file is kept in the blob store under the storage key, the size
+// of the file counts against the storage quota of the user who uploaded it.
+type Attachment struct {
+	Id          int        `json:"id,omitempty" db:"id"`
+	ItemId      int        `json:"item_id,omitempty" db:"item_id"`
+	UploaderId  int        `json:"uploader_id,omitempty" db:"user_id"`
+	Name        string     `json:"name,omitempty" db:"name"`
+	ContentType string     `json:"content_type,omitempty" db:"content_type"`
+	Size        int64      `json:"size,omitempty" db:"size"`
+	StorageKey  string     `json:"-" db:"storage_key"`
+	CreatedAt   *time.Time `json:"created_at,omitempty" db:"created_at"`
+}
+
+// Upload is a file on its way to become an attachment.
+type Upload struct {
+	Name    string
+	Size    int64
+	Content io.ReadSeeker
+}
+
+// AttachmentURL is a link the attachment can be downloaded by without
+// signing in until the link expires.
+type AttachmentURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
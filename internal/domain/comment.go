@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Comment is a message about the item from one of the users sharing its list.
+// A reply to another comment of the same item has the id of that comment as
+// its parent, the replies come nested in the comment they reply to.
+type Comment struct {
+	Id         int        `json:"id,omitempty" db:"id"`
+	ItemId     int        `json:"item_id,omitempty" db:"item_id"`
+	ParentId   *int       `json:"parent_id,omitempty" db:"parent_id"`
+	AuthorId   int        `json:"author_id,omitempty" db:"user_id"`
+	AuthorName string     `json:"author_name,omitempty" db:"author_name"`
+	Body       string     `json:"body,omitempty" db:"body" validate:"nonzero,max=10000"`
+	CreatedAt  *time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	Replies    []Comment  `json:"replies,omitempty" db:"-"`
+}
+
+type UpdateCommentInput struct {
+	Body string `json:"body" validate:"nonzero,max=10000"`
+}
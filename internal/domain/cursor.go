@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// CursorPage is a part of a long listing read in its stable order: at most
+// Limit entries following the entry the cursor of the previous page points
+// at, the first entries when the cursor is empty. A zero limit stands for
+// the default one, the whole listing is read when neither is given.
+type CursorPage struct {
+	Limit  int
+	Cursor string
+}
+
+func (p CursorPage) Validate() error {
+
+	if p.Limit < 0 || p.Limit > MaxPageLimit {
+		return ErrInvalidCursorPage
+	}
+
+	return nil
+}
+
+// Paged tells whether only a part of the listing is asked for.
+func (p CursorPage) Paged() bool {
+	return p.Limit > 0 || p.Cursor != ""
+}
+
+// PageLimit is the number of entries asked for, the default one for a zero limit.
+func (p CursorPage) PageLimit() int {
+
+	if p.Limit == 0 {
+		return DefaultPageLimit
+	}
+
+	return p.Limit
+}
+
+// ListKey is the place of a list among the lists of the user:
+// the pinned lists first, then by position.
+type ListKey struct {
+	Pinned   bool   `json:"pinned"`
+	Position string `json:"position"`
+	Id       int    `json:"id"`
+}
+
+// ItemKey is the place of an item among the items of its list: by position
+// or, when the items are ordered by priority, the most urgent first.
+type ItemKey struct {
+	Priority Priority `json:"priority"`
+	Position string   `json:"position"`
+	Id       int      `json:"id"`
+}
+
+// EncodeCursor turns the key of the last entry of a page into an opaque cursor.
+func EncodeCursor(key interface{}) (string, error) {
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor reads the key of the entry the cursor points at.
+func DecodeCursor(cursor string, key interface{}) error {
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursorPage
+	}
+
+	if err := json.Unmarshal(data, key); err != nil {
+		return ErrInvalidCursorPage
+	}
+
+	return nil
+}
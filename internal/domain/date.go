@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+const (
+	DateLayout      = "2006-01-02"
+	TimeOfDayLayout = "15:04"
+)
+
+// Date is a calendar date without a time zone, e.g. 2023-05-01. The empty
+// date is stored as NULL.
+type Date string
+
+func (d Date) Validate() error {
+
+	if _, err := time.Parse(DateLayout, string(d)); err != nil {
+		return ErrInvalidDate
+	}
+
+	return nil
+}
+
+func (d *Date) Scan(src interface{}) error {
+
+	switch v := src.(type) {
+	case nil:
+		*d = ""
+	case time.Time:
+		*d = Date(v.Format(DateLayout))
+	case []byte:
+		return d.parse(string(v))
+	case string:
+		return d.parse(v)
+	default:
+		return fmt.Errorf("unable to scan %T into a date", src)
+	}
+
+	return nil
+}
+
+func (d *Date) parse(s string) error {
+
+	t, err := time.Parse(DateLayout, truncate(s, len(DateLayout)))
+	if err != nil {
+		return err
+	}
+
+	*d = Date(t.Format(DateLayout))
+
+	return nil
+}
+
+func (d Date) Value() (driver.Value, error) {
+
+	if d == "" {
+		return nil, nil
+	}
+
+	return string(d), nil
+}
+
+// TimeOfDay is a wall clock time in hours and minutes, e.g. 09:30. The empty
+// time is stored as NULL.
+type TimeOfDay string
+
+func (t TimeOfDay) Validate() error {
+
+	if _, err := time.Parse(TimeOfDayLayout, string(t)); err != nil {
+		return ErrInvalidTimeOfDay
+	}
+
+	return nil
+}
+
+func (t *TimeOfDay) Scan(src interface{}) error {
+
+	switch v := src.(type) {
+	case nil:
+		*t = ""
+	case time.Time:
+		*t = TimeOfDay(v.Format(TimeOfDayLayout))
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	default:
+		return fmt.Errorf("unable to scan %T into a time of day", src)
+	}
+
+	return nil
+}
+
+func (t *TimeOfDay) parse(s string) error {
+
+	parsed, err := time.Parse(TimeOfDayLayout, truncate(s, len(TimeOfDayLayout)))
+	if err != nil {
+		return err
+	}
+
+	*t = TimeOfDay(parsed.Format(TimeOfDayLayout))
+
+	return nil
+}
+
+func (t TimeOfDay) Value() (driver.Value, error) {
+
+	if t == "" {
+		return nil, nil
+	}
+
+	return string(t), nil
+}
+
+// Timestamp is a point in time in the RFC 3339 format, e.g.
+// 2023-05-01T09:00:00Z. The empty timestamp is stored as NULL.
+type Timestamp string
+
+func (t Timestamp) Validate() error {
+
+	if _, err := time.Parse(time.RFC3339, string(t)); err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	return nil
+}
+
+func (t *Timestamp) Scan(src interface{}) error {
+
+	switch v := src.(type) {
+	case nil:
+		*t = ""
+	case time.Time:
+		*t = Timestamp(v.UTC().Format(time.RFC3339))
+	default:
+		return fmt.Errorf("unable to scan %T into a timestamp", src)
+	}
+
+	return nil
+}
+
+func (t Timestamp) Value() (driver.Value, error) {
+
+	if t == "" {
+		return nil, nil
+	}
+
+	return time.Parse(time.RFC3339, string(t))
+}
+
+// truncate drops the parts the database adds to the value, such as seconds
+// of a time or the time of a timestamp.
+func truncate(s string, n int) string {
+
+	if len(s) > n {
+		return s[:n]
+	}
+
+	return s
+}
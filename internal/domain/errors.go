@@ -0,0 +1,67 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidMove  = errors.New("invalid move anchors")
+	ErrItemNotFound = errors.New("the item or the target list does not exist or is in the trash")
+	ErrNotTemplate  = errors.New("the list is not a template")
+	ErrFolderCycle  = errors.New("a folder can not be moved into itself or its subfolder")
+	ErrEmptyFolder  = errors.New("the folder name can not be empty")
+
+	ErrInvalidDate        = errors.New("the date must be in the YYYY-MM-DD format")
+	ErrInvalidTimeOfDay   = errors.New("the time must be in the HH:MM format")
+	ErrDueTimeWithoutDate = errors.New("the due time requires a due date")
+	ErrRRuleWithoutDate   = errors.New("a recurring item requires a due date")
+	ErrInvalidTimezone    = errors.New("unknown timezone")
+	ErrInvalidTimestamp   = errors.New("the time must be in the RFC 3339 format")
+
+	ErrInvalidPriority  = errors.New("the priority must be one of none, low, medium, high or urgent")
+	ErrInvalidItemOrder = errors.New("the items can only be ordered by position or priority")
+
+	ErrTagExists = errors.New("a tag with the same name already exists")
+
+	ErrInvalidParent  = errors.New("the parent item has to be in the same list")
+	ErrSubtaskCycle   = errors.New("an item can not be a subtask of itself or its subtask")
+	ErrSubtaskTooDeep = errors.New("the subtasks are nested too deep")
+
+	ErrInvalidDeferral    = errors.New("an item can only be snoozed for a positive duration")
+	ErrTaskNotFound       = errors.New("the description has no such task")
+	ErrDescriptionChanged = errors.New("the description keeps being changed, try again")
+
+	ErrInvalidReminder = errors.New("a reminder needs either a time or a number of minutes before the due time")
+	ErrInvalidSnooze   = errors.New("a reminder can only be snoozed for a positive number of minutes")
+
+	ErrUserNotFound          = errors.New("there is no user with this email")
+	ErrAlreadyMember         = errors.New("the list is already shared with the user")
+	ErrLastMember            = errors.New("the last member can not leave the list")
+	ErrInvalidAssignee       = errors.New("the assignee has to be a member of the list")
+	ErrInvalidAssigneeFilter = errors.New("the assignee must be me, none or a user id")
+
+	ErrInvalidBlocker  = errors.New("an item can only be blocked by another item of your lists")
+	ErrDependencyCycle = errors.New("an item can not be blocked by an item it blocks")
+	ErrItemBlocked     = errors.New("the item can not be done while it is blocked by open items")
+
+	ErrInvalidEstimate   = errors.New("the estimate can not be negative")
+	ErrInvalidTimeEntry  = errors.New("a time entry has to end after it starts")
+	ErrTimerRunning      = errors.New("another timer has just been started")
+	ErrNoRunningTimer    = errors.New("there is no running timer")
+	ErrInvalidTimeReport = errors.New("the report needs a from and a to date, from being on or before to")
+
+	ErrStatusExists    = errors.New("the list already has a status with this name")
+	ErrInvalidStatus   = errors.New("an item can only be moved into a status of its list")
+	ErrInvalidWipLimit = errors.New("the WIP limit must be positive")
+	ErrWipLimit        = errors.New("the status has reached its WIP limit")
+
+	ErrInvalidPage          = errors.New("the limit must be between 1 and 100 and the offset must not be negative")
+	ErrInvalidCursorPage    = errors.New("the limit must be between 1 and 100 and the cursor must come from a previous page")
+	ErrNestedPage           = errors.New("nested items can not be paged, drop either nested or limit and cursor")
+	ErrInvalidCommentParent = errors.New("a comment can only reply to a comment on the same item")
+
+	ErrRevisionNotFound = errors.New("the item has no such revision")
+
+	ErrAttachmentTooLarge  = errors.New("the file is too large")
+	ErrAttachmentType      = errors.New("files of this type can not be attached")
+	ErrStorageQuota        = errors.New("the storage quota is exceeded")
+	ErrInvalidDownloadLink = errors.New("the download link is invalid or has expired")
+)
@@ -0,0 +1,32 @@
+package domain
+
+type Folder struct {
+	Id       int    `json:"id,omitempty" db:"id"`
+	ParentId *int   `json:"parent_id,omitempty" db:"parent_id"`
+	Name     string `json:"name,omitempty" db:"name" validate:"nonzero"`
+	Position string `json:"position,omitempty" db:"position"`
+}
+
+type UpdateFolderInput struct {
+	Name *string `json:"name"`
+}
+
+// MoveFolderInput puts the folder into the parent folder, or to the top level
+// when the parent is missing, and places it between the given siblings.
+type MoveFolderInput struct {
+	ParentId *int `json:"parent_id"`
+	MoveInput
+}
+
+// FolderTree is the folder hierarchy of a user together with the lists
+// which are kept in every folder.
+type FolderTree struct {
+	Folders []FolderNode `json:"folders"`
+	Lists   []TodoList   `json:"lists"`
+}
+
+type FolderNode struct {
+	Folder
+	Folders []FolderNode `json:"folders"`
+	Lists   []TodoList   `json:"lists"`
+}
@@ -1,14 +1,148 @@
 package domain
 
+import "time"
+
+// TodoItem is an item of a todo-list.
 type TodoItem struct {
-	Id          int    `json:"id,omitempty" db:"id"`
-	Title       string `json:"title,omitempty" db:"title" validate:"nonzero"`
-	Description string `json:"description,omitempty" db:"description"`
-	Done        bool   `json:"done,omitempty" db:"done"`
+	Id     int `json:"id,omitempty" db:"id"`
+	ListId int `json:"list_id,omitempty" db:"list_id"`
+
+	// ParentId refers to the parent item of the same list of a subtask.
+	ParentId *int   `json:"parent_id,omitempty" db:"parent_id"`
+	Title    string `json:"title,omitempty" db:"title" validate:"nonzero"`
+
+	// Description is Markdown, DescriptionHTML holds it rendered into
+	// sanitized HTML when asked for.
+	Description     string `json:"description,omitempty" db:"description" validate:"max=20000"`
+	DescriptionHTML string `json:"description_html,omitempty" db:"-"`
+
+	// Done follows the status of the item in a list with workflow statuses.
+	Done     bool     `json:"done,omitempty" db:"done"`
+	Priority Priority `json:"priority,omitempty" db:"priority"`
+
+	// DueDate and DueTime are read in the timezone of the user. DueAt is the
+	// moment the item becomes overdue: the due time or the end of the due date.
+	DueDate Date       `json:"due_date,omitempty" db:"due_date"`
+	DueTime TimeOfDay  `json:"due_time,omitempty" db:"due_time"`
+	DueAt   *time.Time `json:"due_at,omitempty" db:"due_at"`
+
+	// RRule is the RFC 5545 recurrence rule of a recurring item, which needs a
+	// due date. Once the item is completed, the next occurrence is due on the
+	// next date of the rule after the due date or, with RepeatFromCompletion,
+	// after the date the item was completed on.
+	RRule                string `json:"rrule,omitempty" db:"rrule"`
+	RepeatFromCompletion bool   `json:"repeat_from_completion,omitempty" db:"repeat_from_completion"`
+
+	// AssigneeId refers to the member of the list the item is assigned to.
+	AssigneeId *int `json:"assignee_id,omitempty" db:"assignee_id"`
+
+	// Blocked tells whether any of the items the item is blocked by is open.
+	Blocked bool `json:"blocked,omitempty" db:"blocked"`
+
+	// EstimateMinutes is the time the item is expected to take.
+	EstimateMinutes int  `json:"estimate_minutes,omitempty" db:"estimate_minutes"`
+	StatusId        *int `json:"status_id,omitempty" db:"status_id"`
+
+	// DeferUntil leaves the item out of the lists of items until it has passed.
+	DeferUntil *time.Time `json:"defer_until,omitempty" db:"defer_until"`
+	Tags       []Tag      `json:"tags,omitempty" db:"-"`
+	Position   string     `json:"position,omitempty" db:"position"`
+	CreatedAt  *time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+
+	// CompletedAt is the time the item was last marked done, it is cleared
+	// once the item is reopened.
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Children holds the subtasks when the items are returned nested.
+	Children []TodoItem `json:"children,omitempty" db:"-"`
 }
 
 type UpdateTodoItemInput struct {
 	Title       *string `json:"title"`
-	Description *string `json:"description"`
-	Done        *bool   `json:"done"`
+	Description *string `json:"description" validate:"max=20000"`
+
+	// Done moves the item of a list with workflow statuses into
+	// the first status of the list which is done or open accordingly.
+	Done     *bool     `json:"done"`
+	Priority *Priority `json:"priority"`
+
+	// DueDate and DueTime are cleared by an empty string, clearing
+	// the due date clears the due time as well.
+	DueDate *Date      `json:"due_date"`
+	DueTime *TimeOfDay `json:"due_time"`
+
+	// RRule makes the item recurring, an empty string stops the recurrence.
+	RRule                *string `json:"rrule"`
+	RepeatFromCompletion *bool   `json:"repeat_from_completion"`
+
+	// EstimateMinutes is cleared by zero.
+	EstimateMinutes *int `json:"estimate_minutes"`
+
+	// DeferUntil hides the item until the given time, a time
+	// which has passed or an empty string shows the item again.
+	DeferUntil *Timestamp `json:"defer_until"`
+}
+
+// DescriptionTaskInput checks or unchecks a task of the task lists of the description.
+type DescriptionTaskInput struct {
+	Done bool `json:"done"`
+}
+
+// SnoozeTodoItemInput defers the item by the given duration from the time it
+// is deferred until or from now, whichever is later.
+type SnoozeTodoItemInput struct {
+	Days    int `json:"days"`
+	Hours   int `json:"hours"`
+	Minutes int `json:"minutes"`
+}
+
+const (
+	ItemOrderPosition = "position"
+	ItemOrderPriority = "priority"
+
+	// AssigneeMe filters the items assigned to the user asking for them,
+	// AssigneeNone the unassigned items.
+	AssigneeMe   = "me"
+	AssigneeNone = "none"
+)
+
+// TodoItemFilter narrows the items down to the items due within the given
+// dates, both ends are inclusive and optional, to the items of the given
+// priorities, to the items labelled with the given tag of the user and to
+// the items of the given assignee: me, none or the id of a user. The items
+// come in the user-defined order unless they are ordered by priority, the
+// most urgent first. Nested puts the subtasks into their parents instead of
+// returning all the items in a flat list. The deferred items are left out
+// unless they are included.
+type TodoItemFilter struct {
+	DueFrom         Date
+	DueTo           Date
+	Priorities      []Priority
+	Tag             string
+	OrderBy         string
+	Nested          bool
+	Assignee        string
+	IncludeDeferred bool
+}
+
+// TodoItemParentInput makes the item a subtask of the parent item,
+// the item becomes a top-level one when the parent id is missing.
+type TodoItemParentInput struct {
+	ParentId *int `json:"parent_id"`
+}
+
+// CompletionRollUp tells whether completing an item completes its subtasks
+// and whether completing the last open subtask completes its parent.
+type CompletionRollUp struct {
+	Children bool
+	Parent   bool
+}
+
+// TransferTodoItemInput puts the item, or a copy of it, into the given list and
+// between the given neighbours. The item stays in its list when the list id is missing.
+type TransferTodoItemInput struct {
+	ListId *int `json:"list_id"`
+	MoveInput
 }
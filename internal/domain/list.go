@@ -1,12 +1,51 @@
 package domain
 
+import "time"
+
 type TodoList struct {
-	Id          int    `json:"id,omitempty" db:"id"`
-	Title       string `json:"title,omitempty" db:"title" validate:"nonzero"`
-	Description string `json:"description,omitempty" db:"description"`
+	Id          int            `json:"id,omitempty" db:"id"`
+	Title       string         `json:"title,omitempty" db:"title" validate:"nonzero"`
+	Description string         `json:"description,omitempty" db:"description"`
+	IsTemplate  bool           `json:"is_template,omitempty" db:"is_template"`
+	Color       string         `json:"color,omitempty" db:"color" validate:"regexp=^(#[0-9a-fA-F]{6})?$"`
+	Icon        string         `json:"icon,omitempty" db:"icon" validate:"max=64"`
+	Pinned      bool           `json:"pinned,omitempty" db:"pinned"`
+	FolderId    *int           `json:"folder_id,omitempty" db:"folder_id"`
+	Position    string         `json:"position,omitempty" db:"position"`
+	CreatedAt   *time.Time     `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt   *time.Time     `json:"updated_at,omitempty" db:"updated_at"`
+	DeletedAt   *time.Time     `json:"deleted_at,omitempty" db:"deleted_at"`
+	Stats       *TodoListStats `json:"stats,omitempty" db:"stats"`
+}
+
+// TodoListStats is the progress of a list. Overdue items are not done items
+// past their due date. LastActivityAt is the time of the latest change to any
+// of its items and is missing for a list without items.
+type TodoListStats struct {
+	TotalItems     int        `json:"total_items" db:"total_items"`
+	CompletedItems int        `json:"completed_items" db:"completed_items"`
+	OverdueItems   int        `json:"overdue_items" db:"overdue_items"`
+	LastActivityAt *time.Time `json:"last_activity_at" db:"last_activity_at"`
 }
 
 type UpdateTodoListInput struct {
 	Title       *string `json:"title"`
 	Description *string `json:"description"`
+	IsTemplate  *bool   `json:"is_template"`
+	Color       *string `json:"color" validate:"regexp=^(#[0-9a-fA-F]{6})?$"`
+	Icon        *string `json:"icon" validate:"max=64"`
+	Pinned      *bool   `json:"pinned"`
+}
+
+// CopyTodoListInput describes a new list created from an existing list or template.
+// When ResetDone is set every copied item starts as not done.
+type CopyTodoListInput struct {
+	Title     *string `json:"title"`
+	ResetDone bool    `json:"reset_done"`
+}
+
+// TodoListFolderInput puts the list into the folder, a missing folder id moves
+// the list out of any folder.
+type TodoListFolderInput struct {
+	FolderId *int `json:"folder_id"`
 }
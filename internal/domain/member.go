@@ -0,0 +1,14 @@
+package domain
+
+// Member is a user the list is shared with. All the members of a list have
+// the same rights, any of them can share the list with another user.
+type Member struct {
+	UserId int    `json:"user_id,omitempty" db:"id"`
+	Name   string `json:"name,omitempty" db:"name"`
+	Email  string `json:"email,omitempty" db:"email"`
+}
+
+// ShareTodoListInput shares the list with the user signed up with the email.
+type ShareTodoListInput struct {
+	Email string `json:"email" validate:"nonzero"`
+}
@@ -0,0 +1,10 @@
+package domain
+
+// MoveInput places a list or an item between its siblings. AfterId is the sibling
+// which ends up right before the moved element and BeforeId is the one which ends
+// up right after it. Either can be omitted; when both are omitted the element is
+// moved to the end.
+type MoveInput struct {
+	AfterId  *int `json:"after_id"`
+	BeforeId *int `json:"before_id"`
+}
@@ -0,0 +1,22 @@
+package domain
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Page is a part of a long listing: at most Limit entries after skipping
+// the first Offset ones. A zero limit stands for the default one.
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+func (p Page) Validate() error {
+
+	if p.Limit < 0 || p.Limit > MaxPageLimit || p.Offset < 0 {
+		return ErrInvalidPage
+	}
+
+	return nil
+}
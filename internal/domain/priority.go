@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Priority of an item from none to urgent. Priorities are stored as
+// their levels, so the items can be ordered by them.
+type Priority string
+
+const (
+	PriorityNone   Priority = "none"
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+var priorities = []Priority{PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
+// level returns the position of the priority, the empty priority is none.
+func (p Priority) level() int {
+
+	if p == "" {
+		return 0
+	}
+
+	for level, priority := range priorities {
+		if priority == p {
+			return level
+		}
+	}
+
+	return -1
+}
+
+func (p Priority) Validate() error {
+
+	if p.level() < 0 {
+		return ErrInvalidPriority
+	}
+
+	return nil
+}
+
+func (p *Priority) Scan(src interface{}) error {
+
+	level, ok := src.(int64)
+	if !ok || level < 0 || int(level) >= len(priorities) {
+		return fmt.Errorf("unable to scan %v into a priority", src)
+	}
+
+	*p = priorities[level]
+
+	return nil
+}
+
+func (p Priority) Value() (driver.Value, error) {
+
+	level := p.level()
+	if level < 0 {
+		return nil, ErrInvalidPriority
+	}
+
+	return int64(level), nil
+}
@@ -0,0 +1,23 @@
+package domain
+
+// QuickAddInput is a line of text describing an item, such as
+// "Pay rent tomorrow 9am #home !high every month".
+type QuickAddInput struct {
+	Text string `json:"text" validate:"nonzero,max=1000"`
+}
+
+// QuickAddToken is a part of the text recognised as a date, time, tag,
+// priority or recurrence and the value it was read as.
+type QuickAddToken struct {
+	Text  string `json:"text"`
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// QuickAdd is the item read out of a quick-add text and the parts of the
+// text it was read from. A dry run only previews the item, otherwise the
+// item is created and has an id.
+type QuickAdd struct {
+	Item       TodoItem        `json:"item"`
+	Recognised []QuickAddToken `json:"recognised"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Reminder fires at the given moment or the given number of minutes before
+// the item is due. A fired reminder can be snoozed to fire once again later.
+type Reminder struct {
+	Id            int        `json:"id,omitempty" db:"id"`
+	ItemId        int        `json:"item_id,omitempty" db:"item_id"`
+	RemindAt      *time.Time `json:"remind_at,omitempty" db:"remind_at"`
+	MinutesBefore *int       `json:"minutes_before,omitempty" db:"minutes_before"`
+	SnoozedUntil  *time.Time `json:"snoozed_until,omitempty" db:"snoozed_until"`
+	FireAt        *time.Time `json:"fire_at,omitempty" db:"fire_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty" db:"sent_at"`
+}
+
+type SnoozeReminderInput struct {
+	Minutes int `json:"minutes"`
+}
+
+// ReminderDelivery is a fired reminder together with the item it reminds
+// of and the user who has to be notified.
+type ReminderDelivery struct {
+	ReminderId int        `db:"id"`
+	ItemId     int        `db:"item_id"`
+	ItemTitle  string     `db:"title"`
+	DueAt      *time.Time `db:"due_at"`
+	Email      string     `db:"email"`
+	Name       string     `db:"name"`
+	Timezone   string     `db:"timezone"`
+}
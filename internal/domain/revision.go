@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Revision records a change made to an item: the fields it changed, who
+// changed them and when. The author is missing once the user is deleted.
+type Revision struct {
+	Id         int       `json:"id,omitempty" db:"id"`
+	ItemId     int       `json:"item_id,omitempty" db:"item_id"`
+	AuthorId   *int      `json:"author_id,omitempty" db:"user_id"`
+	AuthorName *string   `json:"author_name,omitempty" db:"author_name"`
+	Changes    Changes   `json:"changes" db:"changes"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Change holds the values of a field before and after a revision.
+type Change struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// Changes maps the names of the changed fields, named as in the JSON of
+// an item, to their values. The changes are stored as a JSON object.
+type Changes map[string]Change
+
+// Revert returns the input which changes the fields back to their values
+// before the revision.
+func (c Changes) Revert() (UpdateTodoItemInput, error) {
+
+	values := make(map[string]interface{}, len(c))
+	for field, change := range c {
+		values[field] = change.Old
+	}
+
+	var input UpdateTodoItemInput
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return input, err
+	}
+
+	err = json.Unmarshal(data, &input)
+
+	return input, err
+}
+
+func (c *Changes) Scan(src interface{}) error {
+
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("unable to scan %T into changes", src)
+	}
+}
+
+func (c Changes) Value() (driver.Value, error) {
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,40 @@
+package domain
+
+// Status is a workflow status of a list, a column of its board. The statuses
+// of a list are ordered by their position. An item in a status marked as done
+// is done, an item in any other status is open. A status may limit the number
+// of items in it, the limit is checked when an item is moved into the status.
+type Status struct {
+	Id       int    `json:"id,omitempty" db:"id"`
+	ListId   int    `json:"list_id,omitempty" db:"list_id"`
+	Name     string `json:"name,omitempty" db:"name" validate:"nonzero,max=64"`
+	Done     bool   `json:"done,omitempty" db:"done"`
+	WipLimit *int   `json:"wip_limit,omitempty" db:"wip_limit"`
+	Position string `json:"position,omitempty" db:"position"`
+}
+
+// UpdateStatusInput changes the status. Changing whether the status is done
+// completes or reopens the items in it, the WIP limit is cleared by zero.
+type UpdateStatusInput struct {
+	Name     *string `json:"name" validate:"min=1,max=64"`
+	Done     *bool   `json:"done"`
+	WipLimit *int    `json:"wip_limit"`
+}
+
+// TodoItemStatusInput moves the item into the given status of its list.
+type TodoItemStatusInput struct {
+	StatusId int `json:"status_id" validate:"nonzero"`
+}
+
+// BoardColumn is a status together with its items in the order of the list.
+type BoardColumn struct {
+	Status Status     `json:"status"`
+	Items  []TodoItem `json:"items"`
+}
+
+// Board groups the items of a list by their status. The items no status of
+// the list fits, such as done items of a list without a done status, are unsorted.
+type Board struct {
+	Columns  []BoardColumn `json:"columns"`
+	Unsorted []TodoItem    `json:"unsorted,omitempty"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+// Tag labels items across all the lists of a user. Tags are private to the user
+// who created them, on the items of shared lists every user sees only their own tags.
+type Tag struct {
+	Id    int    `json:"id,omitempty" db:"id"`
+	Name  string `json:"name,omitempty" db:"name" validate:"nonzero,max=64"`
+	Color string `json:"color,omitempty" db:"color" validate:"regexp=^(#[0-9a-fA-F]{6})?$"`
+}
+
+type UpdateTagInput struct {
+	Name  *string `json:"name" validate:"min=1,max=64"`
+	Color *string `json:"color" validate:"regexp=^(#[0-9a-fA-F]{6})?$"`
+}
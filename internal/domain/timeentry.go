@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// TimeEntry is a span of time a user spent on an item. A running timer is an
+// entry without an end, its duration, in seconds, grows until it is stopped.
+type TimeEntry struct {
+	Id        int        `json:"id,omitempty" db:"id"`
+	ItemId    int        `json:"item_id,omitempty" db:"item_id"`
+	UserId    int        `json:"user_id,omitempty" db:"user_id"`
+	StartedAt time.Time  `json:"started_at" db:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty" db:"ended_at"`
+	Duration  int64      `json:"duration" db:"duration"`
+}
+
+// UpdateTimeEntryInput moves the start or the end of an entry,
+// setting the end of a running timer stops it.
+type UpdateTimeEntryInput struct {
+	StartedAt *time.Time `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+// TimeTotal sums up the time spent on an item or on the items of a list,
+// in seconds, and the time they are estimated to take, in minutes.
+type TimeTotal struct {
+	SpentSeconds    int64 `json:"spent_seconds" db:"spent_seconds"`
+	EstimateMinutes int   `json:"estimate_minutes" db:"estimate_minutes"`
+}
+
+// TimeReportFilter narrows the time report down to the days from From to To, both inclusive.
+type TimeReportFilter struct {
+	From Date
+	To   Date
+}
+
+// TimeReportRow is the time, in seconds, the user spent on the items of a list
+// on a day of their timezone. An entry spanning midnight counts towards both days.
+type TimeReportRow struct {
+	ListId    int    `json:"list_id" db:"list_id"`
+	ListTitle string `json:"list_title" db:"list_title"`
+	Date      Date   `json:"date" db:"date"`
+	Seconds   int64  `json:"seconds" db:"seconds"`
+}
@@ -0,0 +1,8 @@
+package domain
+
+// Trash holds the lists and items a user has deleted but which
+// have not been purged yet.
+type Trash struct {
+	Lists []TodoList `json:"lists"`
+	Items []TodoItem `json:"items"`
+}
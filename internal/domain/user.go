@@ -1,8 +1,17 @@
 package domain
 
+import "time"
+
 type User struct {
-	Id       int    `json:"id,omitempty" db:"id"`
-	Name     string `json:"name,omitempty" db:"name" validate:"min=3, max=40"`
-	Email    string `json:"email,omitempty" db:"email" validate:"nonzero"`
-	Password string `json:"password,omitempty" db:"password_hash" validate:"min=6"`
+	Id        int        `json:"id,omitempty" db:"id"`
+	Name      string     `json:"name,omitempty" db:"name" validate:"min=3, max=40"`
+	Email     string     `json:"email,omitempty" db:"email" validate:"nonzero"`
+	Password  string     `json:"password,omitempty" db:"password_hash" validate:"min=6"`
+	Timezone  string     `json:"timezone,omitempty" db:"timezone"`
+	CreatedAt *time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+type TimezoneInput struct {
+	Timezone string `json:"timezone"`
 }
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+)
+
+const (
+	itemAssignmentsTable = "item_assignments"
+)
+
+// Assign assigns the item to a member of its list or unassigns it when the assignee is missing.
+// A change of the assignee is recorded together with the user who made it.
+func (r *postgresTodoItemRepository) Assign(ctx context.Context, userId, itemId int, assigneeId *int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	listId, err := targetList(tx, userId, itemId, nil)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if assigneeId != nil {
+		var member bool
+		memberQuery := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE list_id = $1 AND user_id = $2)", usersListsTable)
+		if err := tx.QueryRow(memberQuery, listId, *assigneeId).Scan(&member); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if !member {
+			tx.Rollback()
+			return domain.ErrInvalidAssignee
+		}
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET assignee_id = $1, updated_at = now() WHERE id = $2 AND deleted_at IS NULL AND assignee_id IS DISTINCT FROM $1",
+		todoItemsTable)
+	result, err := tx.Exec(query, assigneeId, itemId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affected > 0 {
+		recordQuery := fmt.Sprintf("INSERT INTO %s (item_id, assignee_id, assigned_by) VALUES ($1, $2, $3)", itemAssignmentsTable)
+		if _, err := tx.Exec(recordQuery, itemId, assigneeId, userId); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetAssignments returns the history of the assignees of the item, the oldest change first.
+func (r *postgresTodoItemRepository) GetAssignments(ctx context.Context, userId, itemId int) ([]domain.Assignment, error) {
+
+	var assignments []domain.Assignment
+	query := fmt.Sprintf(`SELECT a.id, a.item_id, a.assignee_id, assignee.name AS assignee_name, a.assigned_by, assigner.name AS assigned_by_name, a.assigned_at FROM %s a
+									INNER JOIN %s li on li.item_id = a.item_id INNER JOIN %s ul on ul.list_id = li.list_id
+									LEFT JOIN %s assignee on assignee.id = a.assignee_id LEFT JOIN %s assigner on assigner.id = a.assigned_by
+									WHERE a.item_id = $1 AND ul.user_id = $2 ORDER BY a.assigned_at, a.id`,
+		itemAssignmentsTable, listsItemsTable, usersListsTable, usersTable, usersTable)
+	if err := r.db.Select(&assignments, query, itemId, userId); err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+// unassignNonMembers unassigns the items of the list whose assignees are not members
+// of the list anymore, the user is recorded as the one who unassigned them.
+func unassignNonMembers(tx *sql.Tx, userId, listId int) error {
+
+	query := fmt.Sprintf(`WITH unassigned AS (
+									UPDATE %s ti SET assignee_id = NULL, updated_at = now() FROM %s li
+									WHERE li.item_id = ti.id AND li.list_id = $1 AND ti.assignee_id IS NOT NULL
+									AND NOT EXISTS (SELECT 1 FROM %s ul WHERE ul.list_id = li.list_id AND ul.user_id = ti.assignee_id)
+									RETURNING ti.id
+								)
+								INSERT INTO %s (item_id, assigned_by) SELECT id, $2 FROM unassigned`,
+		todoItemsTable, listsItemsTable, usersListsTable, itemAssignmentsTable)
+	_, err := tx.Exec(query, listId, userId)
+
+	return err
+}
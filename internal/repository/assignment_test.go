@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestTodoItem_Assign(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	type (
+		args struct {
+			userId     int
+			itemId     int
+			assigneeId *int
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      error
+		}
+	)
+
+	listQuery := fmt.Sprintf("SELECT li.list_id FROM %s li INNER JOIN %s ul on (.+) WHERE (.+)", listsItemsTable, usersListsTable)
+	memberQuery := fmt.Sprintf("SELECT EXISTS \\(SELECT 1 FROM %s WHERE list_id = \\$1 AND user_id = \\$2\\)", usersListsTable)
+	updateQuery := fmt.Sprintf("UPDATE %s SET assignee_id = \\$1, updated_at = now\\(\\) WHERE id = \\$2 AND deleted_at IS NULL AND assignee_id IS DISTINCT FROM \\$1",
+		todoItemsTable)
+	recordQuery := fmt.Sprintf("INSERT INTO %s \\(item_id, assignee_id, assigned_by\\) VALUES \\(\\$1, \\$2, \\$3\\)", itemAssignmentsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(memberQuery).WithArgs(7, *args.assigneeId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectExec(updateQuery).WithArgs(*args.assigneeId, args.itemId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(recordQuery).WithArgs(args.itemId, *args.assigneeId, args.userId).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, itemId: 2, assigneeId: intPointer(3)},
+		},
+		{
+			name: "Ok_Unassign",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectExec(updateQuery).WithArgs(nil, args.itemId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(recordQuery).WithArgs(args.itemId, nil, args.userId).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, itemId: 2},
+		},
+		{
+			name: "Ok_Unchanged",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(memberQuery).WithArgs(7, *args.assigneeId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectExec(updateQuery).WithArgs(*args.assigneeId, args.itemId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, itemId: 2, assigneeId: intPointer(3)},
+		},
+		{
+			name: "Not A Member",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(memberQuery).WithArgs(7, *args.assigneeId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 2, assigneeId: intPointer(9)},
+			wantErr: domain.ErrInvalidAssignee,
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 20, assigneeId: intPointer(3)},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := todoItemRepository.Assign(context.TODO(), test.input.userId, test.input.itemId, test.input.assigneeId)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItem_GetAssignments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	assignedAt := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	query := fmt.Sprintf("SELECT a.id, (.+) FROM %s a (.+) WHERE a.item_id = \\$1 AND ul.user_id = \\$2 ORDER BY a.assigned_at, a.id", itemAssignmentsTable)
+	columns := []string{"id", "item_id", "assignee_id", "assignee_name", "assigned_by", "assigned_by_name", "assigned_at"}
+
+	rows := sqlmock.NewRows(columns).
+		AddRow(1, 2, 3, "Bob", 1, "Alice", assignedAt).
+		AddRow(2, 2, nil, nil, 1, "Alice", assignedAt.Add(time.Hour))
+	mock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(rows)
+
+	got, err := todoItemRepository.GetAssignments(context.TODO(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Assignment{
+		{Id: 1, ItemId: 2, AssigneeId: intPointer(3), AssigneeName: stringPointer("Bob"), AssignedById: intPointer(1), AssignedByName: stringPointer("Alice"), AssignedAt: assignedAt},
+		{Id: 2, ItemId: 2, AssignedById: intPointer(1), AssignedByName: stringPointer("Alice"), AssignedAt: assignedAt.Add(time.Hour)},
+	}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
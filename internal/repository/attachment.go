@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	attachmentsTable = "attachments"
+)
+
+// attachmentColumns are the columns of the attachment a.
+const attachmentColumns = "a.id, a.item_id, a.user_id, a.name, a.content_type, a.size, a.storage_key, a.created_at"
+
+type postgresAttachmentsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresAttachmentsRepository(db *sqlx.DB) *postgresAttachmentsRepository {
+	return &postgresAttachmentsRepository{db: db}
+}
+
+// Create adds an attachment of the user to the item, the item has to be in one of the lists of the user.
+// The user is locked while the quota is checked, so concurrent uploads can not exceed it together.
+func (r *postgresAttachmentsRepository) Create(ctx context.Context, userId, itemId int, attachment domain.Attachment, quota int64) (int, error) {
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var used int64
+	usageQuery := fmt.Sprintf(`SELECT COALESCE(SUM(a.size), 0) FROM %s a WHERE a.user_id = (SELECT id FROM %s WHERE id = $1 FOR UPDATE)`,
+		attachmentsTable, usersTable)
+	if err := tx.QueryRow(usageQuery, userId).Scan(&used); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if used+attachment.Size > quota {
+		tx.Rollback()
+		return 0, domain.ErrStorageQuota
+	}
+
+	var attachmentId int
+	createQuery := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, name, content_type, size, storage_key) SELECT ti.id, ul.user_id, $3, $4, $5, $6 FROM %s ti
+									INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE ti.id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL RETURNING id`,
+		attachmentsTable, todoItemsTable, listsItemsTable, usersListsTable)
+	err = tx.QueryRow(createQuery, itemId, userId, attachment.Name, attachment.ContentType, attachment.Size, attachment.StorageKey).Scan(&attachmentId)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return attachmentId, tx.Commit()
+}
+
+func (r *postgresAttachmentsRepository) GetByItemId(ctx context.Context, userId, itemId int) ([]domain.Attachment, error) {
+
+	var attachments []domain.Attachment
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s ti on ti.id = a.item_id
+									INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE a.item_id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL ORDER BY a.created_at, a.id`,
+		attachmentColumns, attachmentsTable, todoItemsTable, listsItemsTable, usersListsTable)
+	if err := r.db.Select(&attachments, query, itemId, userId); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r *postgresAttachmentsRepository) GetById(ctx context.Context, userId, attachmentId int) (domain.Attachment, error) {
+
+	var attachment domain.Attachment
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s ti on ti.id = a.item_id
+									INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE a.id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL`,
+		attachmentColumns, attachmentsTable, todoItemsTable, listsItemsTable, usersListsTable)
+	err := r.db.Get(&attachment, query, attachmentId, userId)
+
+	return attachment, err
+}
+
+// GetLinked returns the attachment a signed download link points to,
+// the signature of the link stands in for the access check.
+func (r *postgresAttachmentsRepository) GetLinked(ctx context.Context, attachmentId int) (domain.Attachment, error) {
+
+	var attachment domain.Attachment
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s ti on ti.id = a.item_id WHERE a.id = $1 AND ti.deleted_at IS NULL`,
+		attachmentColumns, attachmentsTable, todoItemsTable)
+	err := r.db.Get(&attachment, query, attachmentId)
+
+	return attachment, err
+}
+
+// Delete removes an attachment of an item the user has access to and returns
+// the key its file is stored under.
+func (r *postgresAttachmentsRepository) Delete(ctx context.Context, userId, attachmentId int) (string, error) {
+
+	var storageKey string
+	query := fmt.Sprintf(`DELETE FROM %s a USING %s li, %s ul
+									WHERE a.id = $1 AND ul.user_id = $2 AND li.item_id = a.item_id AND ul.list_id = li.list_id RETURNING a.storage_key`,
+		attachmentsTable, listsItemsTable, usersListsTable)
+	err := r.db.QueryRow(query, attachmentId, userId).Scan(&storageKey)
+
+	return storageKey, err
+}
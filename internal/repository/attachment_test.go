@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestAttachment_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	attachmentsRepository := NewPostgresAttachmentsRepository(dbx)
+
+	type (
+		args struct {
+			userId     int
+			itemId     int
+			attachment domain.Attachment
+		}
+
+		test struct {
+			name         string
+			input        args
+			mockBehavior func(args args, id int)
+			wantId       int
+			wantErr      error
+		}
+	)
+
+	usageQuery := fmt.Sprintf("SELECT COALESCE\\(SUM\\(a.size\\), 0\\) FROM %s a WHERE a.user_id = \\(SELECT id FROM %s WHERE id = \\$1 FOR UPDATE\\)",
+		attachmentsTable, usersTable)
+	createQuery := fmt.Sprintf("INSERT INTO %s \\(item_id, user_id, name, content_type, size, storage_key\\) SELECT (.+) FROM %s ti (.+) WHERE ti.id = (.+) AND ul.user_id = (.+)",
+		attachmentsTable, todoItemsTable)
+	attachment := domain.Attachment{Name: "receipt.pdf", ContentType: "application/pdf", Size: 300, StorageKey: "a1b2"}
+
+	tests := []test{
+		{
+			name:  "Ok",
+			input: args{userId: 1, itemId: 2, attachment: attachment},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(usageQuery).WithArgs(args.userId).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(700))
+				mock.ExpectQuery(createQuery).WithArgs(args.itemId, args.userId, "receipt.pdf", "application/pdf", int64(300), "a1b2").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+				mock.ExpectCommit()
+			},
+			wantId: 3,
+		},
+		{
+			name:  "Quota Exceeded",
+			input: args{userId: 1, itemId: 2, attachment: attachment},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(usageQuery).WithArgs(args.userId).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(701))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrStorageQuota,
+		},
+		{
+			name:  "Foreign Item",
+			input: args{userId: 1, itemId: 5, attachment: attachment},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(usageQuery).WithArgs(args.userId).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(0))
+				mock.ExpectQuery(createQuery).WithArgs(args.itemId, args.userId, "receipt.pdf", "application/pdf", int64(300), "a1b2").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input, test.wantId)
+
+			gotId, err := attachmentsRepository.Create(context.TODO(), test.input.userId, test.input.itemId, test.input.attachment, 1000)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantId, gotId)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAttachment_GetByItemId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	attachmentsRepository := NewPostgresAttachmentsRepository(dbx)
+
+	query := fmt.Sprintf("SELECT %s FROM %s a (.+) WHERE a.item_id = \\$1 AND ul.user_id = \\$2", attachmentColumns, attachmentsTable)
+	columns := []string{"id", "item_id", "user_id", "name", "content_type", "size", "storage_key", "created_at"}
+	createdAt := time.Date(2026, time.March, 29, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(query).WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 2, 1, "receipt.pdf", "application/pdf", 300, "a1b2", createdAt))
+
+	got, err := attachmentsRepository.GetByItemId(context.TODO(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Attachment{
+		{Id: 3, ItemId: 2, UploaderId: 1, Name: "receipt.pdf", ContentType: "application/pdf", Size: 300, StorageKey: "a1b2", CreatedAt: timePointer(createdAt)},
+	}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachment_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	attachmentsRepository := NewPostgresAttachmentsRepository(dbx)
+
+	type test struct {
+		name         string
+		mockBehavior func()
+		wantKey      string
+		wantErr      error
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s a USING %s li, %s ul WHERE a.id = \\$1 AND ul.user_id = \\$2 (.+) RETURNING a.storage_key",
+		attachmentsTable, listsItemsTable, usersListsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectQuery(query).WithArgs(3, 1).WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("a1b2"))
+			},
+			wantKey: "a1b2",
+		},
+		{
+			name: "Foreign Attachment",
+			mockBehavior: func() {
+				mock.ExpectQuery(query).WithArgs(3, 1).WillReturnRows(sqlmock.NewRows([]string{"storage_key"}))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			gotKey, err := attachmentsRepository.Delete(context.TODO(), 1, 3)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantKey, gotKey)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	commentsTable = "comments"
+)
+
+// commentParent is the constraint keeping a reply on the item of the comment it replies to.
+const commentParent = "comment_parent"
+
+type postgresCommentsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresCommentsRepository(db *sqlx.DB) *postgresCommentsRepository {
+	return &postgresCommentsRepository{db: db}
+}
+
+// Create adds a comment of the user to the item, the item has to be in one of the lists of the user.
+func (r *postgresCommentsRepository) Create(ctx context.Context, userId, itemId int, comment domain.Comment) (int, error) {
+
+	var commentId int
+	query := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, parent_id, body) SELECT ti.id, ul.user_id, $3, $4 FROM %s ti
+									INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE ti.id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL RETURNING id`,
+		commentsTable, todoItemsTable, listsItemsTable, usersListsTable)
+	err := r.db.QueryRow(query, itemId, userId, comment.ParentId, comment.Body).Scan(&commentId)
+
+	return commentId, commentError(err)
+}
+
+// GetByItemId returns a page of the top-level comments of the item, the oldest first,
+// together with all the replies to them.
+func (r *postgresCommentsRepository) GetByItemId(ctx context.Context, userId, itemId int, page domain.Page) ([]domain.Comment, error) {
+
+	var comments []domain.Comment
+	query := fmt.Sprintf(`WITH RECURSIVE threads AS (
+									SELECT c.id, c.created_at FROM %s c INNER JOIN %s ti on ti.id = c.item_id
+									INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE c.item_id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL AND c.parent_id IS NULL
+									ORDER BY c.created_at, c.id LIMIT $3 OFFSET $4
+								), thread AS (
+									SELECT id FROM threads
+									UNION ALL
+									SELECT c.id FROM %s c INNER JOIN thread t on c.parent_id = t.id
+								)
+								SELECT c.id, c.item_id, c.parent_id, c.user_id, u.name AS author_name, c.body, c.created_at, c.updated_at FROM %s c
+								INNER JOIN thread t on t.id = c.id INNER JOIN %s u on u.id = c.user_id ORDER BY c.created_at, c.id`,
+		commentsTable, todoItemsTable, listsItemsTable, usersListsTable, commentsTable, commentsTable, usersTable)
+	if err := r.db.Select(&comments, query, itemId, userId, page.Limit, page.Offset); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// Update edits a comment written by the user, the user still has to share the list of the item.
+func (r *postgresCommentsRepository) Update(ctx context.Context, userId, commentId int, input domain.UpdateCommentInput) error {
+
+	query := fmt.Sprintf(`UPDATE %s c SET body = $1, updated_at = now() FROM %s li, %s ul
+									WHERE c.id = $2 AND c.user_id = $3 AND li.item_id = c.item_id AND ul.list_id = li.list_id AND ul.user_id = c.user_id`,
+		commentsTable, listsItemsTable, usersListsTable)
+	result, err := r.db.Exec(query, input.Body, commentId, userId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Delete removes a comment written by the user together with the replies to it.
+func (r *postgresCommentsRepository) Delete(ctx context.Context, userId, commentId int) error {
+
+	query := fmt.Sprintf(`DELETE FROM %s c USING %s li, %s ul
+									WHERE c.id = $1 AND c.user_id = $2 AND li.item_id = c.item_id AND ul.list_id = li.list_id AND ul.user_id = c.user_id`,
+		commentsTable, listsItemsTable, usersListsTable)
+	result, err := r.db.Exec(query, commentId, userId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// commentError reports a reply to a comment on another item as domain.ErrInvalidCommentParent.
+func commentError(err error) error {
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Constraint == commentParent {
+		return domain.ErrInvalidCommentParent
+	}
+
+	return err
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func TestComment_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	commentsRepository := NewPostgresCommentsRepository(dbx)
+
+	type (
+		args struct {
+			userId  int
+			itemId  int
+			comment domain.Comment
+		}
+
+		test struct {
+			name         string
+			input        args
+			mockBehavior func(args args, id int)
+			wantId       int
+			wantErr      error
+		}
+	)
+
+	query := fmt.Sprintf("INSERT INTO %s \\(item_id, user_id, parent_id, body\\) SELECT (.+) FROM %s ti (.+) WHERE ti.id = (.+) AND ul.user_id = (.+)",
+		commentsTable, todoItemsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			input: args{
+				userId:  1,
+				itemId:  2,
+				comment: domain.Comment{Body: "Looks good"},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.comment.ParentId, args.comment.Body).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			},
+			wantId: 3,
+		},
+		{
+			name: "Ok_Reply",
+			input: args{
+				userId:  1,
+				itemId:  2,
+				comment: domain.Comment{ParentId: intPointer(3), Body: "Agreed"},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.comment.ParentId, args.comment.Body).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			},
+			wantId: 4,
+		},
+		{
+			name: "Reply To Another Item",
+			input: args{
+				userId:  1,
+				itemId:  2,
+				comment: domain.Comment{ParentId: intPointer(30), Body: "Agreed"},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.comment.ParentId, args.comment.Body).
+					WillReturnError(&pq.Error{Code: "23503", Constraint: commentParent})
+			},
+			wantErr: domain.ErrInvalidCommentParent,
+		},
+		{
+			name: "Foreign Item",
+			input: args{
+				userId:  1,
+				itemId:  5,
+				comment: domain.Comment{Body: "Looks good"},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.comment.ParentId, args.comment.Body).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input, test.wantId)
+
+			gotId, err := commentsRepository.Create(context.TODO(), test.input.userId, test.input.itemId, test.input.comment)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantId, gotId)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestComment_GetByItemId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	commentsRepository := NewPostgresCommentsRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+			itemId int
+			page   domain.Page
+		}
+
+		test struct {
+			name         string
+			input        args
+			mockBehavior func(args args)
+			want         []domain.Comment
+			wantErr      bool
+		}
+	)
+
+	query := "WITH RECURSIVE threads AS (.+) LIMIT \\$3 OFFSET \\$4 (.+) SELECT c.id, c.item_id, c.parent_id, c.user_id, u.name AS author_name, c.body, c.created_at, c.updated_at FROM (.+)"
+	columns := []string{"id", "item_id", "parent_id", "user_id", "author_name", "body", "created_at", "updated_at"}
+	createdAt := time.Date(2026, time.March, 29, 9, 0, 0, 0, time.UTC)
+
+	tests := []test{
+		{
+			name:  "Ok",
+			input: args{userId: 1, itemId: 2, page: domain.Page{Limit: 20}},
+			mockBehavior: func(args args) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.page.Limit, args.page.Offset).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(3, 2, nil, 1, "Alice", "Looks good", createdAt, nil).
+						AddRow(4, 2, 3, 5, "Bob", "Agreed", createdAt, createdAt))
+			},
+			want: []domain.Comment{
+				{Id: 3, ItemId: 2, AuthorId: 1, AuthorName: "Alice", Body: "Looks good", CreatedAt: timePointer(createdAt)},
+				{Id: 4, ItemId: 2, ParentId: intPointer(3), AuthorId: 5, AuthorName: "Bob", Body: "Agreed", CreatedAt: timePointer(createdAt), UpdatedAt: timePointer(createdAt)},
+			},
+		},
+		{
+			name:  "Database Failure",
+			input: args{userId: 1, itemId: 2, page: domain.Page{Limit: 20, Offset: 20}},
+			mockBehavior: func(args args) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.page.Limit, args.page.Offset).
+					WillReturnError(fmt.Errorf("select error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := commentsRepository.GetByItemId(context.TODO(), test.input.userId, test.input.itemId, test.input.page)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestComment_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	commentsRepository := NewPostgresCommentsRepository(dbx)
+
+	type (
+		args struct {
+			userId    int
+			commentId int
+			input     domain.UpdateCommentInput
+		}
+
+		test struct {
+			name         string
+			input        args
+			mockBehavior func(args args)
+			wantErr      error
+		}
+	)
+
+	query := fmt.Sprintf("UPDATE %s c SET body = \\$1, updated_at = now\\(\\) FROM %s li, %s ul WHERE c.id = \\$2 AND c.user_id = \\$3 (.+)",
+		commentsTable, listsItemsTable, usersListsTable)
+
+	tests := []test{
+		{
+			name:  "Ok",
+			input: args{userId: 1, commentId: 3, input: domain.UpdateCommentInput{Body: "Looks great"}},
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.input.Body, args.commentId, args.userId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "Not The Author",
+			input: args{userId: 5, commentId: 3, input: domain.UpdateCommentInput{Body: "Looks great"}},
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.input.Body, args.commentId, args.userId).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := commentsRepository.Update(context.TODO(), test.input.userId, test.input.commentId, test.input.input)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestComment_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	commentsRepository := NewPostgresCommentsRepository(dbx)
+
+	type (
+		args struct {
+			userId    int
+			commentId int
+		}
+
+		test struct {
+			name         string
+			input        args
+			mockBehavior func(args args)
+			wantErr      error
+		}
+	)
+
+	query := fmt.Sprintf("DELETE FROM %s c USING %s li, %s ul WHERE c.id = \\$1 AND c.user_id = \\$2 (.+)", commentsTable, listsItemsTable, usersListsTable)
+
+	tests := []test{
+		{
+			name:  "Ok",
+			input: args{userId: 1, commentId: 3},
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.commentId, args.userId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "Not The Author",
+			input: args{userId: 5, commentId: 3},
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.commentId, args.userId).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := commentsRepository.Delete(context.TODO(), test.input.userId, test.input.commentId)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+)
+
+const (
+	itemDependenciesTable = "item_dependencies"
+)
+
+// AddBlocker makes the item blocked by another item of the lists of the user.
+// The relation is refused when the blocker is, directly or not, blocked by
+// the item itself. The item and the chain of the items blocking the blocker
+// are locked before the cycle is looked for. Relations added at the same time
+// which could close a cycle together share a locked item, so one waits for the
+// other and then sees its relation.
+func (r *postgresTodoItemRepository) AddBlocker(ctx context.Context, userId, itemId, blockerId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := targetList(tx, userId, itemId, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var accessible bool
+	blockerQuery := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s ti INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									INNER JOIN %s tl on tl.id = li.list_id WHERE ti.id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL)`,
+		todoItemsTable, listsItemsTable, usersListsTable, todoListTable)
+	if err := tx.QueryRow(blockerQuery, blockerId, userId).Scan(&accessible); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if !accessible {
+		tx.Rollback()
+		return domain.ErrInvalidBlocker
+	}
+
+	lockQuery := fmt.Sprintf(`WITH RECURSIVE blockers AS (
+									SELECT $1::int AS id
+									UNION
+									SELECT d.blocker_id FROM %s d INNER JOIN blockers b on d.item_id = b.id
+								) SELECT ti.id FROM %s ti WHERE ti.id = $2 OR ti.id IN (SELECT id FROM blockers) ORDER BY ti.id FOR UPDATE`,
+		itemDependenciesTable, todoItemsTable)
+	if _, err := tx.Exec(lockQuery, blockerId, itemId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var cycle bool
+	cycleQuery := fmt.Sprintf(`WITH RECURSIVE blockers AS (
+									SELECT $1::int AS id
+									UNION
+									SELECT d.blocker_id FROM %s d INNER JOIN blockers b on d.item_id = b.id
+								) SELECT EXISTS (SELECT 1 FROM blockers WHERE id = $2)`, itemDependenciesTable)
+	if err := tx.QueryRow(cycleQuery, blockerId, itemId).Scan(&cycle); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if cycle {
+		tx.Rollback()
+		return domain.ErrDependencyCycle
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (item_id, blocker_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", itemDependenciesTable)
+	if _, err := tx.Exec(query, itemId, blockerId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RemoveBlocker stops the item being blocked by the other item.
+func (r *postgresTodoItemRepository) RemoveBlocker(ctx context.Context, userId, itemId, blockerId int) error {
+
+	query := fmt.Sprintf(`DELETE FROM %s d USING %s li, %s ul WHERE d.item_id = $1 AND d.blocker_id = $2
+									AND li.item_id = d.item_id AND ul.list_id = li.list_id AND ul.user_id = $3`,
+		itemDependenciesTable, listsItemsTable, usersListsTable)
+	_, err := r.db.Exec(query, itemId, blockerId, userId)
+
+	return err
+}
+
+// GetBlockers returns the live items the item is blocked by, done or not.
+func (r *postgresTodoItemRepository) GetBlockers(ctx context.Context, userId, itemId int) ([]domain.TodoItem, error) {
+
+	var blockers []domain.TodoItem
+	query := fmt.Sprintf(`SELECT b.id, bl.list_id, b.title, b.done FROM %s d INNER JOIN %s b on b.id = d.blocker_id INNER JOIN %s bl on bl.item_id = b.id
+									INNER JOIN %s li on li.item_id = d.item_id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE d.item_id = $1 AND ul.user_id = $2 AND b.deleted_at IS NULL ORDER BY b.id`,
+		itemDependenciesTable, todoItemsTable, listsItemsTable, listsItemsTable, usersListsTable)
+	if err := r.db.Select(&blockers, query, itemId, userId); err != nil {
+		return nil, err
+	}
+
+	return blockers, nil
+}
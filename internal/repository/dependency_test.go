@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestTodoItem_AddBlocker(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	type (
+		args struct {
+			userId    int
+			itemId    int
+			blockerId int
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      error
+		}
+	)
+
+	listQuery := fmt.Sprintf("SELECT li.list_id FROM %s li INNER JOIN %s ul on (.+) WHERE (.+)", listsItemsTable, usersListsTable)
+	blockerQuery := fmt.Sprintf("SELECT EXISTS \\(SELECT 1 FROM %s ti (.+) WHERE ti.id = \\$1 AND ul.user_id = \\$2 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL\\)", todoItemsTable)
+	lockQuery := fmt.Sprintf("WITH RECURSIVE blockers AS (.+) SELECT ti.id FROM %s ti WHERE ti.id = \\$2 OR ti.id IN \\(SELECT id FROM blockers\\) ORDER BY ti.id FOR UPDATE", todoItemsTable)
+	cycleQuery := "WITH RECURSIVE blockers AS (.+) SELECT EXISTS \\(SELECT 1 FROM blockers WHERE id = \\$2\\)"
+	insertQuery := fmt.Sprintf("INSERT INTO %s \\(item_id, blocker_id\\) VALUES \\(\\$1, \\$2\\) ON CONFLICT DO NOTHING", itemDependenciesTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(blockerQuery).WithArgs(args.blockerId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectExec(lockQuery).WithArgs(args.blockerId, args.itemId).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery(cycleQuery).WithArgs(args.blockerId, args.itemId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec(insertQuery).WithArgs(args.itemId, args.blockerId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, itemId: 2, blockerId: 3},
+		},
+		{
+			name: "Cycle",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(blockerQuery).WithArgs(args.blockerId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectExec(lockQuery).WithArgs(args.blockerId, args.itemId).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery(cycleQuery).WithArgs(args.blockerId, args.itemId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 2, blockerId: 4},
+			wantErr: domain.ErrDependencyCycle,
+		},
+		{
+			name: "Foreign Blocker",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(blockerQuery).WithArgs(args.blockerId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 2, blockerId: 30},
+			wantErr: domain.ErrInvalidBlocker,
+		},
+		{
+			name: "Blocker In Trash",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(blockerQuery).WithArgs(args.blockerId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 2, blockerId: 5},
+			wantErr: domain.ErrInvalidBlocker,
+		},
+		{
+			name: "Foreign Item",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 20, blockerId: 3},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := todoItemRepository.AddBlocker(context.TODO(), test.input.userId, test.input.itemId, test.input.blockerId)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItem_RemoveBlocker(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	query := fmt.Sprintf("DELETE FROM %s d USING %s li, %s ul WHERE d.item_id = \\$1 AND d.blocker_id = \\$2 (.+)", itemDependenciesTable, listsItemsTable, usersListsTable)
+	mock.ExpectExec(query).WithArgs(2, 3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = todoItemRepository.RemoveBlocker(context.TODO(), 1, 2, 3)
+	assert.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoItem_GetBlockers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	query := fmt.Sprintf("SELECT b.id, bl.list_id, b.title, b.done FROM %s d (.+) WHERE d.item_id = \\$1 AND ul.user_id = \\$2 AND b.deleted_at IS NULL ORDER BY b.id",
+		itemDependenciesTable)
+	rows := sqlmock.NewRows([]string{"id", "list_id", "title", "done"}).
+		AddRow(3, 7, "title3", true).
+		AddRow(4, 8, "title4", false)
+	mock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(rows)
+
+	got, err := todoItemRepository.GetBlockers(context.TODO(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.TodoItem{
+		{Id: 3, ListId: 7, Title: "title3", Done: true},
+		{Id: 4, ListId: 8, Title: "title4"},
+	}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
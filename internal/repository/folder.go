@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	foldersTable = "folders"
+)
+
+var folderPositions = positions{table: foldersTable, scopeColumn: "user_id", memberColumn: "id"}
+
+type postgresFoldersRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresFoldersRepository(db *sqlx.DB) *postgresFoldersRepository {
+	return &postgresFoldersRepository{db: db}
+}
+
+func (r *postgresFoldersRepository) Create(ctx context.Context, userId int, folder domain.Folder) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	if folder.ParentId != nil {
+		if err := r.checkOwner(tx, userId, *folder.ParentId); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	position, err := folderPositions.last(tx, userId)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var folderId int
+	query := fmt.Sprintf("INSERT INTO %s (user_id, parent_id, name, position) VALUES ($1, $2, $3, $4) RETURNING id", foldersTable)
+	if err := tx.QueryRow(query, userId, folder.ParentId, folder.Name, position).Scan(&folderId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return folderId, tx.Commit()
+}
+
+func (r *postgresFoldersRepository) GetByUserId(ctx context.Context, userId int) ([]domain.Folder, error) {
+
+	var folders []domain.Folder
+	query := fmt.Sprintf("SELECT id, parent_id, name, position FROM %s WHERE user_id = $1 ORDER BY position, id", foldersTable)
+	err := r.db.Select(&folders, query, userId)
+
+	return folders, err
+}
+
+func (r *postgresFoldersRepository) GetById(ctx context.Context, userId, folderId int) (domain.Folder, error) {
+
+	var folder domain.Folder
+	query := fmt.Sprintf("SELECT id, parent_id, name, position FROM %s WHERE user_id = $1 AND id = $2", foldersTable)
+	err := r.db.Get(&folder, query, userId, folderId)
+
+	return folder, err
+}
+
+func (r *postgresFoldersRepository) Update(ctx context.Context, userId, folderId int, input domain.UpdateFolderInput) error {
+
+	setValues, args, argId := make([]string, 0), make([]interface{}, 0), 1
+
+	if input.Name != nil {
+		setValues = append(setValues, fmt.Sprintf("name=$%d", argId))
+		args = append(args, *input.Name)
+		argId++
+	}
+
+	if len(setValues) == 0 {
+		return nil
+	}
+
+	setQuery := strings.Join(setValues, ", ")
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE user_id=$%d AND id=$%d", foldersTable, setQuery, argId, argId+1)
+
+	args = append(args, userId, folderId)
+
+	_, err := r.db.Exec(query, args...)
+
+	return err
+}
+
+// Delete removes the folder with all its subfolders. The lists kept in them
+// are moved to the top level.
+func (r *postgresFoldersRepository) Delete(ctx context.Context, userId, folderId int) error {
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1 AND id = $2", foldersTable)
+	_, err := r.db.Exec(query, userId, folderId)
+
+	return err
+}
+
+// Move changes the parent and the position of the folder. A folder can not
+// become a child of itself or of any of its subfolders.
+func (r *postgresFoldersRepository) Move(ctx context.Context, userId, folderId int, input domain.MoveFolderInput) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := r.checkOwner(tx, userId, folderId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if input.ParentId != nil {
+		if err := r.checkOwner(tx, userId, *input.ParentId); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		var cycle bool
+		cycleQuery := fmt.Sprintf(`WITH RECURSIVE subtree AS (
+										SELECT id FROM %s WHERE id = $1
+										UNION ALL
+										SELECT f.id FROM %s f INNER JOIN subtree s on f.parent_id = s.id
+									) SELECT EXISTS (SELECT 1 FROM subtree WHERE id = $2)`, foldersTable, foldersTable)
+		if err := tx.QueryRow(cycleQuery, folderId, *input.ParentId).Scan(&cycle); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if cycle {
+			tx.Rollback()
+			return domain.ErrFolderCycle
+		}
+	}
+
+	position, err := folderPositions.between(tx, userId, folderId, input.MoveInput)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET parent_id = $1, position = $2 WHERE user_id = $3 AND id = $4", foldersTable)
+	if _, err := tx.Exec(query, input.ParentId, position, userId, folderId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *postgresFoldersRepository) checkOwner(q rowQueryer, userId, folderId int) error {
+
+	var id int
+	query := fmt.Sprintf("SELECT id FROM %s WHERE user_id = $1 AND id = $2", foldersTable)
+
+	return q.QueryRow(query, userId, folderId).Scan(&id)
+}
@@ -0,0 +1,489 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestFolder_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	foldersRepository := NewPostgresFoldersRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+			folder domain.Folder
+		}
+
+		test struct {
+			name         string
+			input        args
+			mockBehavior func(args args, id int)
+			wantId       int
+			wantErr      bool
+		}
+	)
+
+	ownerQuery := fmt.Sprintf("SELECT id FROM %s WHERE user_id = (.+) AND id = (.+)", foldersTable)
+	positionQuery := fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s", foldersTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			input: args{
+				userId: 1,
+				folder: domain.Folder{Name: "work"},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(positionQuery).WithArgs(args.userId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(""))
+				mock.ExpectQuery(fmt.Sprintf("INSERT INTO %s", foldersTable)).
+					WithArgs(args.userId, args.folder.ParentId, args.folder.Name, "V").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+				mock.ExpectCommit()
+			},
+			wantId: 1,
+		},
+		{
+			name: "Ok_WithParent",
+			input: args{
+				userId: 1,
+				folder: domain.Folder{Name: "projects", ParentId: intPointer(1)},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(ownerQuery).WithArgs(args.userId, *args.folder.ParentId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery(positionQuery).WithArgs(args.userId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectQuery(fmt.Sprintf("INSERT INTO %s", foldersTable)).
+					WithArgs(args.userId, args.folder.ParentId, args.folder.Name, "W").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+				mock.ExpectCommit()
+			},
+			wantId: 2,
+		},
+		{
+			name: "Foreign Parent",
+			input: args{
+				userId: 1,
+				folder: domain.Folder{Name: "projects", ParentId: intPointer(5)},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(ownerQuery).WithArgs(args.userId, *args.folder.ParentId).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input, test.wantId)
+
+			gotId, err := foldersRepository.Create(context.TODO(), test.input.userId, test.input.folder)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantId, gotId)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFolder_GetByUserId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	foldersRepository := NewPostgresFoldersRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			want         []domain.Folder
+			wantErr      bool
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows([]string{"id", "parent_id", "name", "position"}).
+					AddRow(1, nil, "work", "V").
+					AddRow(2, 1, "projects", "k")
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s WHERE user_id = (.+) ORDER BY position, id", foldersTable)
+				mock.ExpectQuery(query).WithArgs(args.userId).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+			},
+			want: []domain.Folder{
+				{Id: 1, Name: "work", Position: "V"},
+				{Id: 2, ParentId: intPointer(1), Name: "projects", Position: "k"},
+			},
+		},
+		{
+			name: "No records",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows([]string{"id", "parent_id", "name", "position"})
+				query := fmt.Sprintf("SELECT (.+) FROM %s WHERE (.+)", foldersTable)
+				mock.ExpectQuery(query).WithArgs(args.userId).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 2,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := foldersRepository.GetByUserId(context.TODO(), test.input.userId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFolder_GetById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	foldersRepository := NewPostgresFoldersRepository(dbx)
+
+	type (
+		args struct {
+			userId   int
+			folderId int
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			want         domain.Folder
+			wantErr      bool
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows([]string{"id", "parent_id", "name", "position"}).AddRow(2, nil, "work", "V")
+				query := fmt.Sprintf("SELECT (.+) FROM %s WHERE user_id = (.+) AND id = (.+)", foldersTable)
+				mock.ExpectQuery(query).WithArgs(args.userId, args.folderId).WillReturnRows(rows)
+			},
+			input: args{
+				userId:   1,
+				folderId: 2,
+			},
+			want: domain.Folder{Id: 2, Name: "work", Position: "V"},
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows([]string{"id", "parent_id", "name", "position"})
+				query := fmt.Sprintf("SELECT (.+) FROM %s WHERE user_id = (.+) AND id = (.+)", foldersTable)
+				mock.ExpectQuery(query).WithArgs(args.userId, args.folderId).WillReturnRows(rows)
+			},
+			input: args{
+				userId:   1,
+				folderId: 404,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := foldersRepository.GetById(context.TODO(), test.input.userId, test.input.folderId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFolder_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	foldersRepository := NewPostgresFoldersRepository(dbx)
+
+	type (
+		args struct {
+			userId   int
+			folderId int
+			input    domain.UpdateFolderInput
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      bool
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s SET name=(.+) WHERE (.+)", foldersTable)
+				mock.ExpectExec(query).WithArgs(*args.input.Name, args.userId, args.folderId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId:   1,
+				folderId: 2,
+				input:    domain.UpdateFolderInput{Name: stringPointer("new name")},
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s SET name=(.+) WHERE (.+)", foldersTable)
+				mock.ExpectExec(query).WithArgs(*args.input.Name, args.userId, args.folderId).WillReturnError(sql.ErrConnDone)
+			},
+			input: args{
+				userId:   1,
+				folderId: 2,
+				input:    domain.UpdateFolderInput{Name: stringPointer("new name")},
+			},
+			wantErr: true,
+		},
+		{
+			name:         "Nothing to update",
+			mockBehavior: func(args args) {},
+			input: args{
+				userId:   1,
+				folderId: 2,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := foldersRepository.Update(context.TODO(), test.input.userId, test.input.folderId, test.input.input)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFolder_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	foldersRepository := NewPostgresFoldersRepository(dbx)
+
+	type (
+		args struct {
+			userId   int
+			folderId int
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      bool
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("DELETE FROM %s WHERE user_id = (.+) AND id = (.+)", foldersTable)
+				mock.ExpectExec(query).WithArgs(args.userId, args.folderId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId:   1,
+				folderId: 2,
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("DELETE FROM %s WHERE (.+)", foldersTable)
+				mock.ExpectExec(query).WithArgs(args.userId, args.folderId).WillReturnError(sql.ErrConnDone)
+			},
+			input: args{
+				userId:   1,
+				folderId: 2,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := foldersRepository.Delete(context.TODO(), test.input.userId, test.input.folderId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFolder_Move(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	foldersRepository := NewPostgresFoldersRepository(dbx)
+
+	type (
+		args struct {
+			userId   int
+			folderId int
+			input    domain.MoveFolderInput
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      error
+		}
+	)
+
+	ownerQuery := fmt.Sprintf("SELECT id FROM %s WHERE user_id = (.+) AND id = (.+)", foldersTable)
+	cycleQuery := "WITH RECURSIVE subtree AS (.+) SELECT EXISTS (.+)"
+
+	tests := []test{
+		{
+			name: "Ok_ToParent",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(ownerQuery).WithArgs(args.userId, args.folderId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(args.folderId))
+				mock.ExpectQuery(ownerQuery).WithArgs(args.userId, *args.input.ParentId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(*args.input.ParentId))
+				mock.ExpectQuery(cycleQuery).WithArgs(args.folderId, *args.input.ParentId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s WHERE (.+)", foldersTable)).
+					WithArgs(args.userId, args.folderId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectExec(fmt.Sprintf("UPDATE %s SET parent_id = (.+), position = (.+) WHERE (.+)", foldersTable)).
+					WithArgs(args.input.ParentId, "W", args.userId, args.folderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			input: args{
+				userId:   1,
+				folderId: 2,
+				input:    domain.MoveFolderInput{ParentId: intPointer(3)},
+			},
+		},
+		{
+			name: "Cycle",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(ownerQuery).WithArgs(args.userId, args.folderId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(args.folderId))
+				mock.ExpectQuery(ownerQuery).WithArgs(args.userId, *args.input.ParentId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(*args.input.ParentId))
+				mock.ExpectQuery(cycleQuery).WithArgs(args.folderId, *args.input.ParentId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			input: args{
+				userId:   1,
+				folderId: 2,
+				input:    domain.MoveFolderInput{ParentId: intPointer(4)},
+			},
+			wantErr: domain.ErrFolderCycle,
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(ownerQuery).WithArgs(args.userId, args.folderId).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			input: args{
+				userId:   1,
+				folderId: 404,
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := foldersRepository.Move(context.TODO(), test.input.userId, test.input.folderId, test.input.input)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
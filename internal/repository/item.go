@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/andredubov/todo-backend/internal/domain"
 	"github.com/jmoiron/sqlx"
@@ -14,6 +17,14 @@ const (
 	listsItemsTable = "lists_items"
 )
 
+// itemDueAt is the moment the item becomes overdue in the timezone of the user
+// joined as u: the due time of the due date or the end of the due date.
+const itemDueAt = "(ti.due_date + COALESCE(ti.due_time, '24:00'::time)) AT TIME ZONE u.timezone"
+
+// itemBlocked tells whether the item joined as ti is blocked by an open item.
+var itemBlocked = fmt.Sprintf(`EXISTS (SELECT 1 FROM %s d INNER JOIN %s b on b.id = d.blocker_id
+									WHERE d.item_id = ti.id AND NOT b.done AND b.deleted_at IS NULL)`, itemDependenciesTable, todoItemsTable)
+
 type postgresTodoItemRepository struct {
 	db *sqlx.DB
 }
@@ -22,65 +33,359 @@ func NewPostgresTodoItemRepository(db *sqlx.DB) *postgresTodoItemRepository {
 	return &postgresTodoItemRepository{db: db}
 }
 
-func (r *postgresTodoItemRepository) Create(ctx context.Context, listId int, item domain.TodoItem) (int, error) {
+// Create adds the item to the list. A subtask has to fit into maxDepth levels below its top-level item.
+// The item starts in the first open workflow status of the list.
+func (r *postgresTodoItemRepository) Create(ctx context.Context, listId int, item domain.TodoItem, maxDepth int) (int, error) {
 
 	tx, err := r.db.Begin()
 	if err != nil {
 		return 0, err
 	}
 
-	var itemId int
-	createItemQuery := fmt.Sprintf("INSERT INTO %s (title, description) values ($1, $2) RETURNING id", todoItemsTable)
-
-	row := tx.QueryRow(createItemQuery, item.Title, item.Description)
-	err = row.Scan(&itemId)
+	itemId, err := createItem(tx, listId, item, maxDepth)
 	if err != nil {
 		tx.Rollback()
 		return 0, err
 	}
 
-	createListItemsQuery := fmt.Sprintf("INSERT INTO %s (list_id, item_id) values ($1, $2)", listsItemsTable)
-	_, err = tx.Exec(createListItemsQuery, listId, itemId)
+	return itemId, tx.Commit()
+}
+
+// CreateTagged adds the item to the list as Create does and labels it with its
+// tags in the same transaction. The tags are found among the tags of the user
+// by name regardless of case, the missing ones are created.
+func (r *postgresTodoItemRepository) CreateTagged(ctx context.Context, userId, listId int, item domain.TodoItem, maxDepth int) (int, []domain.Tag, error) {
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	itemId, err := createItem(tx, listId, item, maxDepth)
 	if err != nil {
 		tx.Rollback()
+		return 0, nil, err
+	}
+
+	tags := make([]domain.Tag, 0, len(item.Tags))
+	attachTagQuery := fmt.Sprintf("INSERT INTO %s (item_id, tag_id) VALUES ($1, $2) ON CONFLICT (item_id, tag_id) DO NOTHING", itemsTagsTable)
+
+	for _, tag := range item.Tags {
+		tag, err = upsertTag(tx, userId, tag.Name)
+		if err != nil {
+			tx.Rollback()
+			return 0, nil, err
+		}
+
+		if _, err := tx.Exec(attachTagQuery, itemId, tag.Id); err != nil {
+			tx.Rollback()
+			return 0, nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return itemId, tags, tx.Commit()
+}
+
+// createItem inserts the item and places it last in the list.
+func createItem(tx *sql.Tx, listId int, item domain.TodoItem, maxDepth int) (int, error) {
+
+	if item.ParentId != nil {
+		if err := checkParent(tx, listId, 0, *item.ParentId, maxDepth); err != nil {
+			return 0, err
+		}
+	}
+
+	var itemId int
+	createItemQuery := fmt.Sprintf(`INSERT INTO %s (parent_id, title, description, priority, due_date, due_time, rrule, repeat_from_completion, estimate_minutes, defer_until, status_id)
+									values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, (SELECT id FROM %s WHERE list_id = $11 AND NOT done ORDER BY position, id LIMIT 1)) RETURNING id`,
+		todoItemsTable, statusesTable)
+
+	row := tx.QueryRow(createItemQuery, item.ParentId, item.Title, item.Description, item.Priority, item.DueDate, item.DueTime, item.RRule, item.RepeatFromCompletion,
+		item.EstimateMinutes, item.DeferUntil, listId)
+	if err := row.Scan(&itemId); err != nil {
 		return 0, err
 	}
 
-	return itemId, tx.Commit()
+	position, err := itemPositions.last(tx, listId)
+	if err != nil {
+		return 0, err
+	}
+
+	createListItemsQuery := fmt.Sprintf("INSERT INTO %s (list_id, item_id, position) values ($1, $2, $3)", listsItemsTable)
+	if _, err := tx.Exec(createListItemsQuery, listId, itemId, position); err != nil {
+		return 0, err
+	}
+
+	return itemId, nil
 }
 
-func (r *postgresTodoItemRepository) GetAll(ctx context.Context, userId, listId int) ([]domain.TodoItem, error) {
+// GetAll returns the items of the list. At most limit items following the item
+// with the given key are returned, all of them for a zero limit and a missing key.
+func (r *postgresTodoItemRepository) GetAll(ctx context.Context, userId, listId int, filter domain.TodoItemFilter, after *domain.ItemKey, limit int) ([]domain.TodoItem, error) {
+
+	conditions, args := itemConditions(filter, []interface{}{listId, userId})
+	keyset, args := itemKeyset(filter, after, args)
+	limits, args := limitClause(limit, args)
+
 	var todoItems []domain.TodoItem
-	query := fmt.Sprintf(`SELECT ti.id, ti.title, ti.description, ti.done FROM %s ti INNER JOIN %s li on li.item_id = ti.id
-									INNER JOIN %s ul on ul.list_id = li.list_id WHERE li.list_id = $1 AND ul.user_id = $2`, todoItemsTable, listsItemsTable, usersListsTable)
-	if err := r.db.Select(&todoItems, query, listId, userId); err != nil {
+	query := fmt.Sprintf(`SELECT ti.id, ti.parent_id, ti.title, ti.description, ti.done, ti.priority, ti.due_date, ti.due_time, %s AS due_at, ti.rrule, ti.repeat_from_completion, ti.assignee_id, %s AS blocked, ti.estimate_minutes, ti.status_id, ti.defer_until, ti.created_at, ti.updated_at, ti.completed_at, li.position FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id INNER JOIN %s u on u.id = ul.user_id
+									INNER JOIN %s tl on tl.id = li.list_id WHERE li.list_id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL %s %s
+									ORDER BY %s %s`,
+		itemDueAt, itemBlocked, todoItemsTable, listsItemsTable, usersListsTable, usersTable, todoListTable, conditions, keyset, itemOrder(filter, "li.position, ti.id"), limits)
+	if err := r.db.Select(&todoItems, query, args...); err != nil {
+		return nil, err
+	}
+
+	if err := attachTags(r.db, userId, todoItems); err != nil {
+		return nil, err
+	}
+
+	return todoItems, nil
+}
+
+// GetByUserId returns the items of all the lists of the user, the lists come in their user-defined order.
+func (r *postgresTodoItemRepository) GetByUserId(ctx context.Context, userId int, filter domain.TodoItemFilter) ([]domain.TodoItem, error) {
+
+	conditions, args := itemConditions(filter, []interface{}{userId})
+
+	var todoItems []domain.TodoItem
+	query := fmt.Sprintf(`SELECT ti.id, li.list_id, ti.parent_id, ti.title, ti.description, ti.done, ti.priority, ti.due_date, ti.due_time, %s AS due_at, ti.rrule, ti.repeat_from_completion, ti.assignee_id, %s AS blocked, ti.estimate_minutes, ti.status_id, ti.defer_until, ti.created_at, ti.updated_at, ti.completed_at, li.position FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id INNER JOIN %s u on u.id = ul.user_id
+									INNER JOIN %s tl on tl.id = li.list_id WHERE ul.user_id = $1 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL %s
+									ORDER BY %s`,
+		itemDueAt, itemBlocked, todoItemsTable, listsItemsTable, usersListsTable, usersTable, todoListTable, conditions, itemOrder(filter, "ul.position, li.position, ti.id"))
+	if err := r.db.Select(&todoItems, query, args...); err != nil {
+		return nil, err
+	}
+
+	if err := attachTags(r.db, userId, todoItems); err != nil {
 		return nil, err
 	}
 
 	return todoItems, nil
 }
 
+// itemConditions turns the filter into the conditions of an item query which joins
+// ti and ul. The arguments of the conditions follow the given ones.
+func itemConditions(filter domain.TodoItemFilter, args []interface{}) (string, []interface{}) {
+
+	conditions, argId := make([]string, 0), len(args)+1
+
+	if !filter.IncludeDeferred {
+		conditions = append(conditions, "AND (ti.defer_until IS NULL OR ti.defer_until <= now())")
+	}
+
+	if filter.DueFrom != "" {
+		conditions = append(conditions, fmt.Sprintf("AND ti.due_date >= $%d", argId))
+		args = append(args, filter.DueFrom)
+		argId++
+	}
+
+	if filter.DueTo != "" {
+		conditions = append(conditions, fmt.Sprintf("AND ti.due_date <= $%d", argId))
+		args = append(args, filter.DueTo)
+		argId++
+	}
+
+	if len(filter.Priorities) > 0 {
+		placeholders := make([]string, 0, len(filter.Priorities))
+		for _, priority := range filter.Priorities {
+			placeholders = append(placeholders, fmt.Sprintf("$%d", argId))
+			args = append(args, priority)
+			argId++
+		}
+		conditions = append(conditions, fmt.Sprintf("AND ti.priority IN (%s)", strings.Join(placeholders, ", ")))
+	}
+
+	if filter.Tag != "" {
+		conditions = append(conditions, fmt.Sprintf(`AND EXISTS (SELECT 1 FROM %s it INNER JOIN %s t on t.id = it.tag_id
+									WHERE it.item_id = ti.id AND t.user_id = ul.user_id AND t.name = $%d)`, itemsTagsTable, tagsTable, argId))
+		args = append(args, filter.Tag)
+		argId++
+	}
+
+	switch filter.Assignee {
+	case "":
+	case domain.AssigneeMe:
+		conditions = append(conditions, "AND ti.assignee_id = ul.user_id")
+	case domain.AssigneeNone:
+		conditions = append(conditions, "AND ti.assignee_id IS NULL")
+	default:
+		conditions = append(conditions, fmt.Sprintf("AND ti.assignee_id = $%d", argId))
+		args = append(args, filter.Assignee)
+		argId++
+	}
+
+	return strings.Join(conditions, " "), args
+}
+
+// itemOrder puts the most urgent items first when the filter asks for it,
+// otherwise the items keep the given order of their positions.
+func itemOrder(filter domain.TodoItemFilter, positions string) string {
+
+	if filter.OrderBy == domain.ItemOrderPriority {
+		return "ti.priority DESC, " + positions
+	}
+
+	return positions
+}
+
+// itemKeyset turns the key of an item of the list into the condition of the items
+// following it in the order of the filter. The arguments of the key follow the given ones.
+func itemKeyset(filter domain.TodoItemFilter, after *domain.ItemKey, args []interface{}) (string, []interface{}) {
+
+	if after == nil {
+		return "", args
+	}
+
+	argId := len(args) + 1
+	if filter.OrderBy == domain.ItemOrderPriority {
+		return fmt.Sprintf("AND (ti.priority < $%d OR ti.priority = $%d AND (li.position, ti.id) > ($%d, $%d))", argId, argId, argId+1, argId+2),
+			append(args, after.Priority, after.Position, after.Id)
+	}
+
+	return fmt.Sprintf("AND (li.position, ti.id) > ($%d, $%d)", argId, argId+1), append(args, after.Position, after.Id)
+}
+
+// limitClause limits the rows of a query to the given number, a zero limit
+// leaves them unlimited. The argument of the limit follows the given ones.
+func limitClause(limit int, args []interface{}) (string, []interface{}) {
+
+	if limit == 0 {
+		return "", args
+	}
+
+	return fmt.Sprintf("LIMIT $%d", len(args)+1), append(args, limit)
+}
+
 func (r *postgresTodoItemRepository) GetById(ctx context.Context, userId, itemId int) (domain.TodoItem, error) {
 	var todoItem domain.TodoItem
-	query := fmt.Sprintf(`SELECT ti.id, ti.title, ti.description, ti.done FROM %s ti 
+	query := fmt.Sprintf(`SELECT ti.id, ti.parent_id, ti.title, ti.description, ti.done, ti.priority, ti.due_date, ti.due_time, %s AS due_at, ti.rrule, ti.repeat_from_completion, ti.assignee_id, %s AS blocked, ti.estimate_minutes, ti.status_id, ti.defer_until, ti.created_at, ti.updated_at, ti.completed_at, li.position FROM %s ti
 									INNER JOIN %s li on li.item_id = ti.id
-									INNER JOIN %s ul on ul.list_id = li.list_id WHERE ti.id = $1 AND ul.user_id = $2`, todoItemsTable, listsItemsTable, usersListsTable)
+									INNER JOIN %s ul on ul.list_id = li.list_id INNER JOIN %s u on u.id = ul.user_id
+									INNER JOIN %s tl on tl.id = li.list_id WHERE ti.id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL`,
+		itemDueAt, itemBlocked, todoItemsTable, listsItemsTable, usersListsTable, usersTable, todoListTable)
 	if err := r.db.Get(&todoItem, query, itemId, userId); err != nil {
 		return todoItem, err
 	}
 
-	return todoItem, nil
+	todoItems := []domain.TodoItem{todoItem}
+	if err := attachTags(r.db, userId, todoItems); err != nil {
+		return todoItem, err
+	}
+
+	return todoItems[0], nil
 }
 
+// Delete moves the item to the trash together with its subtasks.
+// The items stay in the database until Purge removes them.
 func (r *postgresTodoItemRepository) Delete(ctx context.Context, userId, itemId int) error {
-	query := fmt.Sprintf(`DELETE FROM %s ti USING %s li, %s ul WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $1 AND ti.id = $2`,
+	query := fmt.Sprintf(`WITH RECURSIVE subtree AS (
+									SELECT ti.id FROM %s ti INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE ul.user_id = $1 AND ti.id = $2 AND ti.deleted_at IS NULL
+									UNION ALL
+									SELECT c.id FROM %s c INNER JOIN subtree s on c.parent_id = s.id WHERE c.deleted_at IS NULL
+								)
+								UPDATE %s SET deleted_at = now(), updated_at = now() WHERE id IN (SELECT id FROM subtree)`,
+		todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable, todoItemsTable)
+	_, err := r.db.Exec(query, userId, itemId)
+
+	return err
+}
+
+func (r *postgresTodoItemRepository) GetDeleted(ctx context.Context, userId int) ([]domain.TodoItem, error) {
+	var todoItems []domain.TodoItem
+	query := fmt.Sprintf(`SELECT ti.id, ti.title, ti.description, ti.done, ti.deleted_at FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id WHERE ul.user_id = $1 AND ti.deleted_at IS NOT NULL`,
 		todoItemsTable, listsItemsTable, usersListsTable)
+	if err := r.db.Select(&todoItems, query, userId); err != nil {
+		return nil, err
+	}
+
+	return todoItems, nil
+}
+
+// Restore takes the item out of the trash together with the subtasks
+// which were trashed along with it.
+func (r *postgresTodoItemRepository) Restore(ctx context.Context, userId, itemId int) error {
+	query := fmt.Sprintf(`WITH RECURSIVE item AS (
+									SELECT ti.id, ti.deleted_at FROM %s ti INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE ul.user_id = $1 AND ti.id = $2 AND ti.deleted_at IS NOT NULL
+								), subtree AS (
+									SELECT id FROM item
+									UNION ALL
+									SELECT c.id FROM %s c INNER JOIN subtree s on c.parent_id = s.id, item WHERE c.deleted_at = item.deleted_at
+								)
+								UPDATE %s SET deleted_at = NULL, updated_at = now() WHERE id IN (SELECT id FROM subtree)`,
+		todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable, todoItemsTable)
 	_, err := r.db.Exec(query, userId, itemId)
 
 	return err
 }
 
-func (r *postgresTodoItemRepository) Update(ctx context.Context, userId, itemId int, input domain.UpdateTodoItemInput) error {
+// Purge permanently removes the items trashed before the given time
+// together with the items of the lists trashed before it. It returns the
+// storage keys of the files which were attached to them, the files are
+// left for the caller to remove from the store.
+func (r *postgresTodoItemRepository) Purge(ctx context.Context, before time.Time) (int64, []string, error) {
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	condition := fmt.Sprintf("ti.deleted_at < $1 OR ti.id IN (SELECT li.item_id FROM %s li INNER JOIN %s tl on tl.id = li.list_id WHERE tl.deleted_at < $1)",
+		listsItemsTable, todoListTable)
+	purged, keys, err := purgeItems(tx, condition, before)
+	if err != nil {
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	return purged, keys, tx.Commit()
+}
+
+// purgeItems removes the items matched by the condition on ti. The attachments of the
+// items and of their subtasks, which the items take with them, are removed first so
+// that the storage keys of their files are not lost.
+func purgeItems(tx *sqlx.Tx, condition string, args ...interface{}) (int64, []string, error) {
+
+	var keys []string
+	attachmentsQuery := fmt.Sprintf(`WITH RECURSIVE purged AS (
+										SELECT ti.id FROM %s ti WHERE %s
+										UNION
+										SELECT c.id FROM %s c INNER JOIN purged p on c.parent_id = p.id
+									)
+									DELETE FROM %s a WHERE a.item_id IN (SELECT id FROM purged) RETURNING a.storage_key`,
+		todoItemsTable, condition, todoItemsTable, attachmentsTable)
+	if err := tx.Select(&keys, attachmentsQuery, args...); err != nil {
+		return 0, nil, err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s ti WHERE %s", todoItemsTable, condition)
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return purged, keys, nil
+}
+
+// Update changes the given fields of the item and records the revision made by
+// the user. Completing the item schedules its next occurrence when it is
+// recurring and rolls the completion down to its subtasks and up to its parents
+// as asked. Completing or reopening the item sorts the items of its list into
+// the workflow statuses again. The completion is not rolled onto the items
+// blocked by open items when blockCompletion is set.
+func (r *postgresTodoItemRepository) Update(ctx context.Context, userId, itemId int, input domain.UpdateTodoItemInput, rollUp domain.CompletionRollUp, blockCompletion bool) error {
 
 	setValues, args, argId := make([]string, 0), make([]interface{}, 0), 1
 
@@ -97,19 +402,335 @@ func (r *postgresTodoItemRepository) Update(ctx context.Context, userId, itemId
 	}
 
 	if input.Done != nil {
-		setValues = append(setValues, fmt.Sprintf("done=$%d", argId))
+		setValues = append(setValues, fmt.Sprintf("done=$%d, completed_at=CASE WHEN $%d THEN COALESCE(ti.completed_at, now()) END", argId, argId))
 		args = append(args, *input.Done)
 		argId++
 	}
 
+	if input.Priority != nil {
+		setValues = append(setValues, fmt.Sprintf("priority=$%d", argId))
+		args = append(args, *input.Priority)
+		argId++
+	}
+
+	if input.DueDate != nil {
+		setValues = append(setValues, fmt.Sprintf("due_date=$%d", argId))
+		args = append(args, *input.DueDate)
+		argId++
+
+		if *input.DueDate == "" && input.DueTime == nil {
+			setValues = append(setValues, "due_time=NULL")
+		}
+	}
+
+	if input.DueTime != nil {
+		setValues = append(setValues, fmt.Sprintf("due_time=$%d", argId))
+		args = append(args, *input.DueTime)
+		argId++
+	}
+
+	if input.RRule != nil {
+		setValues = append(setValues, fmt.Sprintf("rrule=$%d", argId))
+		args = append(args, *input.RRule)
+		argId++
+	}
+
+	if input.RepeatFromCompletion != nil {
+		setValues = append(setValues, fmt.Sprintf("repeat_from_completion=$%d", argId))
+		args = append(args, *input.RepeatFromCompletion)
+		argId++
+	}
+
+	if input.EstimateMinutes != nil {
+		setValues = append(setValues, fmt.Sprintf("estimate_minutes=$%d", argId))
+		args = append(args, *input.EstimateMinutes)
+		argId++
+	}
+
+	if input.DeferUntil != nil {
+		setValues = append(setValues, fmt.Sprintf("defer_until=$%d", argId))
+		args = append(args, *input.DeferUntil)
+		argId++
+	}
+
+	setValues = append(setValues, "updated_at=now()")
 	setQuery := strings.Join(setValues, ", ")
 
-	query := fmt.Sprintf(`UPDATE %s ti SET %s FROM %s li, %s ul WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $%d AND ti.id = $%d`,
-		todoItemsTable, setQuery, listsItemsTable, usersListsTable, argId, argId+1)
+	query := fmt.Sprintf(`UPDATE %s ti SET %s FROM %s li, %s ul, %s old WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $%d AND ti.id = $%d AND ti.deleted_at IS NULL
+									AND old.id = ti.id RETURNING %s, %s`,
+		todoItemsTable, setQuery, listsItemsTable, usersListsTable, todoItemsTable, argId, argId+1, revisedSelect("old"), revisedSelect("ti"))
 
 	args = append(args, userId, itemId)
 
-	_, err := r.db.Exec(query, args...)
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
 
-	return err
+	var old, updated revisedItem
+	err = tx.QueryRow(query, args...).Scan(append(revisedFields(&old), revisedFields(&updated)...)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return tx.Commit()
+	}
+	if err != nil {
+		tx.Rollback()
+		return itemError(err)
+	}
+
+	if err := recordRevision(tx, userId, itemId, old, updated); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if input.Done != nil {
+		if *input.Done {
+			if err := completeItem(tx, userId, itemId, rollUp, blockCompletion); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+
+		listId, err := targetList(tx, userId, itemId, nil)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := syncStatuses(tx, listId); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ReplaceDescription sets the description of the item, provided the item still
+// has the old description. An item changed in the meantime is left untouched
+// and domain.ErrDescriptionChanged is returned.
+func (r *postgresTodoItemRepository) ReplaceDescription(ctx context.Context, userId, itemId int, old, description string) error {
+
+	query := fmt.Sprintf(`UPDATE %s ti SET description=$1, updated_at=now() FROM %s li, %s ul, %s old WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $2 AND ti.id = $3 AND ti.deleted_at IS NULL
+									AND ti.description = $4 AND old.id = ti.id RETURNING %s, %s`,
+		todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable, revisedSelect("old"), revisedSelect("ti"))
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var before, updated revisedItem
+	err = tx.QueryRow(query, description, userId, itemId, old).Scan(append(revisedFields(&before), revisedFields(&updated)...)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return domain.ErrDescriptionChanged
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := recordRevision(tx, userId, itemId, before, updated); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Snooze defers the item by the given duration from the time it is deferred until
+// or from now, whichever is later, and returns the time it is deferred until.
+func (r *postgresTodoItemRepository) Snooze(ctx context.Context, userId, itemId int, input domain.SnoozeTodoItemInput) (time.Time, error) {
+
+	var deferUntil time.Time
+	query := fmt.Sprintf(`UPDATE %s ti SET defer_until = GREATEST(ti.defer_until, now()) + make_interval(days => $1, hours => $2, mins => $3), updated_at = now()
+									FROM %s li, %s ul WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $4 AND ti.id = $5 AND ti.deleted_at IS NULL
+									RETURNING ti.defer_until`,
+		todoItemsTable, listsItemsTable, usersListsTable)
+	err := r.db.QueryRow(query, input.Days, input.Hours, input.Minutes, userId, itemId).Scan(&deferUntil)
+
+	return deferUntil, err
+}
+
+// completeItem follows up on the completed item: it schedules the next occurrence
+// of a recurring item and rolls the completion down to its subtasks and up to its
+// parents as asked. The subtasks and parents are completed the way the item is:
+// their next occurrences are scheduled, and with blockCompletion set the roll-up
+// fails with domain.ErrItemBlocked when one of them is blocked by open items.
+func completeItem(tx *sql.Tx, userId, itemId int, rollUp domain.CompletionRollUp, blockCompletion bool) error {
+
+	// the next occurrence is scheduled first, so an open one keeps the parent open
+	if err := scheduleNext(tx, userId, itemId, time.Now()); err != nil {
+		return err
+	}
+
+	if rollUp.Children {
+		if err := completeChildren(tx, userId, itemId, blockCompletion); err != nil {
+			return err
+		}
+	}
+
+	if rollUp.Parent {
+		if err := completeParents(tx, userId, itemId, blockCompletion); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Move changes the position of the item inside its list or moves it into
+// another list of the user. An item moved into another list is unassigned
+// unless its assignee is a member of that list too. The moved items are sorted
+// into the workflow statuses of that list.
+func (r *postgresTodoItemRepository) Move(ctx context.Context, userId, itemId int, input domain.TransferTodoItemInput) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	listId, err := targetList(tx, userId, itemId, input.ListId)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return domain.ErrItemNotFound
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	position, err := itemPositions.between(tx, listId, itemId, input.MoveInput)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET list_id = $1, position = $2 WHERE item_id = $3", listsItemsTable)
+	if _, err := tx.Exec(query, listId, position, itemId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// The subtasks follow the item into another list, while the item
+	// itself leaves its parent behind and becomes a top-level one.
+	subtasksQuery := fmt.Sprintf(`WITH RECURSIVE subtree AS (
+									SELECT id FROM %s WHERE parent_id = $2
+									UNION ALL
+									SELECT c.id FROM %s c INNER JOIN subtree s on c.parent_id = s.id
+								)
+								UPDATE %s SET list_id = $1 WHERE item_id IN (SELECT id FROM subtree) AND list_id <> $1`,
+		todoItemsTable, todoItemsTable, listsItemsTable)
+	if _, err := tx.Exec(subtasksQuery, listId, itemId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	parentQuery := fmt.Sprintf(`UPDATE %s ti SET parent_id = NULL, updated_at = now() FROM %s li WHERE li.item_id = ti.parent_id AND ti.id = $1 AND li.list_id <> $2`,
+		todoItemsTable, listsItemsTable)
+	if _, err := tx.Exec(parentQuery, itemId, listId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := unassignNonMembers(tx, userId, listId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := syncStatuses(tx, listId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Copy creates a copy of the item in the given list of the user. The copy
+// is a top-level item, the subtasks of the item are not copied. The copy is
+// put into the first workflow status of the list fitting it.
+func (r *postgresTodoItemRepository) Copy(ctx context.Context, userId, itemId int, input domain.TransferTodoItemInput) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	listId, err := targetList(tx, userId, itemId, input.ListId)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return 0, domain.ErrItemNotFound
+	}
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	position, err := itemPositions.between(tx, listId, 0, input.MoveInput)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	copyId, err := copyItem(tx, itemId, false)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	createListItemsQuery := fmt.Sprintf("INSERT INTO %s (list_id, item_id, position) VALUES ($1, $2, $3)", listsItemsTable)
+	if _, err := tx.Exec(createListItemsQuery, listId, copyId, position); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := syncStatuses(tx, listId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return copyId, tx.Commit()
+}
+
+// targetList returns the list the item is moved or copied into. Both the current
+// list of the item and the target list have to belong to the user, neither the
+// item nor the lists may be in the trash.
+func targetList(q rowQueryer, userId, itemId int, listId *int) (int, error) {
+
+	var sourceId int
+	sourceQuery := fmt.Sprintf(`SELECT li.list_id FROM %s li INNER JOIN %s ul on ul.list_id = li.list_id
+									INNER JOIN %s ti on ti.id = li.item_id INNER JOIN %s tl on tl.id = li.list_id
+									WHERE li.item_id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL`,
+		listsItemsTable, usersListsTable, todoItemsTable, todoListTable)
+	if err := q.QueryRow(sourceQuery, itemId, userId).Scan(&sourceId); err != nil {
+		return 0, err
+	}
+
+	if listId == nil || *listId == sourceId {
+		return sourceId, nil
+	}
+
+	var targetId int
+	targetQuery := fmt.Sprintf(`SELECT tl.id FROM %s tl INNER JOIN %s ul on ul.list_id = tl.id WHERE tl.id = $1 AND ul.user_id = $2 AND tl.deleted_at IS NULL`,
+		todoListTable, usersListsTable)
+	if err := q.QueryRow(targetQuery, *listId, userId).Scan(&targetId); err != nil {
+		return 0, err
+	}
+
+	return targetId, nil
+}
+
+// copyItem inserts a copy of the item, labelled with the same tags, which is not linked to any list yet.
+func copyItem(q rowQueryer, itemId int, resetDone bool) (int, error) {
+
+	var copyId int
+	query := fmt.Sprintf(`WITH copy AS (
+									INSERT INTO %s (title, description, done, completed_at, priority, due_date, due_time, rrule, repeat_from_completion, estimate_minutes, defer_until)
+									SELECT title, description, done AND NOT $2, CASE WHEN NOT $2 THEN completed_at END, priority, due_date, due_time, rrule, repeat_from_completion, estimate_minutes, defer_until FROM %s WHERE id = $1 RETURNING id
+								), tags AS (
+									INSERT INTO %s (item_id, tag_id) SELECT copy.id, it.tag_id FROM copy, %s it WHERE it.item_id = $1
+								)
+								SELECT id FROM copy`,
+		todoItemsTable, todoItemsTable, itemsTagsTable, itemsTagsTable)
+	err := q.QueryRow(query, itemId, resetDone).Scan(&copyId)
+
+	return copyId, err
 }
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/andredubov/todo-backend/internal/domain"
@@ -48,19 +50,60 @@ func TestItem_Create(t *testing.T) {
 				item: domain.TodoItem{
 					Title:       "test title",
 					Description: "test description",
+					Priority:    domain.PriorityHigh,
 				},
 			},
 			mockBehavior: func(args args, id int) {
 				mock.ExpectBegin()
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
 				itemsTableQuery, listsItemsTableQuery := fmt.Sprintf("INSERT INTO %s", todoItemsTable), fmt.Sprintf("INSERT INTO %s", listsItemsTable)
-				mock.ExpectQuery(itemsTableQuery).WithArgs(args.item.Title, args.item.Description).WillReturnRows(rows)
-				mock.ExpectExec(listsItemsTableQuery).WithArgs(args.listId, id).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(itemsTableQuery).WithArgs(args.item.ParentId, args.item.Title, args.item.Description, int64(3), args.item.DueDate, args.item.DueTime, args.item.RRule, args.item.RepeatFromCompletion, args.item.EstimateMinutes, args.item.DeferUntil, args.listId).WillReturnRows(rows)
+				positionRows := sqlmock.NewRows([]string{"position"}).AddRow("V")
+				mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s", listsItemsTable)).WithArgs(args.listId).WillReturnRows(positionRows)
+				mock.ExpectExec(listsItemsTableQuery).WithArgs(args.listId, id, "W").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			wantId:  1,
 			wantErr: false,
 		},
+		{
+			name: "Ok_Subtask",
+			input: args{
+				listId: 1,
+				item: domain.TodoItem{
+					ParentId: intPointer(5),
+					Title:    "test subtask",
+				},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("WITH RECURSIVE ancestors AS (.+) FROM ancestors").WithArgs(5, args.listId).WillReturnRows(sqlmock.NewRows([]string{"depth"}).AddRow(3))
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+				itemsTableQuery, listsItemsTableQuery := fmt.Sprintf("INSERT INTO %s", todoItemsTable), fmt.Sprintf("INSERT INTO %s", listsItemsTable)
+				mock.ExpectQuery(itemsTableQuery).WithArgs(args.item.ParentId, args.item.Title, args.item.Description, args.item.Priority, args.item.DueDate, args.item.DueTime, args.item.RRule, args.item.RepeatFromCompletion, args.item.EstimateMinutes, args.item.DeferUntil, args.listId).WillReturnRows(rows)
+				positionRows := sqlmock.NewRows([]string{"position"}).AddRow("V")
+				mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s", listsItemsTable)).WithArgs(args.listId).WillReturnRows(positionRows)
+				mock.ExpectExec(listsItemsTableQuery).WithArgs(args.listId, id, "W").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantId: 2,
+		},
+		{
+			name: "Subtask Too Deep",
+			input: args{
+				listId: 1,
+				item: domain.TodoItem{
+					ParentId: intPointer(6),
+					Title:    "test subtask",
+				},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("WITH RECURSIVE ancestors AS (.+) FROM ancestors").WithArgs(6, args.listId).WillReturnRows(sqlmock.NewRows([]string{"depth"}).AddRow(4))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
 		{
 			name: "Empty Fields",
 			input: args{
@@ -74,7 +117,7 @@ func TestItem_Create(t *testing.T) {
 				mock.ExpectBegin()
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id).RowError(0, errors.New("insert error"))
 				itemsTableQuery := fmt.Sprintf("INSERT INTO %s", todoItemsTable)
-				mock.ExpectQuery(itemsTableQuery).WithArgs(args.item.Title, args.item.Description).WillReturnRows(rows)
+				mock.ExpectQuery(itemsTableQuery).WithArgs(args.item.ParentId, args.item.Title, args.item.Description, args.item.Priority, args.item.DueDate, args.item.DueTime, args.item.RRule, args.item.RepeatFromCompletion, args.item.EstimateMinutes, args.item.DeferUntil, args.listId).WillReturnRows(rows)
 				mock.ExpectRollback()
 			},
 			wantErr: true,
@@ -92,8 +135,10 @@ func TestItem_Create(t *testing.T) {
 				mock.ExpectBegin()
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
 				itemsTableQuery, listsItemsTableQuery := fmt.Sprintf("INSERT INTO %s", todoItemsTable), fmt.Sprintf("INSERT INTO %s", listsItemsTable)
-				mock.ExpectQuery(itemsTableQuery).WithArgs(args.item.Title, args.item.Description).WillReturnRows(rows)
-				mock.ExpectExec(listsItemsTableQuery).WithArgs(args.listId, id).WillReturnError(errors.New("insert error"))
+				mock.ExpectQuery(itemsTableQuery).WithArgs(args.item.ParentId, args.item.Title, args.item.Description, args.item.Priority, args.item.DueDate, args.item.DueTime, args.item.RRule, args.item.RepeatFromCompletion, args.item.EstimateMinutes, args.item.DeferUntil, args.listId).WillReturnRows(rows)
+				positionRows := sqlmock.NewRows([]string{"position"}).AddRow("V")
+				mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s", listsItemsTable)).WithArgs(args.listId).WillReturnRows(positionRows)
+				mock.ExpectExec(listsItemsTableQuery).WithArgs(args.listId, id, "W").WillReturnError(errors.New("insert error"))
 				mock.ExpectRollback()
 			},
 			wantErr: true,
@@ -105,7 +150,7 @@ func TestItem_Create(t *testing.T) {
 
 			test.mockBehavior(test.input, test.wantId)
 
-			gotId, err := todoItemRepository.Create(context.TODO(), test.input.listId, test.input.item)
+			gotId, err := todoItemRepository.Create(context.TODO(), test.input.listId, test.input.item, 3)
 			if test.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -117,7 +162,7 @@ func TestItem_Create(t *testing.T) {
 	}
 }
 
-func TestTodoItem_GetAll(t *testing.T) {
+func TestTodoItem_CreateTagged(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
@@ -129,73 +174,103 @@ func TestTodoItem_GetAll(t *testing.T) {
 
 	type (
 		args struct {
-			listId int
 			userId int
+			listId int
+			item   domain.TodoItem
 		}
+
 		test struct {
 			name         string
-			mockBehavior func()
 			input        args
-			want         []domain.TodoItem
+			mockBehavior func(args args)
+			wantId       int
+			wantTags     []domain.Tag
 			wantErr      bool
 		}
 	)
 
+	expectItem := func(args args, id int) {
+		mock.ExpectQuery(fmt.Sprintf("INSERT INTO %s", todoItemsTable)).WithArgs(args.item.ParentId, args.item.Title, args.item.Description, args.item.Priority, args.item.DueDate, args.item.DueTime, args.item.RRule, args.item.RepeatFromCompletion, args.item.EstimateMinutes, args.item.DeferUntil, args.listId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+		mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s", listsItemsTable)).WithArgs(args.listId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+		mock.ExpectExec(fmt.Sprintf("INSERT INTO %s", listsItemsTable)).WithArgs(args.listId, id, "W").WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	selectTagQuery := fmt.Sprintf("SELECT id, name, color FROM %s WHERE user_id = (.+) AND lower\\(name\\) = lower\\((.+)\\)", tagsTable)
+	insertTagQuery := fmt.Sprintf("INSERT INTO %s (.+) ON CONFLICT \\(user_id, name\\) DO UPDATE (.+) RETURNING id, name, color", tagsTable)
+	attachTagQuery := fmt.Sprintf("INSERT INTO %s (.+) ON CONFLICT \\(item_id, tag_id\\) DO NOTHING", itemsTagsTable)
+
 	tests := []test{
 		{
 			name: "Ok",
-			mockBehavior: func() {
-				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"}).
-					AddRow(1, "title1", "description1", true).
-					AddRow(2, "title2", "description2", false).
-					AddRow(3, "title3", "description3", false)
-
-				query := fmt.Sprintf("SELECT (.+) FROM %s ti INNER JOIN %s li on (.+) INNER JOIN %s ul on (.+) WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
-				mock.ExpectQuery(query).WithArgs(1, 1).WillReturnRows(rows)
-			},
 			input: args{
-				listId: 1,
 				userId: 1,
+				listId: 2,
+				item:   domain.TodoItem{Title: "call mom", Tags: []domain.Tag{{Name: "Family"}, {Name: "phone"}}},
 			},
-			want: []domain.TodoItem{
-				{Id: 1, Title: "title1", Description: "description1", Done: true},
-				{Id: 2, Title: "title2", Description: "description2", Done: false},
-				{Id: 3, Title: "title3", Description: "description3", Done: false},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				expectItem(args, 3)
+				mock.ExpectQuery(selectTagQuery).WithArgs(args.userId, "Family").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "color"}).AddRow(4, "family", "#ff0000"))
+				mock.ExpectExec(attachTagQuery).WithArgs(3, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(selectTagQuery).WithArgs(args.userId, "phone").WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(insertTagQuery).WithArgs(args.userId, "phone").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "color"}).AddRow(5, "phone", ""))
+				mock.ExpectExec(attachTagQuery).WithArgs(3, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
+			wantId:   3,
+			wantTags: []domain.Tag{{Id: 4, Name: "family", Color: "#ff0000"}, {Id: 5, Name: "phone"}},
 		},
 		{
-			name: "No Records",
-			mockBehavior: func() {
-				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"})
-				query := fmt.Sprintf("SELECT (.+) FROM %s ti INNER JOIN %s li on (.+) INNER JOIN %s ul on (.+) WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
-				mock.ExpectQuery(query).WithArgs(1, 1).WillReturnRows(rows)
+			name: "Tag Not Created",
+			input: args{
+				userId: 1,
+				listId: 2,
+				item:   domain.TodoItem{Title: "call mom", Tags: []domain.Tag{{Name: "phone"}}},
+			},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				expectItem(args, 3)
+				mock.ExpectQuery(selectTagQuery).WithArgs(args.userId, "phone").WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(insertTagQuery).WithArgs(args.userId, "phone").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
 			},
+			wantErr: true,
+		},
+		{
+			name: "Item Not Created",
 			input: args{
-				listId: 1,
 				userId: 1,
+				listId: 2,
+				item:   domain.TodoItem{Title: "call mom", Tags: []domain.Tag{{Name: "phone"}}},
 			},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(fmt.Sprintf("INSERT INTO %s", todoItemsTable)).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 
-			test.mockBehavior()
+			test.mockBehavior(test.input)
 
-			got, err := todoItemRepository.GetAll(context.TODO(), test.input.userId, test.input.listId)
+			gotId, gotTags, err := todoItemRepository.CreateTagged(context.TODO(), test.input.userId, test.input.listId, test.input.item, 3)
 			if test.wantErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, test.want, got)
+				assert.Equal(t, test.wantId, gotId)
+				assert.Equal(t, test.wantTags, gotTags)
 			}
-
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
-func TestTodoItem_GetById(t *testing.T) {
+func TestTodoItem_GetAll(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
@@ -207,44 +282,204 @@ func TestTodoItem_GetById(t *testing.T) {
 
 	type (
 		args struct {
-			itemId int
+			listId int
 			userId int
+			filter domain.TodoItemFilter
+			after  *domain.ItemKey
+			limit  int
 		}
 		test struct {
 			name         string
 			mockBehavior func()
 			input        args
-			want         domain.TodoItem
+			want         []domain.TodoItem
 			wantErr      bool
 		}
 	)
 
+	tagsQuery := fmt.Sprintf("SELECT it.item_id, (.+) FROM %s it INNER JOIN %s t on (.+) WHERE t.user_id = (.+) AND it.item_id IN (.+)", itemsTagsTable, tagsTable)
+	tagColumns := []string{"item_id", "id", "name", "color"}
+
 	tests := []test{
 		{
 			name: "Ok",
 			mockBehavior: func() {
-				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"}).AddRow(1, "title1", "description1", true)
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"}).
+					AddRow(1, "title1", "description1", true).
+					AddRow(2, "title2", "description2", false).
+					AddRow(3, "title3", "description3", false)
+
 				query := fmt.Sprintf("SELECT (.+) FROM %s ti INNER JOIN %s li on (.+) INNER JOIN %s ul on (.+) WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
 				mock.ExpectQuery(query).WithArgs(1, 1).WillReturnRows(rows)
+				tagRows := sqlmock.NewRows(tagColumns).
+					AddRow(1, 1, "#errand", "#ff0000").
+					AddRow(3, 1, "#errand", "#ff0000").
+					AddRow(3, 2, "@waiting", "")
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 1, 2, 3).WillReturnRows(tagRows)
 			},
 			input: args{
-				itemId: 1,
+				listId: 1,
 				userId: 1,
 			},
-			want: domain.TodoItem{Id: 1, Title: "title1", Description: "description1", Done: true},
+			want: []domain.TodoItem{
+				{Id: 1, Title: "title1", Description: "description1", Done: true, Tags: []domain.Tag{{Id: 1, Name: "#errand", Color: "#ff0000"}}},
+				{Id: 2, Title: "title2", Description: "description2", Done: false},
+				{Id: 3, Title: "title3", Description: "description3", Done: false, Tags: []domain.Tag{{Id: 1, Name: "#errand", Color: "#ff0000"}, {Id: 2, Name: "@waiting"}}},
+			},
 		},
 		{
-			name: "Not Found",
+			name: "Ok_DueRange",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "due_date", "due_time", "due_at"}).
+					AddRow(1, "title1", "description1", false, time.Date(2023, time.May, 2, 0, 0, 0, 0, time.UTC), nil, time.Date(2023, time.May, 2, 22, 0, 0, 0, time.UTC)).
+					AddRow(2, "title2", "description2", false, time.Date(2023, time.May, 3, 0, 0, 0, 0, time.UTC), []byte("09:30:00"), time.Date(2023, time.May, 3, 7, 30, 0, 0, time.UTC))
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti INNER JOIN %s li on (.+) INNER JOIN %s ul on (.+) WHERE (.+) AND ti.due_date >= (.+) AND ti.due_date <= (.+) ORDER BY (.+)",
+					todoItemsTable, listsItemsTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(1, 1, "2023-05-01", "2023-05-07").WillReturnRows(rows)
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 1, 2).WillReturnRows(sqlmock.NewRows(tagColumns))
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+				filter: domain.TodoItemFilter{DueFrom: "2023-05-01", DueTo: "2023-05-07"},
+			},
+			want: []domain.TodoItem{
+				{Id: 1, Title: "title1", Description: "description1", DueDate: "2023-05-02", DueAt: timePointer(time.Date(2023, time.May, 2, 22, 0, 0, 0, time.UTC))},
+				{Id: 2, Title: "title2", Description: "description2", DueDate: "2023-05-03", DueTime: "09:30", DueAt: timePointer(time.Date(2023, time.May, 3, 7, 30, 0, 0, time.UTC))},
+			},
+		},
+		{
+			name: "Ok_ByPriority",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "priority"}).
+					AddRow(2, "title2", "description2", false, 4).
+					AddRow(1, "title1", "description1", false, 3)
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti INNER JOIN %s li on (.+) WHERE (.+) AND ti.priority IN \\(\\$3, \\$4\\) ORDER BY ti.priority DESC, li.position, ti.id",
+					todoItemsTable, listsItemsTable)
+				mock.ExpectQuery(query).WithArgs(1, 1, int64(3), int64(4)).WillReturnRows(rows)
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 2, 1).WillReturnRows(sqlmock.NewRows(tagColumns))
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+				filter: domain.TodoItemFilter{Priorities: []domain.Priority{domain.PriorityHigh, domain.PriorityUrgent}, OrderBy: domain.ItemOrderPriority},
+			},
+			want: []domain.TodoItem{
+				{Id: 2, Title: "title2", Description: "description2", Priority: domain.PriorityUrgent},
+				{Id: 1, Title: "title1", Description: "description1", Priority: domain.PriorityHigh},
+			},
+		},
+		{
+			name: "Ok_ByTag",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"}).AddRow(3, "title3", "description3", false)
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti (.+) WHERE (.+) AND EXISTS \\(SELECT 1 FROM %s it INNER JOIN %s t on (.+) AND t.name = \\$3\\) ORDER BY (.+)",
+					todoItemsTable, itemsTagsTable, tagsTable)
+				mock.ExpectQuery(query).WithArgs(1, 1, "@waiting").WillReturnRows(rows)
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 3).WillReturnRows(sqlmock.NewRows(tagColumns).AddRow(3, 2, "@waiting", ""))
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+				filter: domain.TodoItemFilter{Tag: "@waiting"},
+			},
+			want: []domain.TodoItem{
+				{Id: 3, Title: "title3", Description: "description3", Tags: []domain.Tag{{Id: 2, Name: "@waiting"}}},
+			},
+		},
+		{
+			name: "Ok_HidesDeferred",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"}).AddRow(2, "title2", "description2", false)
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti (.+) WHERE (.+) AND \\(ti.defer_until IS NULL OR ti.defer_until <= now\\(\\)\\) ORDER BY (.+)", todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(1, 1).WillReturnRows(rows)
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows(tagColumns))
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+			},
+			want: []domain.TodoItem{
+				{Id: 2, Title: "title2", Description: "description2"},
+			},
+		},
+		{
+			name: "Ok_IncludeDeferred",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "defer_until"}).
+					AddRow(1, "title1", "description1", false, time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC)).
+					AddRow(2, "title2", "description2", false, nil)
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti (.+) WHERE (.+) AND tl.deleted_at IS NULL\\s+ORDER BY (.+)", todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(1, 1).WillReturnRows(rows)
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 1, 2).WillReturnRows(sqlmock.NewRows(tagColumns))
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+				filter: domain.TodoItemFilter{IncludeDeferred: true},
+			},
+			want: []domain.TodoItem{
+				{Id: 1, Title: "title1", Description: "description1", DeferUntil: timePointer(time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC))},
+				{Id: 2, Title: "title2", Description: "description2"},
+			},
+		},
+		{
+			name: "Ok_AfterCursor",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "position"}).
+					AddRow(4, "title4", "description4", "k")
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti (.+) AND \\(li.position, ti.id\\) > \\(\\$3, \\$4\\)\\s+ORDER BY li.position, ti.id LIMIT \\$5", todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(1, 1, "V", 3, 2).WillReturnRows(rows)
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 4).WillReturnRows(sqlmock.NewRows(tagColumns))
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+				after:  &domain.ItemKey{Position: "V", Id: 3},
+				limit:  2,
+			},
+			want: []domain.TodoItem{
+				{Id: 4, Title: "title4", Description: "description4", Position: "k"},
+			},
+		},
+		{
+			name: "Ok_AfterCursorByPriority",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "position"}).
+					AddRow(4, "title4", "description4", "k")
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti (.+) AND \\(ti.priority < \\$3 OR ti.priority = \\$3 AND \\(li.position, ti.id\\) > \\(\\$4, \\$5\\)\\)\\s+ORDER BY ti.priority DESC, li.position, ti.id LIMIT \\$6", todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(1, 1, 3, "V", 3, 2).WillReturnRows(rows)
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 4).WillReturnRows(sqlmock.NewRows(tagColumns))
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+				filter: domain.TodoItemFilter{OrderBy: domain.ItemOrderPriority},
+				after:  &domain.ItemKey{Priority: domain.PriorityHigh, Position: "V", Id: 3},
+				limit:  2,
+			},
+			want: []domain.TodoItem{
+				{Id: 4, Title: "title4", Description: "description4", Position: "k"},
+			},
+		},
+		{
+			name: "No Records",
 			mockBehavior: func() {
 				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"})
 				query := fmt.Sprintf("SELECT (.+) FROM %s ti INNER JOIN %s li on (.+) INNER JOIN %s ul on (.+) WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
-				mock.ExpectQuery(query).WithArgs(765, 1).WillReturnRows(rows)
+				mock.ExpectQuery(query).WithArgs(1, 1).WillReturnRows(rows)
 			},
 			input: args{
-				itemId: 765,
+				listId: 1,
 				userId: 1,
 			},
-			wantErr: true,
 		},
 	}
 
@@ -253,7 +488,7 @@ func TestTodoItem_GetById(t *testing.T) {
 
 			test.mockBehavior()
 
-			got, err := todoItemRepository.GetById(context.TODO(), test.input.userId, test.input.itemId)
+			got, err := todoItemRepository.GetAll(context.TODO(), test.input.userId, test.input.listId, test.input.filter, test.input.after, test.input.limit)
 			if test.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -266,7 +501,7 @@ func TestTodoItem_GetById(t *testing.T) {
 	}
 }
 
-func TestTodoItem_Delete(t *testing.T) {
+func TestTodoItem_GetByUserId(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
@@ -278,37 +513,84 @@ func TestTodoItem_Delete(t *testing.T) {
 
 	type (
 		args struct {
-			itemId int
 			userId int
+			filter domain.TodoItemFilter
 		}
 		test struct {
 			name         string
 			mockBehavior func()
 			input        args
+			want         []domain.TodoItem
 			wantErr      bool
 		}
 	)
 
+	tagsQuery := fmt.Sprintf("SELECT it.item_id, (.+) FROM %s it INNER JOIN %s t on (.+) WHERE t.user_id = (.+) AND it.item_id IN (.+)", itemsTagsTable, tagsTable)
+	tagColumns := []string{"item_id", "id", "name", "color"}
+
 	tests := []test{
 		{
-			name: "Ok",
+			name: "Ok_ByTag",
 			mockBehavior: func() {
-				query := fmt.Sprintf("DELETE FROM %s ti USING %s li, %s ul WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
-				mock.ExpectExec(query).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				rows := sqlmock.NewRows([]string{"id", "list_id", "title", "description", "done"}).
+					AddRow(3, 1, "title3", "description3", false).
+					AddRow(7, 2, "title7", "description7", false)
+
+				query := fmt.Sprintf("SELECT ti.id, li.list_id, (.+) FROM %s ti (.+) WHERE ul.user_id = \\$1 (.+) AND t.name = \\$2\\) ORDER BY ul.position, li.position, ti.id",
+					todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(1, "@waiting").WillReturnRows(rows)
+				tagRows := sqlmock.NewRows(tagColumns).
+					AddRow(3, 2, "@waiting", "").
+					AddRow(7, 2, "@waiting", "")
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 3, 7).WillReturnRows(tagRows)
 			},
 			input: args{
-				itemId: 1,
 				userId: 1,
+				filter: domain.TodoItemFilter{Tag: "@waiting"},
+			},
+			want: []domain.TodoItem{
+				{Id: 3, ListId: 1, Title: "title3", Description: "description3", Tags: []domain.Tag{{Id: 2, Name: "@waiting"}}},
+				{Id: 7, ListId: 2, Title: "title7", Description: "description7", Tags: []domain.Tag{{Id: 2, Name: "@waiting"}}},
 			},
 		},
 		{
-			name: "Not Found",
+			name: "Ok_AssignedToMe",
 			mockBehavior: func() {
-				query := fmt.Sprintf("DELETE FROM %s ti USING %s li, %s ul WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
-				mock.ExpectExec(query).WithArgs(1, 404).WillReturnError(sql.ErrNoRows)
+				rows := sqlmock.NewRows([]string{"id", "list_id", "title", "description", "done", "assignee_id"}).
+					AddRow(4, 2, "title4", "description4", false, 1)
+
+				query := fmt.Sprintf("SELECT ti.id, li.list_id, (.+) FROM %s ti (.+) WHERE ul.user_id = \\$1 (.+) AND ti.assignee_id = ul.user_id ORDER BY ul.position, li.position, ti.id",
+					todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 4).WillReturnRows(sqlmock.NewRows(tagColumns))
+			},
+			input: args{
+				userId: 1,
+				filter: domain.TodoItemFilter{Assignee: domain.AssigneeMe},
+			},
+			want: []domain.TodoItem{
+				{Id: 4, ListId: 2, Title: "title4", Description: "description4", AssigneeId: intPointer(1)},
+			},
+		},
+		{
+			name: "Ok_ByPriority",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "list_id", "title", "description", "done"})
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti (.+) ORDER BY ti.priority DESC, ul.position, li.position, ti.id", todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				filter: domain.TodoItemFilter{OrderBy: domain.ItemOrderPriority},
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func() {
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti (.+)", todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(1).WillReturnError(sql.ErrConnDone)
 			},
 			input: args{
-				itemId: 404,
 				userId: 1,
 			},
 			wantErr: true,
@@ -320,11 +602,12 @@ func TestTodoItem_Delete(t *testing.T) {
 
 			test.mockBehavior()
 
-			err := todoItemRepository.Delete(context.TODO(), test.input.userId, test.input.itemId)
+			got, err := todoItemRepository.GetByUserId(context.TODO(), test.input.userId, test.input.filter)
 			if test.wantErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -332,7 +615,7 @@ func TestTodoItem_Delete(t *testing.T) {
 	}
 }
 
-func TestTodoItem_Update(t *testing.T) {
+func TestTodoItem_GetById(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
@@ -344,74 +627,67 @@ func TestTodoItem_Update(t *testing.T) {
 
 	type (
 		args struct {
-			itemId              int
-			userId              int
-			updateTodoItemInput domain.UpdateTodoItemInput
+			itemId int
+			userId int
 		}
 		test struct {
 			name         string
 			mockBehavior func()
 			input        args
+			want         domain.TodoItem
 			wantErr      bool
 		}
 	)
 
 	tests := []test{
 		{
-			name: "OK_AllFields",
+			name: "Ok",
 			mockBehavior: func() {
-				query := fmt.Sprintf("UPDATE %s ti SET (.+) FROM %s li, %s ul WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
-				mock.ExpectExec(query).WithArgs("new title", "new description", true, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "created_at", "updated_at", "completed_at"}).
+					AddRow(1, "title1", "description1", true, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC), time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC))
+				query := fmt.Sprintf("SELECT (.+), ti.created_at, ti.updated_at, ti.completed_at, (.+) FROM %s ti INNER JOIN %s li on (.+) INNER JOIN %s ul on (.+) WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(1, 1).WillReturnRows(rows)
+				tagsQuery := fmt.Sprintf("SELECT it.item_id, (.+) FROM %s it INNER JOIN %s t on (.+) WHERE (.+)", itemsTagsTable, tagsTable)
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows([]string{"item_id", "id", "name", "color"}))
 			},
 			input: args{
 				itemId: 1,
 				userId: 1,
-				updateTodoItemInput: domain.UpdateTodoItemInput{
-					Title:       stringPointer("new title"),
-					Description: stringPointer("new description"),
-					Done:        boolPointer(true),
-				},
 			},
-		},
-		{
-			name: "OK_WithoutDone",
-			mockBehavior: func() {
-				query := fmt.Sprintf("UPDATE %s ti SET (.+) FROM %s li, %s ul WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
-				mock.ExpectExec(query).WithArgs("new title", "new description", 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
-			},
-			input: args{
-				itemId: 1,
-				userId: 1,
-				updateTodoItemInput: domain.UpdateTodoItemInput{
-					Title:       stringPointer("new title"),
-					Description: stringPointer("new description"),
-				},
+			want: domain.TodoItem{Id: 1, Title: "title1", Description: "description1", Done: true,
+				CreatedAt:   timePointer(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)),
+				UpdatedAt:   timePointer(time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)),
+				CompletedAt: timePointer(time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)),
 			},
 		},
 		{
-			name: "OK_WithoutDoneAndDescription",
+			name: "Ok_Blocked",
 			mockBehavior: func() {
-				query := fmt.Sprintf("UPDATE %s ti SET (.+) FROM %s li, %s ul WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
-				mock.ExpectExec(query).WithArgs("new title", 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "blocked"}).AddRow(2, "title2", "description2", false, true)
+				query := fmt.Sprintf("SELECT (.+), EXISTS \\(SELECT 1 FROM %s d (.+)\\) AS blocked, (.+) FROM %s ti INNER JOIN %s li on (.+) WHERE (.+)",
+					itemDependenciesTable, todoItemsTable, listsItemsTable)
+				mock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(rows)
+				tagsQuery := fmt.Sprintf("SELECT it.item_id, (.+) FROM %s it INNER JOIN %s t on (.+) WHERE (.+)", itemsTagsTable, tagsTable)
+				mock.ExpectQuery(tagsQuery).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"item_id", "id", "name", "color"}))
 			},
 			input: args{
-				itemId: 1,
+				itemId: 2,
 				userId: 1,
-				updateTodoItemInput: domain.UpdateTodoItemInput{
-					Title: stringPointer("new title"),
-				},
 			},
+			want: domain.TodoItem{Id: 2, Title: "title2", Description: "description2", Blocked: true},
 		},
 		{
-			name: "OK_NoInputFields",
+			name: "Not Found",
 			mockBehavior: func() {
-				query := fmt.Sprintf("UPDATE %s ti SET FROM %s li, %s ul WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
-				mock.ExpectExec(query).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"})
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti INNER JOIN %s li on (.+) INNER JOIN %s ul on (.+) WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(765, 1).WillReturnRows(rows)
 			},
 			input: args{
-				itemId: 1,
+				itemId: 765,
 				userId: 1,
 			},
+			wantErr: true,
 		},
 	}
 
@@ -420,11 +696,12 @@ func TestTodoItem_Update(t *testing.T) {
 
 			test.mockBehavior()
 
-			err := todoItemRepository.Update(context.TODO(), test.input.userId, test.input.itemId, test.input.updateTodoItemInput)
+			got, err := todoItemRepository.GetById(context.TODO(), test.input.userId, test.input.itemId)
 			if test.wantErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -432,10 +709,1092 @@ func TestTodoItem_Update(t *testing.T) {
 	}
 }
 
-func stringPointer(s string) *string {
-	return &s
-}
-
+func TestTodoItem_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	type (
+		args struct {
+			itemId int
+			userId int
+		}
+		test struct {
+			name         string
+			mockBehavior func()
+			input        args
+			wantErr      bool
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				query := fmt.Sprintf("WITH RECURSIVE subtree AS (.+) UPDATE %s SET deleted_at = now\\(\\), updated_at = now\\(\\) WHERE id IN \\(SELECT id FROM subtree\\)", todoItemsTable)
+				mock.ExpectExec(query).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+			},
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func() {
+				query := fmt.Sprintf("WITH RECURSIVE subtree AS (.+) UPDATE %s SET deleted_at = now\\(\\), updated_at = now\\(\\) WHERE id IN \\(SELECT id FROM subtree\\)", todoItemsTable)
+				mock.ExpectExec(query).WithArgs(1, 404).WillReturnError(sql.ErrNoRows)
+			},
+			input: args{
+				itemId: 404,
+				userId: 1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			err := todoItemRepository.Delete(context.TODO(), test.input.userId, test.input.itemId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItem_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	type (
+		args struct {
+			itemId              int
+			userId              int
+			updateTodoItemInput domain.UpdateTodoItemInput
+			rollUp              domain.CompletionRollUp
+			blockCompletion     bool
+		}
+		test struct {
+			name         string
+			mockBehavior func()
+			input        args
+			wantErr      bool
+		}
+	)
+
+	recurrenceQuery := fmt.Sprintf("SELECT ti.rrule, ti.repeat_from_completion, ti.occurrence, ti.due_date, li.list_id, u.timezone FROM %s ti (.+) AND ti.rrule <> ''", todoItemsTable)
+	listQuery := fmt.Sprintf("SELECT li.list_id FROM %s li INNER JOIN %s ul on (.+) WHERE (.+)", listsItemsTable, usersListsTable)
+	revisionQuery := fmt.Sprintf("INSERT INTO %s \\(item_id, user_id, changes\\) VALUES", itemRevisionsTable)
+	childrenQuery := fmt.Sprintf("WITH RECURSIVE subtree AS (.+) UPDATE %s SET done = true, completed_at = now\\(\\), updated_at = now\\(\\) WHERE id IN \\(SELECT id FROM subtree\\) (.+) RETURNING id", todoItemsTable)
+	parentQuery := fmt.Sprintf("SELECT ti.id, EXISTS (.+) FROM %s c INNER JOIN %s ti on ti.id = c.parent_id WHERE c.id = \\$1 (.+)", todoItemsTable, todoItemsTable)
+	completeParentQuery := fmt.Sprintf("UPDATE %s SET done = true, completed_at = now\\(\\), updated_at = now\\(\\) WHERE id = \\$1", todoItemsTable)
+
+	tests := []test{
+		{
+			name: "OK_AllFields",
+			mockBehavior: func() {
+				query := fmt.Sprintf("UPDATE %s ti SET (.+) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs("new title", "new description", true, 1, 1).
+					WillReturnRows(revisedRow(map[string][2]driver.Value{"title": {"title", "new title"}, "description": {"description", "new description"}, "done": {false, true}}))
+				mock.ExpectExec(revisionQuery).WithArgs(1, 1, `{"description":{"old":"description","new":"new description"},"done":{"old":false,"new":true},"title":{"old":"title","new":"new title"}}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(listQuery).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				expectStatusSync(mock, 7, false)
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Title:       stringPointer("new title"),
+					Description: stringPointer("new description"),
+					Done:        boolPointer(true),
+				},
+			},
+		},
+		{
+			name: "OK_DueDateAndTime",
+			mockBehavior: func() {
+				query := fmt.Sprintf("UPDATE %s ti SET due_date=(.+), due_time=(.+), updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs("2023-05-10", "18:00", 1, 1).
+					WillReturnRows(revisedRow(map[string][2]driver.Value{"due_date": {nil, "2023-05-10"}, "due_time": {nil, "18:00:00"}}))
+				mock.ExpectExec(revisionQuery).WithArgs(1, 1, `{"due_date":{"old":"","new":"2023-05-10"},"due_time":{"old":"","new":"18:00"}}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					DueDate: datePointer("2023-05-10"),
+					DueTime: timeOfDayPointer("18:00"),
+				},
+			},
+		},
+		{
+			name: "OK_Priority",
+			mockBehavior: func() {
+				query := fmt.Sprintf("UPDATE %s ti SET priority=(.+), updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(int64(4), 1, 1).WillReturnRows(revisedRow(map[string][2]driver.Value{"priority": {int64(0), int64(4)}}))
+				mock.ExpectExec(revisionQuery).WithArgs(1, 1, `{"priority":{"old":"none","new":"urgent"}}`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Priority: priorityPointer(domain.PriorityUrgent),
+				},
+			},
+		},
+		{
+			name: "OK_ClearDueDate",
+			mockBehavior: func() {
+				query := fmt.Sprintf("UPDATE %s ti SET due_date=(.+), due_time=NULL, updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(nil, 1, 1).WillReturnRows(revisedRow(map[string][2]driver.Value{"due_date": {"2023-05-10", nil}}))
+				mock.ExpectExec(revisionQuery).WithArgs(1, 1, `{"due_date":{"old":"2023-05-10","new":""}}`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					DueDate: datePointer(""),
+				},
+			},
+		},
+		{
+			name: "OK_ClearDeferral",
+			mockBehavior: func() {
+				query := fmt.Sprintf("UPDATE %s ti SET defer_until=(.+), updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(nil, 1, 1).WillReturnRows(revisedRow(nil))
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					DeferUntil: timestampPointer(""),
+				},
+			},
+		},
+		{
+			name: "OK_WithoutDone",
+			mockBehavior: func() {
+				query := fmt.Sprintf("UPDATE %s ti SET (.+) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs("new title", "new description", 1, 1).
+					WillReturnRows(revisedRow(map[string][2]driver.Value{"title": {"title", "new title"}, "description": {"description", "new description"}}))
+				mock.ExpectExec(revisionQuery).WithArgs(1, 1, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Title:       stringPointer("new title"),
+					Description: stringPointer("new description"),
+				},
+			},
+		},
+		{
+			name: "OK_WithoutDoneAndDescription",
+			mockBehavior: func() {
+				query := fmt.Sprintf("UPDATE %s ti SET (.+) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs("new title", 1, 1).WillReturnRows(revisedRow(map[string][2]driver.Value{"title": {"title", "new title"}}))
+				mock.ExpectExec(revisionQuery).WithArgs(1, 1, `{"title":{"old":"title","new":"new title"}}`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Title: stringPointer("new title"),
+				},
+			},
+		},
+		{
+			name: "OK_Defer",
+			mockBehavior: func() {
+				query := fmt.Sprintf("UPDATE %s ti SET defer_until=\\$1, updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				deferUntil := time.Date(2026, time.April, 1, 11, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(deferUntil.UTC(), 1, 2).WillReturnRows(revisedRow(map[string][2]driver.Value{"defer_until": {nil, deferUntil}}))
+				mock.ExpectExec(revisionQuery).WithArgs(2, 1, `{"defer_until":{"old":"","new":"2026-04-01T09:00:00Z"}}`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 2,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					DeferUntil: timestampPointer("2026-04-01T09:00:00Z"),
+				},
+			},
+		},
+		{
+			name: "OK_NoInputFields",
+			mockBehavior: func() {
+				query := fmt.Sprintf("UPDATE %s ti SET updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(1, 1).WillReturnRows(revisedRow(nil))
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+			},
+		},
+		{
+			name: "OK_CompleteChildrenAndParents",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				query := fmt.Sprintf("UPDATE %s ti SET done=\\$1, completed_at=CASE WHEN \\$1 THEN COALESCE\\(ti.completed_at, now\\(\\)\\) END, updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(true, 1, 2).WillReturnRows(revisedRow(map[string][2]driver.Value{"done": {false, true}}))
+				mock.ExpectExec(revisionQuery).WithArgs(2, 1, `{"done":{"old":false,"new":true}}`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(childrenQuery).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(3, 1).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(4, 1).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(parentQuery).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"id", "blocked"}).AddRow(7, false))
+				mock.ExpectExec(completeParentQuery).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(7, 1).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(parentQuery).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id", "blocked"}))
+				mock.ExpectQuery(listQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				expectStatusSync(mock, 7, false)
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 2,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Done: boolPointer(true),
+				},
+				rollUp: domain.CompletionRollUp{Children: true, Parent: true},
+			},
+		},
+		{
+			name: "OK_Reopen",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				query := fmt.Sprintf("UPDATE %s ti SET done=\\$1, completed_at=CASE WHEN \\$1 THEN COALESCE\\(ti.completed_at, now\\(\\)\\) END, updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(false, 1, 2).WillReturnRows(revisedRow(map[string][2]driver.Value{"done": {true, false}}))
+				mock.ExpectExec(revisionQuery).WithArgs(2, 1, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(listQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				expectStatusSync(mock, 7, false)
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 2,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Done: boolPointer(false),
+				},
+				rollUp: domain.CompletionRollUp{Children: true, Parent: true},
+			},
+		},
+		{
+			name: "OK_NoRollUpForOtherUser",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				query := fmt.Sprintf("UPDATE %s ti SET done=(.+), updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(true, 2, 2).WillReturnRows(sqlmock.NewRows(revisedColumns))
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 2,
+				userId: 2,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Done: boolPointer(true),
+				},
+				rollUp: domain.CompletionRollUp{Children: true, Parent: true},
+			},
+		},
+		{
+			name: "Failed_RollUp",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				query := fmt.Sprintf("UPDATE %s ti SET done=(.+), updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(true, 1, 2).WillReturnRows(revisedRow(map[string][2]driver.Value{"done": {false, true}}))
+				mock.ExpectExec(revisionQuery).WithArgs(2, 1, `{"done":{"old":false,"new":true}}`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(childrenQuery).WithArgs(2).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			input: args{
+				itemId: 2,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Done: boolPointer(true),
+				},
+				rollUp: domain.CompletionRollUp{Children: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed_BlockedParent",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				query := fmt.Sprintf("UPDATE %s ti SET done=(.+), updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING old.title, (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectQuery(query).WithArgs(true, 1, 2).WillReturnRows(revisedRow(map[string][2]driver.Value{"done": {false, true}}))
+				mock.ExpectExec(revisionQuery).WithArgs(2, 1, `{"done":{"old":false,"new":true}}`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(parentQuery).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"id", "blocked"}).AddRow(7, true))
+				mock.ExpectRollback()
+			},
+			input: args{
+				itemId: 2,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Done: boolPointer(true),
+				},
+				rollUp:          domain.CompletionRollUp{Parent: true},
+				blockCompletion: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed_Revision",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				query := fmt.Sprintf("UPDATE %s ti SET title=(.+) FROM %s li, %s ul, %s old WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+				mock.ExpectQuery(query).WithArgs("new title", 1, 2).WillReturnRows(revisedRow(map[string][2]driver.Value{"title": {"title", "new title"}}))
+				mock.ExpectExec(revisionQuery).WithArgs(2, 1, sqlmock.AnyArg()).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			input: args{
+				itemId: 2,
+				userId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Title: stringPointer("new title"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			err := todoItemRepository.Update(context.TODO(), test.input.userId, test.input.itemId, test.input.updateTodoItemInput, test.input.rollUp, test.input.blockCompletion)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItem_ReplaceDescription(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	query := fmt.Sprintf("UPDATE %s ti SET description=(.+) AND ti.description = (.+) RETURNING", todoItemsTable)
+	revisionQuery := fmt.Sprintf("INSERT INTO %s \\(item_id, user_id, changes\\) VALUES", itemRevisionsTable)
+
+	tests := []struct {
+		name         string
+		mockBehavior func()
+		wantErrIs    error
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs("- [x] milk", 1, 2, "- [ ] milk").
+					WillReturnRows(revisedRow(map[string][2]driver.Value{"description": {"- [ ] milk", "- [x] milk"}}))
+				mock.ExpectExec(revisionQuery).WithArgs(2, 1, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Changed Meanwhile",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs("- [x] milk", 1, 2, "- [ ] milk").WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErrIs: domain.ErrDescriptionChanged,
+			wantErr:   true,
+		},
+		{
+			name: "Database Error",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs("- [x] milk", 1, 2, "- [ ] milk").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			err := todoItemRepository.ReplaceDescription(context.TODO(), 1, 2, "- [ ] milk", "- [x] milk")
+			if test.wantErr {
+				assert.Error(t, err)
+				if test.wantErrIs != nil {
+					assert.True(t, errors.Is(err, test.wantErrIs))
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItem_Snooze(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+			itemId int
+			input  domain.SnoozeTodoItemInput
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			want         time.Time
+			wantErr      error
+		}
+	)
+
+	deferUntil := time.Date(2026, time.March, 30, 9, 0, 0, 0, time.UTC)
+	query := fmt.Sprintf("UPDATE %s ti SET defer_until = GREATEST\\(ti.defer_until, now\\(\\)\\) \\+ make_interval\\(days => \\$1, hours => \\$2, mins => \\$3\\), updated_at = now\\(\\) FROM %s li, %s ul WHERE (.+) RETURNING ti.defer_until",
+		todoItemsTable, listsItemsTable, usersListsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows([]string{"defer_until"}).AddRow(deferUntil)
+				mock.ExpectQuery(query).WithArgs(1, 2, 0, args.userId, args.itemId).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				itemId: 3,
+				input:  domain.SnoozeTodoItemInput{Days: 1, Hours: 2},
+			},
+			want: deferUntil,
+		},
+		{
+			name: "Foreign Item",
+			mockBehavior: func(args args) {
+				mock.ExpectQuery(query).WithArgs(0, 0, 30, args.userId, args.itemId).WillReturnRows(sqlmock.NewRows([]string{"defer_until"}))
+			},
+			input: args{
+				userId: 1,
+				itemId: 4,
+				input:  domain.SnoozeTodoItemInput{Minutes: 30},
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := todoItemRepository.Snooze(context.TODO(), test.input.userId, test.input.itemId, test.input.input)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.want, got)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItem_GetDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	deletedAt := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	type (
+		args struct {
+			userId int
+		}
+		test struct {
+			name         string
+			mockBehavior func()
+			input        args
+			want         []domain.TodoItem
+			wantErr      bool
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "deleted_at"}).
+					AddRow(1, "title1", "description1", true, deletedAt)
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti INNER JOIN %s li on (.+) INNER JOIN %s ul on (.+) WHERE (.+) AND ti.deleted_at IS NOT NULL", todoItemsTable, listsItemsTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+			},
+			want: []domain.TodoItem{
+				{Id: 1, Title: "title1", Description: "description1", Done: true, DeletedAt: &deletedAt},
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func() {
+				query := fmt.Sprintf("SELECT (.+) FROM %s ti INNER JOIN %s li on (.+) INNER JOIN %s ul on (.+) WHERE (.+)", todoItemsTable, listsItemsTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(1).WillReturnError(sql.ErrConnDone)
+			},
+			input: args{
+				userId: 1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			got, err := todoItemRepository.GetDeleted(context.TODO(), test.input.userId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItem_Restore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	type (
+		args struct {
+			itemId int
+			userId int
+		}
+		test struct {
+			name         string
+			mockBehavior func()
+			input        args
+			wantErr      bool
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				query := fmt.Sprintf("WITH RECURSIVE item AS (.+), subtree AS (.+) UPDATE %s SET deleted_at = NULL, updated_at = now\\(\\) WHERE id IN \\(SELECT id FROM subtree\\)", todoItemsTable)
+				mock.ExpectExec(query).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func() {
+				query := fmt.Sprintf("WITH RECURSIVE item AS (.+), subtree AS (.+) UPDATE %s SET deleted_at = NULL, updated_at = now\\(\\) WHERE id IN \\(SELECT id FROM subtree\\)", todoItemsTable)
+				mock.ExpectExec(query).WithArgs(1, 404).WillReturnError(sql.ErrConnDone)
+			},
+			input: args{
+				itemId: 404,
+				userId: 1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			err := todoItemRepository.Restore(context.TODO(), test.input.userId, test.input.itemId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItem_Purge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	before := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	type test struct {
+		name         string
+		mockBehavior func()
+		want         int64
+		wantKeys     []string
+		wantErr      bool
+	}
+
+	attachmentsQuery := fmt.Sprintf("WITH RECURSIVE purged AS (.+) FROM %s ti WHERE ti.deleted_at < (.+) OR ti.id IN (.+) UNION (.+) DELETE FROM %s a WHERE a.item_id IN (.+) RETURNING a.storage_key", todoItemsTable, attachmentsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(attachmentsQuery).WithArgs(before).WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("a1").AddRow("b2"))
+				query := fmt.Sprintf("DELETE FROM %s ti WHERE ti.deleted_at < (.+) OR ti.id IN (.+)", todoItemsTable)
+				mock.ExpectExec(query).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 5))
+				mock.ExpectCommit()
+			},
+			want:     5,
+			wantKeys: []string{"a1", "b2"},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(attachmentsQuery).WithArgs(before).WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("a1"))
+				query := fmt.Sprintf("DELETE FROM %s ti WHERE (.+)", todoItemsTable)
+				mock.ExpectExec(query).WithArgs(before).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			got, keys, err := todoItemRepository.Purge(context.TODO(), before)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+				assert.Equal(t, test.wantKeys, keys)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItem_Move(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	type (
+		args struct {
+			itemId int
+			userId int
+			input  domain.TransferTodoItemInput
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      bool
+			wantErrIs    error
+		}
+	)
+
+	listQuery := fmt.Sprintf("SELECT li.list_id FROM %s li INNER JOIN %s ul on (.+) WHERE (.+)", listsItemsTable, usersListsTable)
+	positionQuery := fmt.Sprintf("SELECT position FROM %s WHERE list_id = (.+) AND item_id = (.+)", listsItemsTable)
+	targetQuery := fmt.Sprintf("SELECT tl.id FROM %s tl INNER JOIN %s ul on (.+) WHERE (.+)", todoListTable, usersListsTable)
+	subtasksQuery := fmt.Sprintf("WITH RECURSIVE subtree AS (.+) UPDATE %s SET list_id = \\$1 WHERE item_id IN \\(SELECT id FROM subtree\\)", listsItemsTable)
+	parentQuery := fmt.Sprintf("UPDATE %s ti SET parent_id = NULL, updated_at = now\\(\\) FROM %s li WHERE (.+)", todoItemsTable, listsItemsTable)
+	unassignQuery := fmt.Sprintf("WITH unassigned AS \\( UPDATE %s ti SET assignee_id = NULL(.+) INSERT INTO %s", todoItemsTable, itemAssignmentsTable)
+
+	tests := []test{
+		{
+			name: "Ok_BetweenAnchors",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(positionQuery).WithArgs(7, *args.input.AfterId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("F"))
+				mock.ExpectQuery(positionQuery).WithArgs(7, *args.input.BeforeId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectExec(fmt.Sprintf("UPDATE %s SET list_id = (.+), position = (.+) WHERE item_id = (.+)", listsItemsTable)).
+					WithArgs(7, "N", args.itemId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(subtasksQuery).WithArgs(7, args.itemId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(parentQuery).WithArgs(args.itemId, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(unassignQuery).WithArgs(7, args.userId).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectStatusSync(mock, 7, false)
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				input:  domain.TransferTodoItemInput{MoveInput: domain.MoveInput{AfterId: intPointer(2), BeforeId: intPointer(3)}},
+			},
+		},
+		{
+			name: "Ok_ToAnotherList",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(targetQuery).WithArgs(*args.input.ListId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(*args.input.ListId))
+				mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s WHERE (.+)", listsItemsTable)).
+					WithArgs(*args.input.ListId, args.itemId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectExec(fmt.Sprintf("UPDATE %s SET list_id = (.+), position = (.+) WHERE item_id = (.+)", listsItemsTable)).
+					WithArgs(*args.input.ListId, "W", args.itemId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(subtasksQuery).WithArgs(*args.input.ListId, args.itemId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(parentQuery).WithArgs(args.itemId, *args.input.ListId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(unassignQuery).WithArgs(*args.input.ListId, args.userId).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectStatusSync(mock, *args.input.ListId, false)
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				input:  domain.TransferTodoItemInput{ListId: intPointer(8)},
+			},
+		},
+		{
+			name: "Full Status",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(targetQuery).WithArgs(*args.input.ListId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(*args.input.ListId))
+				mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s WHERE (.+)", listsItemsTable)).
+					WithArgs(*args.input.ListId, args.itemId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectExec(fmt.Sprintf("UPDATE %s SET list_id = (.+), position = (.+) WHERE item_id = (.+)", listsItemsTable)).
+					WithArgs(*args.input.ListId, "W", args.itemId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(subtasksQuery).WithArgs(*args.input.ListId, args.itemId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(parentQuery).WithArgs(args.itemId, *args.input.ListId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(unassignQuery).WithArgs(*args.input.ListId, args.userId).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectStatusSync(mock, *args.input.ListId, true)
+				mock.ExpectRollback()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				input:  domain.TransferTodoItemInput{ListId: intPointer(8)},
+			},
+			wantErr:   true,
+			wantErrIs: domain.ErrWipLimit,
+		},
+		{
+			name: "Foreign Target List",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(targetQuery).WithArgs(*args.input.ListId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				input:  domain.TransferTodoItemInput{ListId: intPointer(9)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Reversed Anchors",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(positionQuery).WithArgs(7, *args.input.AfterId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectQuery(positionQuery).WithArgs(7, *args.input.BeforeId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("F"))
+				mock.ExpectRollback()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				input:  domain.TransferTodoItemInput{MoveInput: domain.MoveInput{AfterId: intPointer(3), BeforeId: intPointer(2)}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}))
+				mock.ExpectRollback()
+			},
+			input: args{
+				itemId: 404,
+				userId: 1,
+			},
+			wantErr:   true,
+			wantErrIs: domain.ErrItemNotFound,
+		},
+		{
+			name: "Trashed Item",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("SELECT li.list_id FROM %s li (.+) WHERE li.item_id = \\$1 AND ul.user_id = \\$2 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL", listsItemsTable)
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}))
+				mock.ExpectRollback()
+			},
+			input: args{
+				itemId: 5,
+				userId: 1,
+				input:  domain.TransferTodoItemInput{ListId: intPointer(8)},
+			},
+			wantErr:   true,
+			wantErrIs: domain.ErrItemNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := todoItemRepository.Move(context.TODO(), test.input.userId, test.input.itemId, test.input.input)
+			if test.wantErr {
+				assert.Error(t, err)
+				if test.wantErrIs != nil {
+					assert.Equal(t, test.wantErrIs, err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItem_Copy(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	type (
+		args struct {
+			itemId int
+			userId int
+			input  domain.TransferTodoItemInput
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantId       int
+			wantErr      bool
+		}
+	)
+
+	listQuery := fmt.Sprintf("SELECT li.list_id FROM %s li INNER JOIN %s ul on (.+) WHERE (.+)", listsItemsTable, usersListsTable)
+	targetQuery := fmt.Sprintf("SELECT tl.id FROM %s tl INNER JOIN %s ul on (.+) WHERE (.+)", todoListTable, usersListsTable)
+	lastQuery := fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s WHERE (.+)", listsItemsTable)
+	copyItemQuery := fmt.Sprintf("INSERT INTO %s \\(title, description, done, completed_at, priority, due_date, due_time, rrule, repeat_from_completion, estimate_minutes, defer_until\\) SELECT (.+) FROM %s WHERE id = (.+) RETURNING id", todoItemsTable, todoItemsTable)
+
+	tests := []test{
+		{
+			name: "Ok_SameList",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(lastQuery).WithArgs(7, 0).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectQuery(copyItemQuery).WithArgs(args.itemId, false).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec(fmt.Sprintf("INSERT INTO %s", listsItemsTable)).WithArgs(7, 5, "W").WillReturnResult(sqlmock.NewResult(1, 1))
+				expectStatusSync(mock, 7, false)
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+			},
+			wantId: 5,
+		},
+		{
+			name: "Ok_ToAnotherList",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(targetQuery).WithArgs(*args.input.ListId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(*args.input.ListId))
+				mock.ExpectQuery(lastQuery).WithArgs(*args.input.ListId, 0).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(""))
+				mock.ExpectQuery(copyItemQuery).WithArgs(args.itemId, false).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+				mock.ExpectExec(fmt.Sprintf("INSERT INTO %s", listsItemsTable)).WithArgs(*args.input.ListId, 6, "V").WillReturnResult(sqlmock.NewResult(1, 1))
+				expectStatusSync(mock, *args.input.ListId, false)
+				mock.ExpectCommit()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				input:  domain.TransferTodoItemInput{ListId: intPointer(8)},
+			},
+			wantId: 6,
+		},
+		{
+			name: "Foreign Target List",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(targetQuery).WithArgs(*args.input.ListId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				input:  domain.TransferTodoItemInput{ListId: intPointer(9)},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			gotId, err := todoItemRepository.Copy(context.TODO(), test.input.userId, test.input.itemId, test.input.input)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantId, gotId)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
+
 func boolPointer(b bool) *bool {
 	return &b
 }
+
+func intPointer(i int) *int {
+	return &i
+}
+
+// revisedRow returns the revised columns of an item before and after an update,
+// the changed columns map to their old and new values.
+func revisedRow(changes map[string][2]driver.Value) *sqlmock.Rows {
+
+	item := map[string]driver.Value{"title": "title", "description": "description", "done": false, "priority": int64(0), "due_date": nil,
+		"due_time": nil, "rrule": "", "repeat_from_completion": false, "estimate_minutes": int64(0), "defer_until": nil}
+
+	old, updated := make([]driver.Value, 0, len(revisedColumns)), make([]driver.Value, 0, len(revisedColumns))
+	for _, column := range revisedColumns {
+		if change, ok := changes[column]; ok {
+			old, updated = append(old, change[0]), append(updated, change[1])
+			continue
+		}
+		old, updated = append(old, item[column]), append(updated, item[column])
+	}
+
+	columns := append(append([]string{}, revisedColumns...), revisedColumns...)
+
+	return sqlmock.NewRows(columns).AddRow(append(old, updated...)...)
+}
+
+func timePointer(t time.Time) *time.Time {
+	return &t
+}
+
+func datePointer(d domain.Date) *domain.Date {
+	return &d
+}
+
+func timestampPointer(t domain.Timestamp) *domain.Timestamp {
+	return &t
+}
+
+func timeOfDayPointer(t domain.TimeOfDay) *domain.TimeOfDay {
+	return &t
+}
+
+func priorityPointer(p domain.Priority) *domain.Priority {
+	return &p
+}
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/andredubov/todo-backend/internal/domain"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 const (
@@ -14,6 +17,18 @@ const (
 	usersListsTable = "users_lists"
 )
 
+// listStatsJoin aggregates the items of every selected list in the same query,
+// listStatsColumns maps the aggregates onto domain.TodoListStats. Overdue items
+// are counted in the timezone of the user joined as u.
+var (
+	listStatsJoin = fmt.Sprintf(`LEFT JOIN LATERAL (SELECT COUNT(*) FILTER (WHERE ti.deleted_at IS NULL) AS total_items,
+									COUNT(*) FILTER (WHERE ti.deleted_at IS NULL AND ti.done) AS completed_items,
+									COUNT(*) FILTER (WHERE ti.deleted_at IS NULL AND NOT ti.done AND %s < now()) AS overdue_items, MAX(ti.updated_at) AS last_activity_at
+									FROM %s li INNER JOIN %s ti on ti.id = li.item_id WHERE li.list_id = tl.id) s ON true`, itemDueAt, listsItemsTable, todoItemsTable)
+	listStatsColumns = `s.total_items AS "stats.total_items", s.completed_items AS "stats.completed_items", s.overdue_items AS "stats.overdue_items",
+									s.last_activity_at AS "stats.last_activity_at"`
+)
+
 type postgresTodoListRepository struct {
 	db *sqlx.DB
 }
@@ -29,15 +44,21 @@ func (r *postgresTodoListRepository) Create(ctx context.Context, todolist domain
 	}
 
 	var todoListId int
-	createTodoListQuery := fmt.Sprintf("INSERT INTO %s (title, description) VALUES ($1, $2) RETURNING id", todoListTable)
-	row := r.db.QueryRow(createTodoListQuery, todolist.Title, todolist.Description)
+	createTodoListQuery := fmt.Sprintf("INSERT INTO %s (title, description, is_template, color, icon) VALUES ($1, $2, $3, $4, $5) RETURNING id", todoListTable)
+	row := tx.QueryRow(createTodoListQuery, todolist.Title, todolist.Description, todolist.IsTemplate, todolist.Color, todolist.Icon)
 	if err := row.Scan(&todoListId); err != nil {
 		tx.Rollback()
 		return 0, err
 	}
 
-	createUsersListsQuery := fmt.Sprintf("INSERT INTO %s (user_id, list_id) VALUES ($1, $2) RETURNING id", usersListsTable)
-	_, err = tx.Exec(createUsersListsQuery, userID, todoListId)
+	position, err := listPositions.last(tx, userID)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	createUsersListsQuery := fmt.Sprintf("INSERT INTO %s (user_id, list_id, pinned, position) VALUES ($1, $2, $3, $4) RETURNING id", usersListsTable)
+	_, err = tx.Exec(createUsersListsQuery, userID, todoListId, todolist.Pinned, position)
 	if err != nil {
 		tx.Rollback()
 		return 0, err
@@ -46,11 +67,24 @@ func (r *postgresTodoListRepository) Create(ctx context.Context, todolist domain
 	return todoListId, tx.Commit()
 }
 
-func (r *postgresTodoListRepository) GetByUserId(ctx context.Context, userId int) ([]domain.TodoList, error) {
+// GetByUserId returns the lists of the user, the pinned ones first. At most limit lists following
+// the list with the given key are returned, all of them for a zero limit and a missing key.
+func (r *postgresTodoListRepository) GetByUserId(ctx context.Context, userId int, after *domain.ListKey, limit int) ([]domain.TodoList, error) {
+
+	keyset, args := "", []interface{}{userId}
+	if after != nil {
+		keyset = "AND (NOT ul.pinned, ul.position, tl.id) > ($2, $3, $4)"
+		args = append(args, !after.Pinned, after.Position, after.Id)
+	}
+
+	limits, args := limitClause(limit, args)
 
 	var todolists []domain.TodoList
-	query := fmt.Sprintf("SELECT tl.id, tl.title, tl.description FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id WHERE ul.user_id = $1", todoListTable, usersListsTable)
-	err := r.db.Select(&todolists, query, userId)
+	query := fmt.Sprintf(`SELECT tl.id, tl.title, tl.description, tl.is_template, tl.color, tl.icon, ul.pinned, ul.folder_id, ul.position, tl.created_at, tl.updated_at, %s FROM %s tl
+									INNER JOIN %s ul on tl.id = ul.list_id INNER JOIN %s u on u.id = ul.user_id %s WHERE ul.user_id = $1 AND tl.deleted_at IS NULL %s
+									ORDER BY ul.pinned DESC, ul.position, tl.id %s`,
+		listStatsColumns, todoListTable, usersListsTable, usersTable, listStatsJoin, keyset, limits)
+	err := r.db.Select(&todolists, query, args...)
 
 	return todolists, err
 }
@@ -58,21 +92,81 @@ func (r *postgresTodoListRepository) GetByUserId(ctx context.Context, userId int
 func (r *postgresTodoListRepository) GetById(ctx context.Context, userId, listId int) (domain.TodoList, error) {
 
 	var todolist domain.TodoList
-	query := fmt.Sprintf("SELECT tl.id, tl.title, tl.description FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id WHERE ul.user_id = $1 AND ul.list_id = $2",
-		todoListTable, usersListsTable)
+	query := fmt.Sprintf(`SELECT tl.id, tl.title, tl.description, tl.is_template, tl.color, tl.icon, ul.pinned, ul.folder_id, ul.position, tl.created_at, tl.updated_at, %s FROM %s tl
+									INNER JOIN %s ul on tl.id = ul.list_id INNER JOIN %s u on u.id = ul.user_id %s WHERE ul.user_id = $1 AND ul.list_id = $2 AND tl.deleted_at IS NULL`,
+		listStatsColumns, todoListTable, usersListsTable, usersTable, listStatsJoin)
 	err := r.db.Get(&todolist, query, userId, listId)
 
 	return todolist, err
 }
 
+// Delete moves the list to the trash. The list and its items stay in the database
+// until Purge removes them.
 func (r *postgresTodoListRepository) Delete(ctx context.Context, userId, listId int) error {
 
-	query := fmt.Sprintf("DELETE FROM %s tl USING %s ul WHERE tl.id = ul.list_id AND ul.user_id=$1 AND ul.list_id=$2", todoListTable, usersListsTable)
+	query := fmt.Sprintf("UPDATE %s tl SET deleted_at = now(), updated_at = now() FROM %s ul WHERE tl.id = ul.list_id AND ul.user_id=$1 AND ul.list_id=$2 AND tl.deleted_at IS NULL",
+		todoListTable, usersListsTable)
 	_, err := r.db.Exec(query, userId, listId)
 
 	return err
 }
 
+func (r *postgresTodoListRepository) GetDeleted(ctx context.Context, userId int) ([]domain.TodoList, error) {
+
+	var todolists []domain.TodoList
+	query := fmt.Sprintf("SELECT tl.id, tl.title, tl.description, tl.deleted_at FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id WHERE ul.user_id = $1 AND tl.deleted_at IS NOT NULL",
+		todoListTable, usersListsTable)
+	err := r.db.Select(&todolists, query, userId)
+
+	return todolists, err
+}
+
+func (r *postgresTodoListRepository) Restore(ctx context.Context, userId, listId int) error {
+
+	query := fmt.Sprintf("UPDATE %s tl SET deleted_at = NULL, updated_at = now() FROM %s ul WHERE tl.id = ul.list_id AND ul.user_id=$1 AND ul.list_id=$2 AND tl.deleted_at IS NOT NULL",
+		todoListTable, usersListsTable)
+	_, err := r.db.Exec(query, userId, listId)
+
+	return err
+}
+
+// Purge permanently removes the lists trashed before the given time together
+// with the items still left in them. It returns the storage keys of the files
+// which were attached to these items, the files are left for the caller to
+// remove from the store.
+func (r *postgresTodoListRepository) Purge(ctx context.Context, before time.Time) (int64, []string, error) {
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	condition := fmt.Sprintf("ti.id IN (SELECT li.item_id FROM %s li INNER JOIN %s tl on tl.id = li.list_id WHERE tl.deleted_at < $1)",
+		listsItemsTable, todoListTable)
+	items, keys, err := purgeItems(tx, condition, before)
+	if err != nil {
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE deleted_at < $1", todoListTable)
+	result, err := tx.Exec(query, before)
+	if err != nil {
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	lists, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	return items + lists, keys, tx.Commit()
+}
+
+// Update changes the list itself and the pinned flag which belongs to the user
+// only, since the list may be shared with other users.
 func (r *postgresTodoListRepository) Update(ctx context.Context, userId, listId int, input domain.UpdateTodoListInput) error {
 
 	setValues, args, argId := make([]string, 0), make([]interface{}, 0), 1
@@ -89,14 +183,221 @@ func (r *postgresTodoListRepository) Update(ctx context.Context, userId, listId
 		argId++
 	}
 
-	setQuery := strings.Join(setValues, ", ")
+	if input.IsTemplate != nil {
+		setValues = append(setValues, fmt.Sprintf("is_template=$%d", argId))
+		args = append(args, input.IsTemplate)
+		argId++
+	}
 
-	query := fmt.Sprintf("UPDATE %s tl SET %s FROM %s ul WHERE tl.id = ul.list_id AND ul.list_id=$%d AND ul.user_id=$%d",
-		todoListTable, setQuery, usersListsTable, argId, argId+1)
+	if input.Color != nil {
+		setValues = append(setValues, fmt.Sprintf("color=$%d", argId))
+		args = append(args, input.Color)
+		argId++
+	}
 
-	args = append(args, listId, userId)
+	if input.Icon != nil {
+		setValues = append(setValues, fmt.Sprintf("icon=$%d", argId))
+		args = append(args, input.Icon)
+		argId++
+	}
 
-	_, err := r.db.Exec(query, args...)
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
 
-	return err
+	if len(setValues) > 0 {
+		setValues = append(setValues, "updated_at = now()")
+		setQuery := strings.Join(setValues, ", ")
+
+		query := fmt.Sprintf("UPDATE %s tl SET %s FROM %s ul WHERE tl.id = ul.list_id AND ul.list_id=$%d AND ul.user_id=$%d AND tl.deleted_at IS NULL",
+			todoListTable, setQuery, usersListsTable, argId, argId+1)
+
+		args = append(args, listId, userId)
+
+		if _, err := tx.Exec(query, args...); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if input.Pinned != nil {
+		query := fmt.Sprintf("UPDATE %s SET pinned=$1 WHERE user_id=$2 AND list_id=$3", usersListsTable)
+		if _, err := tx.Exec(query, input.Pinned, userId, listId); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Move changes the position of the list among the lists of the user.
+func (r *postgresTodoListRepository) Move(ctx context.Context, userId, listId int, input domain.MoveInput) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var current string
+	if err := tx.QueryRow(listPositions.positionQuery(), userId, listId).Scan(&current); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	position, err := listPositions.between(tx, userId, listId, input)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET position = $1 WHERE user_id = $2 AND list_id = $3", usersListsTable)
+	if _, err := tx.Exec(query, position, userId, listId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *postgresTodoListRepository) GetTemplates(ctx context.Context, userId int) ([]domain.TodoList, error) {
+
+	var todolists []domain.TodoList
+	query := fmt.Sprintf("SELECT tl.id, tl.title, tl.description, tl.is_template, tl.color, tl.icon, ul.pinned, ul.folder_id, ul.position, tl.created_at, tl.updated_at FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id WHERE ul.user_id = $1 AND tl.is_template AND tl.deleted_at IS NULL ORDER BY ul.pinned DESC, ul.position, tl.id",
+		todoListTable, usersListsTable)
+	err := r.db.Select(&todolists, query, userId)
+
+	return todolists, err
+}
+
+// Copy creates a new list of the user with the description and all the items of
+// the given list. The new list is never a template. The workflow statuses of the
+// list are copied along with the items.
+func (r *postgresTodoListRepository) Copy(ctx context.Context, userId, listId int, input domain.CopyTodoListInput) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	var todoListId int
+	copyListQuery := fmt.Sprintf(`INSERT INTO %s (title, description, color, icon) SELECT $1, tl.description, tl.color, tl.icon FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id
+									WHERE ul.user_id = $2 AND tl.id = $3 AND tl.deleted_at IS NULL RETURNING id`, todoListTable, todoListTable, usersListsTable)
+	if err := tx.QueryRow(copyListQuery, input.Title, userId, listId).Scan(&todoListId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	position, err := listPositions.last(tx, userId)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	createUsersListsQuery := fmt.Sprintf("INSERT INTO %s (user_id, list_id, position) VALUES ($1, $2, $3)", usersListsTable)
+	if _, err := tx.Exec(createUsersListsQuery, userId, todoListId, position); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var items []struct {
+		Id       int    `db:"id"`
+		ParentId *int   `db:"parent_id"`
+		Position string `db:"position"`
+	}
+	itemsQuery := fmt.Sprintf(`SELECT ti.id, ti.parent_id, li.position FROM %s ti INNER JOIN %s li on li.item_id = ti.id WHERE li.list_id = $1 AND ti.deleted_at IS NULL ORDER BY li.position, ti.id`,
+		todoItemsTable, listsItemsTable)
+	if err := tx.Select(&items, itemsQuery, listId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	createListItemsQuery := fmt.Sprintf("INSERT INTO %s (list_id, item_id, position) VALUES ($1, $2, $3)", listsItemsTable)
+
+	copies := make(map[int]int, len(items))
+	for _, item := range items {
+		itemId, err := copyItem(tx, item.Id, input.ResetDone)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		if _, err := tx.Exec(createListItemsQuery, todoListId, itemId, item.Position); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		copies[item.Id] = itemId
+	}
+
+	// The copied subtasks are linked to the copies of their parents once all the items are copied.
+	setParentQuery := fmt.Sprintf("UPDATE %s SET parent_id = $1 WHERE id = $2", todoItemsTable)
+	for _, item := range items {
+		if item.ParentId == nil {
+			continue
+		}
+
+		parentId, ok := copies[*item.ParentId]
+		if !ok {
+			continue
+		}
+
+		if _, err := tx.Exec(setParentQuery, parentId, copies[item.Id]); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	// The workflow statuses are copied too, every copied item is put into the
+	// status named like the status of its original.
+	copyStatusesQuery := fmt.Sprintf("INSERT INTO %s (list_id, name, done, wip_limit, position) SELECT $1, name, done, wip_limit, position FROM %s WHERE list_id = $2",
+		statusesTable, statusesTable)
+	if _, err := tx.Exec(copyStatusesQuery, todoListId, listId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	originalIds, copyIds := make([]int64, 0, len(items)), make([]int64, 0, len(items))
+	for _, item := range items {
+		originalIds, copyIds = append(originalIds, int64(item.Id)), append(copyIds, int64(copies[item.Id]))
+	}
+
+	setStatusQuery := fmt.Sprintf(`UPDATE %s c SET status_id = ns.id FROM unnest($1::int[], $2::int[]) AS m(original_id, copy_id)
+									INNER JOIN %s o on o.id = m.original_id INNER JOIN %s os on os.id = o.status_id
+									INNER JOIN %s ns on ns.list_id = $3 AND ns.name = os.name WHERE c.id = m.copy_id`,
+		todoItemsTable, todoItemsTable, statusesTable, statusesTable)
+	if _, err := tx.Exec(setStatusQuery, pq.Array(originalIds), pq.Array(copyIds), todoListId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	// the copies reset to open leave the done statuses
+	if err := syncStatuses(tx.Tx, todoListId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return todoListId, tx.Commit()
+}
+
+// SetFolder puts the list into the folder of the user or moves it to
+// the top level when the folder id is nil.
+func (r *postgresTodoListRepository) SetFolder(ctx context.Context, userId, listId int, folderId *int) error {
+
+	query := fmt.Sprintf(`UPDATE %s ul SET folder_id = $1 WHERE ul.user_id = $2 AND ul.list_id = $3
+									AND ($1::int IS NULL OR EXISTS (SELECT 1 FROM %s f WHERE f.id = $1 AND f.user_id = $2))`, usersListsTable, foldersTable)
+	result, err := r.db.Exec(query, folderId, userId, listId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
 }
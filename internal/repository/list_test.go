@@ -5,11 +5,13 @@ import (
 	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/andredubov/todo-backend/internal/domain"
 	"github.com/dvln/testify/assert"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 func TestList_Create(t *testing.T) {
@@ -47,14 +49,19 @@ func TestList_Create(t *testing.T) {
 				todoList: domain.TodoList{
 					Title:       "title",
 					Description: "description",
+					Color:       "#1e90ff",
+					Icon:        "📦",
+					Pinned:      true,
 				},
 			},
 			mockBehavior: func(args args, id int) {
 				mock.ExpectBegin()
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
 				itemsTableQuery, usersListsTableQuery := fmt.Sprintf("INSERT INTO %s", todoListTable), fmt.Sprintf("INSERT INTO %s", usersListsTable)
-				mock.ExpectQuery(itemsTableQuery).WithArgs(args.todoList.Title, args.todoList.Description).WillReturnRows(rows)
-				mock.ExpectExec(usersListsTableQuery).WithArgs(args.userId, id).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(itemsTableQuery).WithArgs(args.todoList.Title, args.todoList.Description, args.todoList.IsTemplate, args.todoList.Color, args.todoList.Icon).WillReturnRows(rows)
+				positionRows := sqlmock.NewRows([]string{"position"}).AddRow("V")
+				mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s", usersListsTable)).WithArgs(args.userId).WillReturnRows(positionRows)
+				mock.ExpectExec(usersListsTableQuery).WithArgs(args.userId, id, args.todoList.Pinned, "W").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			wantId:  1,
@@ -72,7 +79,7 @@ func TestList_Create(t *testing.T) {
 				mock.ExpectBegin()
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
 				itemsTableQuery := fmt.Sprintf("INSERT INTO %s", todoListTable)
-				mock.ExpectQuery(itemsTableQuery).WithArgs(args.todoList.Title, args.todoList.Description).WillReturnRows(rows)
+				mock.ExpectQuery(itemsTableQuery).WithArgs(args.todoList.Title, args.todoList.Description, args.todoList.IsTemplate, args.todoList.Color, args.todoList.Icon).WillReturnRows(rows)
 				mock.ExpectRollback()
 			},
 			wantId:  1,
@@ -111,6 +118,8 @@ func TestList_GetByUserId(t *testing.T) {
 	type (
 		args struct {
 			userId int
+			after  *domain.ListKey
+			limit  int
 		}
 
 		test struct {
@@ -143,6 +152,44 @@ func TestList_GetByUserId(t *testing.T) {
 				{Id: 3, Title: "title3", Description: "description3"},
 			},
 		},
+		{
+			name: "Ok_PinnedFirst",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "color", "icon", "pinned"}).
+					AddRow(2, "title2", "description2", "#ff0000", "⭐", true).
+					AddRow(1, "title1", "description1", "", "", false)
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s tl INNER JOIN %s ul on (.+) WHERE (.+) ORDER BY ul.pinned DESC, ul.position, tl.id", todoListTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(args.userId).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+			},
+			want: []domain.TodoList{
+				{Id: 2, Title: "title2", Description: "description2", Color: "#ff0000", Icon: "⭐", Pinned: true},
+				{Id: 1, Title: "title1", Description: "description1"},
+			},
+		},
+		{
+			name: "Ok_AfterCursor",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "position"}).
+					AddRow(4, "title4", "description4", "k").
+					AddRow(5, "title5", "description5", "n")
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s tl INNER JOIN %s ul on (.+) WHERE (.+) AND \\(NOT ul.pinned, ul.position, tl.id\\) > \\(\\$2, \\$3, \\$4\\) ORDER BY ul.pinned DESC, ul.position, tl.id LIMIT \\$5", todoListTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(args.userId, false, "V", 3, 2).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				after:  &domain.ListKey{Pinned: true, Position: "V", Id: 3},
+				limit:  2,
+			},
+			want: []domain.TodoList{
+				{Id: 4, Title: "title4", Description: "description4", Position: "k"},
+				{Id: 5, Title: "title5", Description: "description5", Position: "n"},
+			},
+		},
 		{
 			name: "No records",
 			mockBehavior: func(args args) {
@@ -161,7 +208,7 @@ func TestList_GetByUserId(t *testing.T) {
 
 			test.mockBehavior(test.input)
 
-			got, err := todoListRepository.GetByUserId(context.TODO(), test.input.userId)
+			got, err := todoListRepository.GetByUserId(context.TODO(), test.input.userId, test.input.after, test.input.limit)
 			if test.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -214,6 +261,26 @@ func TestList_GetById(t *testing.T) {
 			},
 			want: domain.TodoList{Id: 1, Title: "title1", Description: "description1"},
 		},
+		{
+			name: "Ok_WithStats",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "stats.total_items", "stats.completed_items", "stats.overdue_items", "stats.last_activity_at"}).
+					AddRow(1, "title1", "description1", 12, 7, 2, time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC))
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s tl INNER JOIN %s ul on (.+) LEFT JOIN LATERAL (.+) WHERE (.+)", todoListTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(args.userId, args.todoListId).WillReturnRows(rows)
+			},
+			input: args{
+				userId:     1,
+				todoListId: 2,
+			},
+			want: domain.TodoList{Id: 1, Title: "title1", Description: "description1", Stats: &domain.TodoListStats{
+				TotalItems:     12,
+				CompletedItems: 7,
+				OverdueItems:   2,
+				LastActivityAt: timePointer(time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)),
+			}},
+		},
 		{
 			name: "Not Found",
 			mockBehavior: func(args args) {
@@ -276,7 +343,7 @@ func TestList_Delete(t *testing.T) {
 		{
 			name: "Ok",
 			mockBehavior: func(args args) {
-				query := fmt.Sprintf("DELETE FROM %s tl USING %s ul WHERE (.+)", todoListTable, usersListsTable)
+				query := fmt.Sprintf("UPDATE %s tl SET deleted_at = now\\(\\), updated_at = now\\(\\) FROM %s ul WHERE (.+)", todoListTable, usersListsTable)
 				mock.ExpectExec(query).WithArgs(args.userId, args.todoListId).WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			input: args{
@@ -287,7 +354,7 @@ func TestList_Delete(t *testing.T) {
 		{
 			name: "Not found",
 			mockBehavior: func(args args) {
-				query := fmt.Sprintf("DELETE FROM %s tl USING %s ul WHERE (.+)", todoListTable, usersListsTable)
+				query := fmt.Sprintf("UPDATE %s tl SET deleted_at = now\\(\\), updated_at = now\\(\\) FROM %s ul WHERE (.+)", todoListTable, usersListsTable)
 				mock.ExpectExec(query).WithArgs(args.userId, args.todoListId).WillReturnError(sql.ErrNoRows)
 			},
 			input: args{
@@ -345,9 +412,14 @@ func TestList_Update(t *testing.T) {
 			name: "Ok_AllFields",
 			mockBehavior: func(args args) {
 				query := fmt.Sprintf("UPDATE %s tl SET (.+) FROM %s ul WHERE (.+)", todoListTable, usersListsTable)
+				mock.ExpectBegin()
 				mock.ExpectExec(query).
-					WithArgs(args.todoListInput.Title, args.todoListInput.Description, args.todoListId, args.userId).
+					WithArgs(args.todoListInput.Title, args.todoListInput.Description, args.todoListInput.IsTemplate, args.todoListInput.Color, args.todoListInput.Icon, args.todoListId, args.userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(fmt.Sprintf("UPDATE %s SET pinned=(.+) WHERE user_id=(.+) AND list_id=(.+)", usersListsTable)).
+					WithArgs(args.todoListInput.Pinned, args.userId, args.todoListId).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			input: args{
 				userId:     1,
@@ -355,6 +427,10 @@ func TestList_Update(t *testing.T) {
 				todoListInput: domain.UpdateTodoListInput{
 					Title:       stringPointer("new title"),
 					Description: stringPointer("new description"),
+					IsTemplate:  boolPointer(true),
+					Color:       stringPointer("#ff8800"),
+					Icon:        stringPointer("🚀"),
+					Pinned:      boolPointer(true),
 				},
 			},
 		},
@@ -362,9 +438,11 @@ func TestList_Update(t *testing.T) {
 			name: "OK_WithoutTitle",
 			mockBehavior: func(args args) {
 				query := fmt.Sprintf("UPDATE %s tl SET (.+) FROM %s ul WHERE (.+)", todoListTable, usersListsTable)
+				mock.ExpectBegin()
 				mock.ExpectExec(query).
 					WithArgs(args.todoListInput.Description, args.todoListId, args.userId).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			input: args{
 				userId:     1,
@@ -378,9 +456,11 @@ func TestList_Update(t *testing.T) {
 			name: "OK_WithoutDescription",
 			mockBehavior: func(args args) {
 				query := fmt.Sprintf("UPDATE %s tl SET (.+) FROM %s ul WHERE (.+)", todoListTable, usersListsTable)
+				mock.ExpectBegin()
 				mock.ExpectExec(query).
 					WithArgs(args.todoListInput.Title, args.todoListId, args.userId).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			input: args{
 				userId:     1,
@@ -390,11 +470,47 @@ func TestList_Update(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Ok_OnlyPinned",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec(fmt.Sprintf("UPDATE %s SET pinned=(.+) WHERE user_id=(.+) AND list_id=(.+)", usersListsTable)).
+					WithArgs(args.todoListInput.Pinned, args.userId, args.todoListId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			input: args{
+				userId:     1,
+				todoListId: 3,
+				todoListInput: domain.UpdateTodoListInput{
+					Pinned: boolPointer(false),
+				},
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s tl SET (.+) FROM %s ul WHERE (.+)", todoListTable, usersListsTable)
+				mock.ExpectBegin()
+				mock.ExpectExec(query).
+					WithArgs(args.todoListInput.Color, args.todoListId, args.userId).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			input: args{
+				userId:     1,
+				todoListId: 3,
+				todoListInput: domain.UpdateTodoListInput{
+					Color: stringPointer("#00aa00"),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Ok_NoInputFields",
 			mockBehavior: func(args args) {
-				query := fmt.Sprintf("UPDATE %s tl SET FROM %s ul WHERE (.+)", todoListTable, usersListsTable)
-				mock.ExpectExec(query).WithArgs(args.todoListId, args.userId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectBegin()
+				mock.ExpectCommit()
 			},
 			input: args{
 				userId:     1,
@@ -419,3 +535,554 @@ func TestList_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestList_GetDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoListRepository := NewPostgresTodoListRepository(dbx)
+
+	deletedAt := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	type (
+		args struct {
+			userId int
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			want         []domain.TodoList
+			wantErr      bool
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "deleted_at"}).
+					AddRow(1, "title1", "description1", deletedAt)
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s tl INNER JOIN %s ul on (.+) WHERE (.+) AND tl.deleted_at IS NOT NULL", todoListTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(args.userId).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+			},
+			want: []domain.TodoList{
+				{Id: 1, Title: "title1", Description: "description1", DeletedAt: &deletedAt},
+			},
+		},
+		{
+			name: "Empty trash",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "deleted_at"})
+				query := fmt.Sprintf("SELECT (.+) FROM %s tl INNER JOIN %s ul on (.+) WHERE (.+) AND tl.deleted_at IS NOT NULL", todoListTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(args.userId).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 2,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := todoListRepository.GetDeleted(context.TODO(), test.input.userId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestList_Restore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoListRepository := NewPostgresTodoListRepository(dbx)
+
+	type (
+		args struct {
+			userId     int
+			todoListId int
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      bool
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s tl SET deleted_at = NULL, updated_at = now\\(\\) FROM %s ul WHERE (.+)", todoListTable, usersListsTable)
+				mock.ExpectExec(query).WithArgs(args.userId, args.todoListId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId:     1,
+				todoListId: 2,
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s tl SET deleted_at = NULL, updated_at = now\\(\\) FROM %s ul WHERE (.+)", todoListTable, usersListsTable)
+				mock.ExpectExec(query).WithArgs(args.userId, args.todoListId).WillReturnError(sql.ErrConnDone)
+			},
+			input: args{
+				userId:     1,
+				todoListId: 3,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := todoListRepository.Restore(context.TODO(), test.input.userId, test.input.todoListId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestList_Purge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoListRepository := NewPostgresTodoListRepository(dbx)
+
+	before := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	type test struct {
+		name         string
+		mockBehavior func()
+		want         int64
+		wantKeys     []string
+		wantErr      bool
+	}
+
+	attachmentsQuery := fmt.Sprintf("WITH RECURSIVE purged AS (.+) FROM %s ti WHERE ti.id IN \\(SELECT li.item_id FROM %s li (.+) WHERE tl.deleted_at < (.+)\\) UNION (.+) DELETE FROM %s a (.+) RETURNING a.storage_key", todoItemsTable, listsItemsTable, attachmentsTable)
+	itemsQuery := fmt.Sprintf("DELETE FROM %s ti WHERE ti.id IN (.+)", todoItemsTable)
+	listsQuery := fmt.Sprintf("DELETE FROM %s WHERE deleted_at < (.+)", todoListTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(attachmentsQuery).WithArgs(before).WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("a1"))
+				mock.ExpectExec(itemsQuery).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(listsQuery).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+			want:     5,
+			wantKeys: []string{"a1"},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(attachmentsQuery).WithArgs(before).WillReturnRows(sqlmock.NewRows([]string{"storage_key"}))
+				mock.ExpectExec(itemsQuery).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(listsQuery).WithArgs(before).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			got, keys, err := todoListRepository.Purge(context.TODO(), before)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+				assert.Equal(t, test.wantKeys, keys)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestList_Move(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoListRepository := NewPostgresTodoListRepository(dbx)
+
+	type (
+		args struct {
+			userId     int
+			todoListId int
+			input      domain.MoveInput
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      bool
+		}
+	)
+
+	positionQuery := fmt.Sprintf("SELECT position FROM %s WHERE user_id = (.+) AND list_id = (.+)", usersListsTable)
+
+	tests := []test{
+		{
+			name: "Ok_AfterAnchor",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(positionQuery).WithArgs(args.userId, args.todoListId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("k"))
+				mock.ExpectQuery(positionQuery).WithArgs(args.userId, *args.input.AfterId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("F"))
+				mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MIN\\(position\\), ''\\) FROM %s WHERE (.+) AND position > (.+)", usersListsTable)).
+					WithArgs(args.userId, args.todoListId, "F").WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectExec(fmt.Sprintf("UPDATE %s SET position = (.+) WHERE (.+)", usersListsTable)).
+					WithArgs("N", args.userId, args.todoListId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				input:      domain.MoveInput{AfterId: intPointer(3)},
+			},
+		},
+		{
+			name: "Ok_ToTheEnd",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(positionQuery).WithArgs(args.userId, args.todoListId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("F"))
+				mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s WHERE (.+)", usersListsTable)).
+					WithArgs(args.userId, args.todoListId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectExec(fmt.Sprintf("UPDATE %s SET position = (.+) WHERE (.+)", usersListsTable)).
+					WithArgs("W", args.userId, args.todoListId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			input: args{
+				userId:     1,
+				todoListId: 2,
+			},
+		},
+		{
+			name: "Anchor Not Found",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(positionQuery).WithArgs(args.userId, args.todoListId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("k"))
+				mock.ExpectQuery(positionQuery).WithArgs(args.userId, *args.input.BeforeId).WillReturnRows(sqlmock.NewRows([]string{"position"}))
+				mock.ExpectRollback()
+			},
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				input:      domain.MoveInput{BeforeId: intPointer(404)},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := todoListRepository.Move(context.TODO(), test.input.userId, test.input.todoListId, test.input.input)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestList_GetTemplates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoListRepository := NewPostgresTodoListRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			want         []domain.TodoList
+			wantErr      bool
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "is_template", "position"}).
+					AddRow(1, "release", "release checklist", true, "V")
+
+				query := fmt.Sprintf("SELECT (.+) FROM %s tl INNER JOIN %s ul on (.+) WHERE (.+) AND tl.is_template (.+)", todoListTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(args.userId).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+			},
+			want: []domain.TodoList{
+				{Id: 1, Title: "release", Description: "release checklist", IsTemplate: true, Position: "V"},
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("SELECT (.+) FROM %s tl INNER JOIN %s ul on (.+) WHERE (.+)", todoListTable, usersListsTable)
+				mock.ExpectQuery(query).WithArgs(args.userId).WillReturnError(sql.ErrConnDone)
+			},
+			input: args{
+				userId: 1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := todoListRepository.GetTemplates(context.TODO(), test.input.userId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestList_Copy(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoListRepository := NewPostgresTodoListRepository(dbx)
+
+	type (
+		args struct {
+			userId     int
+			todoListId int
+			input      domain.CopyTodoListInput
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args args, id int)
+			input        args
+			wantId       int
+			wantErr      bool
+		}
+	)
+
+	copyListQuery := fmt.Sprintf("INSERT INTO %s \\(title, description, color, icon\\) SELECT (.+) FROM %s tl INNER JOIN %s ul on (.+)", todoListTable, todoListTable, usersListsTable)
+	copyItemQuery := fmt.Sprintf("INSERT INTO %s \\(title, description, done, completed_at, priority, due_date, due_time, rrule, repeat_from_completion, estimate_minutes, defer_until\\) SELECT (.+) FROM %s WHERE id = (.+)", todoItemsTable, todoItemsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(copyListQuery).WithArgs(*args.input.Title, args.userId, args.todoListId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+				mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s", usersListsTable)).
+					WithArgs(args.userId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectExec(fmt.Sprintf("INSERT INTO %s", usersListsTable)).WithArgs(args.userId, id, "W").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(fmt.Sprintf("SELECT ti.id, ti.parent_id, li.position FROM %s ti INNER JOIN %s li on (.+)", todoItemsTable, listsItemsTable)).
+					WithArgs(args.todoListId).WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id", "position"}).AddRow(10, nil, "F").AddRow(11, 10, "V"))
+				mock.ExpectQuery(copyItemQuery).WithArgs(10, true).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20))
+				mock.ExpectExec(fmt.Sprintf("INSERT INTO %s", listsItemsTable)).WithArgs(id, 20, "F").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(copyItemQuery).WithArgs(11, true).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(21))
+				mock.ExpectExec(fmt.Sprintf("INSERT INTO %s", listsItemsTable)).WithArgs(id, 21, "V").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(fmt.Sprintf("UPDATE %s SET parent_id = \\$1 WHERE id = \\$2", todoItemsTable)).WithArgs(20, 21).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(fmt.Sprintf("INSERT INTO %s \\(list_id, name, done, wip_limit, position\\) SELECT (.+) FROM %s WHERE list_id = \\$2", statusesTable, statusesTable)).
+					WithArgs(id, args.todoListId).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(fmt.Sprintf("UPDATE %s c SET status_id = ns.id FROM unnest(.+) INNER JOIN %s ns on (.+)", todoItemsTable, statusesTable)).
+					WithArgs(pq.Array([]int64{10, 11}), pq.Array([]int64{20, 21}), id).WillReturnResult(sqlmock.NewResult(0, 2))
+				expectStatusSync(mock, id, false)
+				mock.ExpectCommit()
+			},
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				input:      domain.CopyTodoListInput{Title: stringPointer("copy"), ResetDone: true},
+			},
+			wantId: 3,
+		},
+		{
+			name: "Source Not Found",
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(copyListQuery).WithArgs(*args.input.Title, args.userId, args.todoListId).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			input: args{
+				userId:     1,
+				todoListId: 404,
+				input:      domain.CopyTodoListInput{Title: stringPointer("copy")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input, test.wantId)
+
+			gotId, err := todoListRepository.Copy(context.TODO(), test.input.userId, test.input.todoListId, test.input.input)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantId, gotId)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestList_SetFolder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoListRepository := NewPostgresTodoListRepository(dbx)
+
+	type (
+		args struct {
+			userId     int
+			todoListId int
+			folderId   *int
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      bool
+		}
+	)
+
+	query := fmt.Sprintf("UPDATE %s ul SET folder_id = (.+) WHERE (.+)", usersListsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.folderId, args.userId, args.todoListId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				folderId:   intPointer(3),
+			},
+		},
+		{
+			name: "Ok_TopLevel",
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(nil, args.userId, args.todoListId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId:     1,
+				todoListId: 2,
+			},
+		},
+		{
+			name: "Foreign Folder",
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.folderId, args.userId, args.todoListId).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				folderId:   intPointer(5),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := todoListRepository.SetFolder(context.TODO(), test.input.userId, test.input.todoListId, test.input.folderId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
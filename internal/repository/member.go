@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type postgresMembersRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresMembersRepository(db *sqlx.DB) *postgresMembersRepository {
+	return &postgresMembersRepository{db: db}
+}
+
+// GetByListId returns the members of a list the user is a member of.
+func (r *postgresMembersRepository) GetByListId(ctx context.Context, userId, listId int) ([]domain.Member, error) {
+
+	var members []domain.Member
+	query := fmt.Sprintf(`SELECT u.id, u.name, u.email FROM %s u INNER JOIN %s ul on ul.user_id = u.id
+									WHERE ul.list_id = $1 AND EXISTS (SELECT 1 FROM %s m WHERE m.list_id = ul.list_id AND m.user_id = $2)
+									ORDER BY u.name, u.id`,
+		usersTable, usersListsTable, usersListsTable)
+	if err := r.db.Select(&members, query, listId, userId); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// Add shares the list of the user with the user signed up with the email,
+// the list is put at the end of the lists of the new member.
+func (r *postgresMembersRepository) Add(ctx context.Context, userId, listId int, email string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	listQuery := fmt.Sprintf(`SELECT tl.id FROM %s tl INNER JOIN %s ul on ul.list_id = tl.id WHERE tl.id = $1 AND ul.user_id = $2 AND tl.deleted_at IS NULL`,
+		todoListTable, usersListsTable)
+	if err := tx.QueryRow(listQuery, listId, userId).Scan(&listId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var memberId int
+	userQuery := fmt.Sprintf("SELECT id FROM %s WHERE email = $1", usersTable)
+	err = tx.QueryRow(userQuery, email).Scan(&memberId)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return 0, domain.ErrUserNotFound
+	}
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	position, err := listPositions.last(tx, memberId)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (user_id, list_id, position) VALUES ($1, $2, $3)", usersListsTable)
+	if _, err := tx.Exec(query, memberId, listId, position); err != nil {
+		tx.Rollback()
+		return 0, memberError(err)
+	}
+
+	return memberId, tx.Commit()
+}
+
+// Remove takes the member off a list the user is a member of, a member leaves
+// the list by removing themselves. The last member can not leave the list.
+// The items assigned to the member are unassigned.
+func (r *postgresMembersRepository) Remove(ctx context.Context, userId, listId, memberId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var members int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE list_id = $1 HAVING bool_or(user_id = $2)", usersListsTable)
+	if err := tx.QueryRow(countQuery, listId, userId).Scan(&members); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if members == 1 && memberId == userId {
+		tx.Rollback()
+		return domain.ErrLastMember
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE list_id = $1 AND user_id = $2", usersListsTable)
+	result, err := tx.Exec(query, listId, memberId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affected == 0 {
+		tx.Rollback()
+		return sql.ErrNoRows
+	}
+
+	if err := unassignNonMembers(tx, userId, listId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// memberError reports sharing the list with one of its members as domain.ErrAlreadyMember.
+func memberError(err error) error {
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return domain.ErrAlreadyMember
+	}
+
+	return err
+}
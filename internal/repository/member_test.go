@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func TestMembers_GetByListId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	membersRepository := NewPostgresMembersRepository(dbx)
+
+	query := fmt.Sprintf("SELECT u.id, u.name, u.email FROM %s u INNER JOIN %s ul on (.+) WHERE ul.list_id = \\$1 AND EXISTS (.+) ORDER BY u.name, u.id",
+		usersTable, usersListsTable)
+	rows := sqlmock.NewRows([]string{"id", "name", "email"}).
+		AddRow(1, "Alice", "alice@example.com").
+		AddRow(3, "Bob", "bob@example.com")
+	mock.ExpectQuery(query).WithArgs(7, 1).WillReturnRows(rows)
+
+	got, err := membersRepository.GetByListId(context.TODO(), 1, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Member{
+		{UserId: 1, Name: "Alice", Email: "alice@example.com"},
+		{UserId: 3, Name: "Bob", Email: "bob@example.com"},
+	}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMembers_Add(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	membersRepository := NewPostgresMembersRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+			listId int
+			email  string
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantId       int
+			wantErr      error
+		}
+	)
+
+	listQuery := fmt.Sprintf("SELECT tl.id FROM %s tl INNER JOIN %s ul on (.+) WHERE (.+)", todoListTable, usersListsTable)
+	userQuery := fmt.Sprintf("SELECT id FROM %s WHERE email = \\$1", usersTable)
+	positionQuery := fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s WHERE user_id = \\$1", usersListsTable)
+	insertQuery := fmt.Sprintf("INSERT INTO %s \\(user_id, list_id, position\\) VALUES \\(\\$1, \\$2, \\$3\\)", usersListsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(args.listId))
+				mock.ExpectQuery(userQuery).WithArgs(args.email).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectQuery(positionQuery).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectExec(insertQuery).WithArgs(3, args.listId, "W").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			input:  args{userId: 1, listId: 7, email: "bob@example.com"},
+			wantId: 3,
+		},
+		{
+			name: "Unknown Email",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(args.listId))
+				mock.ExpectQuery(userQuery).WithArgs(args.email).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, listId: 7, email: "nobody@example.com"},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name: "Already A Member",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(args.listId))
+				mock.ExpectQuery(userQuery).WithArgs(args.email).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectQuery(positionQuery).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectExec(insertQuery).WithArgs(3, args.listId, "W").WillReturnError(&pq.Error{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, listId: 7, email: "bob@example.com"},
+			wantErr: domain.ErrAlreadyMember,
+		},
+		{
+			name: "Foreign List",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, listId: 70, email: "bob@example.com"},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := membersRepository.Add(context.TODO(), test.input.userId, test.input.listId, test.input.email)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantId, got)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMembers_Remove(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	membersRepository := NewPostgresMembersRepository(dbx)
+
+	type (
+		args struct {
+			userId   int
+			listId   int
+			memberId int
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      error
+		}
+	)
+
+	countQuery := fmt.Sprintf("SELECT COUNT\\(\\*\\) FROM %s WHERE list_id = \\$1 HAVING bool_or\\(user_id = \\$2\\)", usersListsTable)
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE list_id = \\$1 AND user_id = \\$2", usersListsTable)
+	unassignQuery := fmt.Sprintf("WITH unassigned AS \\( UPDATE %s ti SET assignee_id = NULL(.+) INSERT INTO %s", todoItemsTable, itemAssignmentsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(countQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectExec(deleteQuery).WithArgs(args.listId, args.memberId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(unassignQuery).WithArgs(args.listId, args.userId).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, listId: 7, memberId: 3},
+		},
+		{
+			name: "Ok_Leave",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(countQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectExec(deleteQuery).WithArgs(args.listId, args.memberId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(unassignQuery).WithArgs(args.listId, args.userId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 3, listId: 7, memberId: 3},
+		},
+		{
+			name: "Last Member",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(countQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, listId: 7, memberId: 1},
+			wantErr: domain.ErrLastMember,
+		},
+		{
+			name: "Not A Member",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(countQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectExec(deleteQuery).WithArgs(args.listId, args.memberId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, listId: 7, memberId: 9},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "Foreign List",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(countQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"count"}))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, listId: 70, memberId: 3},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := membersRepository.Remove(context.TODO(), test.input.userId, test.input.listId, test.input.memberId)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
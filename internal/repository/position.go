@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/pkg/rank"
+)
+
+type rowQueryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// positions describes where the rank of a row is stored: lists are ordered
+// per user in users_lists and items are ordered per list in lists_items.
+type positions struct {
+	table        string
+	scopeColumn  string
+	memberColumn string
+}
+
+var (
+	listPositions = positions{table: usersListsTable, scopeColumn: "user_id", memberColumn: "list_id"}
+	itemPositions = positions{table: listsItemsTable, scopeColumn: "list_id", memberColumn: "item_id"}
+)
+
+// last returns a position after every row of the scope.
+func (p positions) last(q rowQueryer, scopeId int) (string, error) {
+
+	var lower string
+	query := fmt.Sprintf("SELECT COALESCE(MAX(position), '') FROM %s WHERE %s = $1", p.table, p.scopeColumn)
+	if err := q.QueryRow(query, scopeId).Scan(&lower); err != nil {
+		return "", err
+	}
+
+	return rank.Between(lower, "")
+}
+
+// between returns a position for the member placed according to the move input.
+// Only the anchors and their nearest neighbours are read, nothing is renumbered.
+func (p positions) between(q rowQueryer, scopeId, memberId int, input domain.MoveInput) (string, error) {
+
+	var lower, upper string
+
+	if input.AfterId != nil {
+		if err := q.QueryRow(p.positionQuery(), scopeId, *input.AfterId).Scan(&lower); err != nil {
+			return "", err
+		}
+	}
+
+	if input.BeforeId != nil {
+		if err := q.QueryRow(p.positionQuery(), scopeId, *input.BeforeId).Scan(&upper); err != nil {
+			return "", err
+		}
+	}
+
+	switch {
+	case input.AfterId != nil && input.BeforeId == nil:
+		query := fmt.Sprintf("SELECT COALESCE(MIN(position), '') FROM %s WHERE %s = $1 AND %s <> $2 AND position > $3",
+			p.table, p.scopeColumn, p.memberColumn)
+		if err := q.QueryRow(query, scopeId, memberId, lower).Scan(&upper); err != nil {
+			return "", err
+		}
+	case input.AfterId == nil && input.BeforeId != nil:
+		query := fmt.Sprintf("SELECT COALESCE(MAX(position), '') FROM %s WHERE %s = $1 AND %s <> $2 AND position < $3",
+			p.table, p.scopeColumn, p.memberColumn)
+		if err := q.QueryRow(query, scopeId, memberId, upper).Scan(&lower); err != nil {
+			return "", err
+		}
+	case input.AfterId == nil && input.BeforeId == nil:
+		query := fmt.Sprintf("SELECT COALESCE(MAX(position), '') FROM %s WHERE %s = $1 AND %s <> $2",
+			p.table, p.scopeColumn, p.memberColumn)
+		if err := q.QueryRow(query, scopeId, memberId).Scan(&lower); err != nil {
+			return "", err
+		}
+	}
+
+	return rank.Between(lower, upper)
+}
+
+func (p positions) positionQuery() string {
+	return fmt.Sprintf("SELECT position FROM %s WHERE %s = $1 AND %s = $2", p.table, p.scopeColumn, p.memberColumn)
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/pkg/rrule"
+	"github.com/lib/pq"
+)
+
+// recurrenceDueDate is the constraint keeping a due date on every recurring item.
+const recurrenceDueDate = "recurrence_due_date"
+
+// scheduleNext adds the next occurrence of the completed item to the end of its
+// list when the item is recurring. The occurrence is due on the next date of the
+// rule after the due date or, when the item repeats from completion, after the
+// date it is completed on in the timezone of the user. It keeps the due time,
+// the tags and the reminders set relative to the due time. The completed item
+// stops recurring, so completing it once again schedules nothing.
+func scheduleNext(tx *sql.Tx, userId, itemId int, now time.Time) error {
+
+	var (
+		rule, timezone       string
+		repeatFromCompletion bool
+		occurrence, listId   int
+		dueDate              domain.Date
+	)
+	query := fmt.Sprintf(`SELECT ti.rrule, ti.repeat_from_completion, ti.occurrence, ti.due_date, li.list_id, u.timezone FROM %s ti
+									INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id INNER JOIN %s u on u.id = ul.user_id
+									WHERE ti.id = $1 AND ul.user_id = $2 AND ti.rrule <> ''`,
+		todoItemsTable, listsItemsTable, usersListsTable, usersTable)
+	err := tx.QueryRow(query, itemId, userId).Scan(&rule, &repeatFromCompletion, &occurrence, &dueDate, &listId, &timezone)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	next, ok, err := nextDueDate(rule, occurrence, dueDate, repeatFromCompletion, now, timezone)
+	if err != nil || !ok {
+		return err
+	}
+
+	var nextId int
+	nextQuery := fmt.Sprintf(`WITH next AS (
+									INSERT INTO %s (parent_id, title, description, priority, due_date, due_time, rrule, repeat_from_completion, occurrence, estimate_minutes)
+									SELECT parent_id, title, description, priority, $2, due_time, rrule, repeat_from_completion, occurrence + 1, estimate_minutes FROM %s WHERE id = $1 RETURNING id
+								), tags AS (
+									INSERT INTO %s (item_id, tag_id) SELECT next.id, it.tag_id FROM next, %s it WHERE it.item_id = $1
+								), reminders AS (
+									INSERT INTO %s (item_id, user_id, minutes_before) SELECT next.id, r.user_id, r.minutes_before FROM next, %s r
+									WHERE r.item_id = $1 AND r.minutes_before IS NOT NULL
+								)
+								SELECT id FROM next`,
+		todoItemsTable, todoItemsTable, itemsTagsTable, itemsTagsTable, remindersTable, remindersTable)
+	if err := tx.QueryRow(nextQuery, itemId, next.Format(domain.DateLayout)).Scan(&nextId); err != nil {
+		return err
+	}
+
+	position, err := itemPositions.last(tx, listId)
+	if err != nil {
+		return err
+	}
+
+	createListItemsQuery := fmt.Sprintf("INSERT INTO %s (list_id, item_id, position) VALUES ($1, $2, $3)", listsItemsTable)
+	if _, err := tx.Exec(createListItemsQuery, listId, nextId, position); err != nil {
+		return err
+	}
+
+	stopQuery := fmt.Sprintf("UPDATE %s SET rrule = '', updated_at = now() WHERE id = $1", todoItemsTable)
+	_, err = tx.Exec(stopQuery, itemId)
+
+	return err
+}
+
+// nextDueDate finds the due date of the occurrence following the given one,
+// ok is false once the rule has run out of occurrences.
+func nextDueDate(rule string, occurrence int, dueDate domain.Date, repeatFromCompletion bool, now time.Time, timezone string) (time.Time, bool, error) {
+
+	recurrence, err := rrule.Parse(rule)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	if recurrence.Count > 0 && occurrence >= recurrence.Count {
+		return time.Time{}, false, nil
+	}
+
+	start, err := time.Parse(domain.DateLayout, string(dueDate))
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	if repeatFromCompletion {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			location = time.UTC
+		}
+		start = now.In(location)
+	}
+
+	next, ok := recurrence.Next(start, start)
+
+	return next, ok, nil
+}
+
+// itemError reports clearing the due date of a recurring item as domain.ErrRRuleWithoutDate.
+func itemError(err error) error {
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Constraint == recurrenceDueDate {
+		return domain.ErrRRuleWithoutDate
+	}
+
+	return err
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestTodoItem_UpdateRecurring(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	type test struct {
+		name         string
+		mockBehavior func()
+		wantErr      bool
+	}
+
+	updateQuery := fmt.Sprintf("UPDATE %s ti SET done=(.+), updated_at=now\\(\\) FROM %s li, %s ul, %s old WHERE (.+) RETURNING (.+)", todoItemsTable, listsItemsTable, usersListsTable, todoItemsTable)
+	recurrenceQuery := fmt.Sprintf("SELECT ti.rrule, ti.repeat_from_completion, ti.occurrence, ti.due_date, li.list_id, u.timezone FROM %s ti (.+)", todoItemsTable)
+	recurrenceColumns := []string{"rrule", "repeat_from_completion", "occurrence", "due_date", "list_id", "timezone"}
+	listQuery := fmt.Sprintf("SELECT li.list_id FROM %s li INNER JOIN %s ul on (.+) WHERE (.+)", listsItemsTable, usersListsTable)
+	revisionQuery := fmt.Sprintf("INSERT INTO %s \\(item_id, user_id, changes\\) VALUES", itemRevisionsTable)
+	nextQuery := fmt.Sprintf("WITH next AS \\( INSERT INTO %s \\(parent_id, title, description, priority, due_date, due_time, rrule, repeat_from_completion, occurrence, estimate_minutes\\)", todoItemsTable)
+
+	tests := []test{
+		{
+			name: "Ok_NextOccurrence",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateQuery).WithArgs(true, 1, 2).WillReturnRows(revisedRow(map[string][2]driver.Value{"done": {false, true}}))
+				mock.ExpectExec(revisionQuery).WithArgs(2, 1, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows(recurrenceColumns).AddRow("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", false, 2, "2024-01-04", 7, "Europe/Berlin"))
+				mock.ExpectQuery(nextQuery).WithArgs(2, "2024-01-15").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				mock.ExpectQuery(fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s", listsItemsTable)).WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectExec(fmt.Sprintf("INSERT INTO %s", listsItemsTable)).WithArgs(7, 9, "W").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(fmt.Sprintf("UPDATE %s SET rrule = '', updated_at = now\\(\\) WHERE id = \\$1", todoItemsTable)).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(listQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				expectStatusSync(mock, 7, false)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Ok_CountReached",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateQuery).WithArgs(true, 1, 2).WillReturnRows(revisedRow(map[string][2]driver.Value{"done": {false, true}}))
+				mock.ExpectExec(revisionQuery).WithArgs(2, 1, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows(recurrenceColumns).AddRow("FREQ=DAILY;COUNT=3", false, 3, "2024-01-04", 7, "UTC"))
+				mock.ExpectQuery(listQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				expectStatusSync(mock, 7, false)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Failed_NextOccurrence",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(updateQuery).WithArgs(true, 1, 2).WillReturnRows(revisedRow(map[string][2]driver.Value{"done": {false, true}}))
+				mock.ExpectExec(revisionQuery).WithArgs(2, 1, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows(recurrenceColumns).AddRow("FREQ=DAILY", false, 1, "2024-01-04", 7, "UTC"))
+				mock.ExpectQuery(nextQuery).WithArgs(2, "2024-01-05").WillReturnError(fmt.Errorf("insert error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			err := todoItemRepository.Update(context.TODO(), 1, 2, domain.UpdateTodoItemInput{Done: boolPointer(true)}, domain.CompletionRollUp{}, false)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNextDueDate(t *testing.T) {
+
+	tests := []struct {
+		name                 string
+		rule                 string
+		occurrence           int
+		dueDate              domain.Date
+		repeatFromCompletion bool
+		now                  time.Time
+		timezone             string
+		want                 string
+		wantOk               bool
+	}{
+		{
+			name: "From due date", rule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", occurrence: 1, dueDate: "2024-03-29",
+			now: time.Date(2024, time.April, 3, 12, 0, 0, 0, time.UTC), timezone: "UTC", want: "2024-04-30", wantOk: true,
+		},
+		{
+			name: "From completion after the transition", rule: "FREQ=DAILY;INTERVAL=2", occurrence: 1, dueDate: "2024-03-01", repeatFromCompletion: true,
+			now: time.Date(2024, time.March, 10, 6, 30, 0, 0, time.UTC), timezone: "America/New_York", want: "2024-03-12", wantOk: true,
+		},
+		{
+			name: "From completion on the previous local day", rule: "FREQ=DAILY;INTERVAL=2", occurrence: 1, dueDate: "2024-03-01", repeatFromCompletion: true,
+			now: time.Date(2024, time.March, 10, 3, 0, 0, 0, time.UTC), timezone: "America/New_York", want: "2024-03-11", wantOk: true,
+		},
+		{
+			name: "Until passed", rule: "FREQ=WEEKLY;UNTIL=20240310", occurrence: 1, dueDate: "2024-03-08",
+			now: time.Date(2024, time.March, 8, 12, 0, 0, 0, time.UTC), timezone: "UTC",
+		},
+		{
+			name: "Count reached", rule: "FREQ=WEEKLY;COUNT=2", occurrence: 2, dueDate: "2024-03-08",
+			now: time.Date(2024, time.March, 8, 12, 0, 0, 0, time.UTC), timezone: "UTC",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			got, ok, err := nextDueDate(test.rule, test.occurrence, test.dueDate, test.repeatFromCompletion, test.now, test.timezone)
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantOk, ok)
+			if test.wantOk {
+				assert.Equal(t, test.want, got.Format(domain.DateLayout))
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	remindersTable = "reminders"
+)
+
+// reminderFireAt is the moment the reminder r of the item ti fires for the user u:
+// the snooze time of a snoozed reminder, the given time or the given number of
+// minutes before the item is due.
+const reminderFireAt = "COALESCE(r.snoozed_until, r.remind_at, " + itemDueAt + " - r.minutes_before * interval '1 minute')"
+
+type postgresRemindersRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRemindersRepository(db *sqlx.DB) *postgresRemindersRepository {
+	return &postgresRemindersRepository{db: db}
+}
+
+// Create adds a reminder of the user to the item, the item has to be in one of the lists of the user.
+func (r *postgresRemindersRepository) Create(ctx context.Context, userId, itemId int, reminder domain.Reminder) (int, error) {
+
+	var reminderId int
+	query := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, remind_at, minutes_before) SELECT ti.id, ul.user_id, $3, $4 FROM %s ti
+									INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE ti.id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL RETURNING id`,
+		remindersTable, todoItemsTable, listsItemsTable, usersListsTable)
+	err := r.db.QueryRow(query, itemId, userId, reminder.RemindAt, reminder.MinutesBefore).Scan(&reminderId)
+
+	return reminderId, err
+}
+
+func (r *postgresRemindersRepository) GetByItemId(ctx context.Context, userId, itemId int) ([]domain.Reminder, error) {
+
+	var reminders []domain.Reminder
+	query := fmt.Sprintf(`SELECT r.id, r.item_id, r.remind_at, r.minutes_before, r.snoozed_until, %s AS fire_at, r.sent_at FROM %s r
+									INNER JOIN %s ti on ti.id = r.item_id INNER JOIN %s u on u.id = r.user_id
+									WHERE r.item_id = $1 AND r.user_id = $2 ORDER BY fire_at, r.id`,
+		reminderFireAt, remindersTable, todoItemsTable, usersTable)
+	if err := r.db.Select(&reminders, query, itemId, userId); err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (r *postgresRemindersRepository) Delete(ctx context.Context, userId, reminderId int) error {
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1 AND id = $2", remindersTable)
+	_, err := r.db.Exec(query, userId, reminderId)
+
+	return err
+}
+
+// Snooze makes a fired reminder fire once again at the given time.
+func (r *postgresRemindersRepository) Snooze(ctx context.Context, userId, reminderId int, until time.Time) error {
+
+	query := fmt.Sprintf("UPDATE %s SET snoozed_until = $1, sent_at = NULL WHERE user_id = $2 AND id = $3 AND sent_at IS NOT NULL", remindersTable)
+	result, err := r.db.Exec(query, until, userId, reminderId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Claim marks up to limit reminders which fire by now as sent and returns them.
+// The rows locked by a concurrent claim are skipped, so every reminder is
+// claimed only once no matter how many dispatchers are running. The reminders
+// are marked before they are delivered: a reminder claimed by a process which
+// crashes before delivering it is lost, it is never sent twice. The reminders
+// of the users who are no longer members of the item's list are not claimed.
+func (r *postgresRemindersRepository) Claim(ctx context.Context, now time.Time, limit int) ([]domain.ReminderDelivery, error) {
+
+	var deliveries []domain.ReminderDelivery
+	query := fmt.Sprintf(`WITH due AS (
+									SELECT r.id FROM %s r INNER JOIN %s ti on ti.id = r.item_id INNER JOIN %s u on u.id = r.user_id
+									INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s tl on tl.id = li.list_id
+									INNER JOIN %s ul on ul.list_id = li.list_id AND ul.user_id = r.user_id
+									WHERE r.sent_at IS NULL AND NOT ti.done AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL AND %s <= $1
+									ORDER BY r.id LIMIT $2 FOR UPDATE OF r SKIP LOCKED
+								)
+								UPDATE %s r SET sent_at = $1 FROM due, %s ti, %s u WHERE r.id = due.id AND ti.id = r.item_id AND u.id = r.user_id
+								RETURNING r.id, r.item_id, ti.title, %s AS due_at, u.email, u.name, u.timezone`,
+		remindersTable, todoItemsTable, usersTable, listsItemsTable, todoListTable, usersListsTable, reminderFireAt,
+		remindersTable, todoItemsTable, usersTable, itemDueAt)
+	if err := r.db.Select(&deliveries, query, now, limit); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// Release returns a claimed reminder which could not be delivered, so it is claimed again.
+func (r *postgresRemindersRepository) Release(ctx context.Context, reminderId int) error {
+
+	query := fmt.Sprintf("UPDATE %s SET sent_at = NULL WHERE id = $1", remindersTable)
+	_, err := r.db.Exec(query, reminderId)
+
+	return err
+}
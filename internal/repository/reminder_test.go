@@ -0,0 +1,425 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestReminder_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	remindersRepository := NewPostgresRemindersRepository(dbx)
+
+	type (
+		args struct {
+			userId   int
+			itemId   int
+			reminder domain.Reminder
+		}
+
+		test struct {
+			name         string
+			input        args
+			mockBehavior func(args args, id int)
+			wantId       int
+			wantErr      bool
+		}
+	)
+
+	query := fmt.Sprintf("INSERT INTO %s \\(item_id, user_id, remind_at, minutes_before\\) SELECT (.+) FROM %s ti (.+) WHERE ti.id = (.+) AND ul.user_id = (.+)",
+		remindersTable, todoItemsTable)
+
+	tests := []test{
+		{
+			name: "Ok_MinutesBefore",
+			input: args{
+				userId:   1,
+				itemId:   2,
+				reminder: domain.Reminder{MinutesBefore: intPointer(30)},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.reminder.RemindAt, args.reminder.MinutesBefore).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			},
+			wantId: 1,
+		},
+		{
+			name: "Ok_RemindAt",
+			input: args{
+				userId:   1,
+				itemId:   2,
+				reminder: domain.Reminder{RemindAt: timePointer(time.Date(2026, time.March, 29, 9, 0, 0, 0, time.UTC))},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.reminder.RemindAt, args.reminder.MinutesBefore).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			},
+			wantId: 2,
+		},
+		{
+			name: "Foreign Item",
+			input: args{
+				userId:   1,
+				itemId:   5,
+				reminder: domain.Reminder{MinutesBefore: intPointer(30)},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.reminder.RemindAt, args.reminder.MinutesBefore).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input, test.wantId)
+
+			gotId, err := remindersRepository.Create(context.TODO(), test.input.userId, test.input.itemId, test.input.reminder)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantId, gotId)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReminder_GetByItemId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	remindersRepository := NewPostgresRemindersRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+			itemId int
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			want         []domain.Reminder
+			wantErr      bool
+		}
+	)
+
+	fireAt := time.Date(2026, time.March, 29, 8, 30, 0, 0, time.UTC)
+	sentAt := time.Date(2026, time.March, 29, 8, 30, 12, 0, time.UTC)
+	columns := []string{"id", "item_id", "remind_at", "minutes_before", "snoozed_until", "fire_at", "sent_at"}
+	query := fmt.Sprintf("SELECT (.+) AS fire_at, r.sent_at FROM %s r (.+) WHERE r.item_id = (.+) AND r.user_id = (.+) ORDER BY fire_at, r.id", remindersTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 2, nil, 30, nil, fireAt, sentAt).
+					AddRow(2, 2, fireAt, nil, nil, fireAt, nil)
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			want: []domain.Reminder{
+				{Id: 1, ItemId: 2, MinutesBefore: intPointer(30), FireAt: &fireAt, SentAt: &sentAt},
+				{Id: 2, ItemId: 2, RemindAt: &fireAt, FireAt: &fireAt},
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func(args args) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId).WillReturnError(sql.ErrConnDone)
+			},
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := remindersRepository.GetByItemId(context.TODO(), test.input.userId, test.input.itemId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReminder_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	remindersRepository := NewPostgresRemindersRepository(dbx)
+
+	type (
+		args struct {
+			userId     int
+			reminderId int
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      bool
+		}
+	)
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = (.+) AND id = (.+)", remindersTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.userId, args.reminderId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId:     1,
+				reminderId: 3,
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.userId, args.reminderId).WillReturnError(sql.ErrConnDone)
+			},
+			input: args{
+				userId:     1,
+				reminderId: 3,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := remindersRepository.Delete(context.TODO(), test.input.userId, test.input.reminderId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReminder_Snooze(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	remindersRepository := NewPostgresRemindersRepository(dbx)
+
+	type (
+		args struct {
+			userId     int
+			reminderId int
+			until      time.Time
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      error
+		}
+	)
+
+	until := time.Date(2026, time.March, 29, 8, 40, 0, 0, time.UTC)
+	query := fmt.Sprintf("UPDATE %s SET snoozed_until = (.+), sent_at = NULL WHERE user_id = (.+) AND id = (.+) AND sent_at IS NOT NULL", remindersTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.until, args.userId, args.reminderId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId:     1,
+				reminderId: 3,
+				until:      until,
+			},
+		},
+		{
+			name: "Not fired",
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.until, args.userId, args.reminderId).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			input: args{
+				userId:     1,
+				reminderId: 4,
+				until:      until,
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := remindersRepository.Snooze(context.TODO(), test.input.userId, test.input.reminderId, test.input.until)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReminder_Claim(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	remindersRepository := NewPostgresRemindersRepository(dbx)
+
+	type (
+		args struct {
+			now   time.Time
+			limit int
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			want         []domain.ReminderDelivery
+			wantErr      bool
+		}
+	)
+
+	now := time.Date(2026, time.March, 29, 8, 30, 0, 0, time.UTC)
+	dueAt := time.Date(2026, time.March, 29, 9, 0, 0, 0, time.UTC)
+	columns := []string{"id", "item_id", "title", "due_at", "email", "name", "timezone"}
+	// only the reminders of the members of the item's list are claimed
+	query := fmt.Sprintf("WITH due AS \\( SELECT r.id FROM %s r (.+) INNER JOIN %s ul on ul.list_id = li.list_id AND ul.user_id = r.user_id "+
+		"(.+) FOR UPDATE OF r SKIP LOCKED \\) UPDATE %s r SET sent_at = (.+) RETURNING (.+)",
+		remindersTable, usersListsTable, remindersTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 2, "Pay rent", dueAt, "ann@example.com", "Ann", "Europe/Berlin").
+					AddRow(3, 4, "Call mom", nil, "bob@example.com", "Bob", "UTC")
+				mock.ExpectQuery(query).WithArgs(args.now, args.limit).WillReturnRows(rows)
+			},
+			input: args{
+				now:   now,
+				limit: 100,
+			},
+			want: []domain.ReminderDelivery{
+				{ReminderId: 1, ItemId: 2, ItemTitle: "Pay rent", DueAt: &dueAt, Email: "ann@example.com", Name: "Ann", Timezone: "Europe/Berlin"},
+				{ReminderId: 3, ItemId: 4, ItemTitle: "Call mom", Email: "bob@example.com", Name: "Bob", Timezone: "UTC"},
+			},
+		},
+		{
+			name: "Nothing fired",
+			mockBehavior: func(args args) {
+				mock.ExpectQuery(query).WithArgs(args.now, args.limit).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			input: args{
+				now:   now,
+				limit: 100,
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func(args args) {
+				mock.ExpectQuery(query).WithArgs(args.now, args.limit).WillReturnError(sql.ErrConnDone)
+			},
+			input: args{
+				now:   now,
+				limit: 100,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := remindersRepository.Claim(context.TODO(), test.input.now, test.input.limit)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReminder_Release(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	remindersRepository := NewPostgresRemindersRepository(dbx)
+
+	query := fmt.Sprintf("UPDATE %s SET sent_at = NULL WHERE id = (.+)", remindersTable)
+	mock.ExpectExec(query).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, remindersRepository.Release(context.TODO(), 3))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/andredubov/todo-backend/internal/domain"
 	"github.com/jmoiron/sqlx"
@@ -10,34 +11,147 @@ import (
 type Users interface {
 	Create(ctx context.Context, user domain.User) (int, error)
 	GetByCredentials(ctx context.Context, email, password string) (domain.User, error)
+	GetById(ctx context.Context, userId int) (domain.User, error)
+	SetTimezone(ctx context.Context, userId int, timezone string) error
 }
 
 type TodoList interface {
 	Create(ctx context.Context, todolist domain.TodoList, userId int) (int, error)
-	GetByUserId(ctx context.Context, userId int) ([]domain.TodoList, error)
+	GetByUserId(ctx context.Context, userId int, after *domain.ListKey, limit int) ([]domain.TodoList, error)
 	GetById(ctx context.Context, userId, listId int) (domain.TodoList, error)
 	Delete(ctx context.Context, userId, listId int) error
 	Update(ctx context.Context, userId, listId int, input domain.UpdateTodoListInput) error
+	Move(ctx context.Context, userId, listId int, input domain.MoveInput) error
+	SetFolder(ctx context.Context, userId, listId int, folderId *int) error
+	GetTemplates(ctx context.Context, userId int) ([]domain.TodoList, error)
+	Copy(ctx context.Context, userId, listId int, input domain.CopyTodoListInput) (int, error)
+	GetDeleted(ctx context.Context, userId int) ([]domain.TodoList, error)
+	Restore(ctx context.Context, userId, listId int) error
+	Purge(ctx context.Context, before time.Time) (int64, []string, error)
 }
 
 type TodoItem interface {
-	Create(ctx context.Context, listId int, item domain.TodoItem) (int, error)
-	GetAll(ctx context.Context, userId, listId int) ([]domain.TodoItem, error)
+	Create(ctx context.Context, listId int, item domain.TodoItem, maxDepth int) (int, error)
+	CreateTagged(ctx context.Context, userId, listId int, item domain.TodoItem, maxDepth int) (int, []domain.Tag, error)
+	GetAll(ctx context.Context, userId, listId int, filter domain.TodoItemFilter, after *domain.ItemKey, limit int) ([]domain.TodoItem, error)
+	GetByUserId(ctx context.Context, userId int, filter domain.TodoItemFilter) ([]domain.TodoItem, error)
 	GetById(ctx context.Context, userId, itemId int) (domain.TodoItem, error)
 	Delete(ctx context.Context, userId, itemId int) error
-	Update(ctx context.Context, userId, itemId int, input domain.UpdateTodoItemInput) error
+	Update(ctx context.Context, userId, itemId int, input domain.UpdateTodoItemInput, rollUp domain.CompletionRollUp, blockCompletion bool) error
+	Snooze(ctx context.Context, userId, itemId int, input domain.SnoozeTodoItemInput) (time.Time, error)
+	ReplaceDescription(ctx context.Context, userId, itemId int, old, description string) error
+	Move(ctx context.Context, userId, itemId int, input domain.TransferTodoItemInput) error
+	Copy(ctx context.Context, userId, itemId int, input domain.TransferTodoItemInput) (int, error)
+	SetParent(ctx context.Context, userId, itemId int, parentId *int, maxDepth int) error
+	Assign(ctx context.Context, userId, itemId int, assigneeId *int) error
+	GetAssignments(ctx context.Context, userId, itemId int) ([]domain.Assignment, error)
+	AddBlocker(ctx context.Context, userId, itemId, blockerId int) error
+	RemoveBlocker(ctx context.Context, userId, itemId, blockerId int) error
+	GetBlockers(ctx context.Context, userId, itemId int) ([]domain.TodoItem, error)
+	GetRevisions(ctx context.Context, userId, itemId int) ([]domain.Revision, error)
+	GetRevision(ctx context.Context, userId, itemId, revisionId int) (domain.Revision, error)
+	GetDeleted(ctx context.Context, userId int) ([]domain.TodoItem, error)
+	Restore(ctx context.Context, userId, itemId int) error
+	Purge(ctx context.Context, before time.Time) (int64, []string, error)
+}
+
+type Members interface {
+	GetByListId(ctx context.Context, userId, listId int) ([]domain.Member, error)
+	Add(ctx context.Context, userId, listId int, email string) (int, error)
+	Remove(ctx context.Context, userId, listId, memberId int) error
+}
+
+type Statuses interface {
+	Create(ctx context.Context, userId, listId int, status domain.Status) (int, error)
+	GetByListId(ctx context.Context, userId, listId int) ([]domain.Status, error)
+	GetById(ctx context.Context, userId, statusId int) (domain.Status, error)
+	Update(ctx context.Context, userId, statusId int, input domain.UpdateStatusInput, rollUp domain.CompletionRollUp, blockCompletion bool) error
+	Move(ctx context.Context, userId, statusId int, input domain.MoveInput) error
+	Delete(ctx context.Context, userId, statusId int) error
+	SetItemStatus(ctx context.Context, userId, itemId, statusId int, rollUp domain.CompletionRollUp, blockCompletion bool) error
+}
+
+type TimeEntries interface {
+	Start(ctx context.Context, userId, itemId int) (int, error)
+	Stop(ctx context.Context, userId int) (domain.TimeEntry, error)
+	Create(ctx context.Context, userId, itemId int, entry domain.TimeEntry) (int, error)
+	GetByItemId(ctx context.Context, userId, itemId int) ([]domain.TimeEntry, error)
+	Update(ctx context.Context, userId, entryId int, input domain.UpdateTimeEntryInput) error
+	Delete(ctx context.Context, userId, entryId int) error
+	GetItemTotal(ctx context.Context, userId, itemId int) (domain.TimeTotal, error)
+	GetListTotal(ctx context.Context, userId, listId int) (domain.TimeTotal, error)
+	GetReport(ctx context.Context, userId int, filter domain.TimeReportFilter) ([]domain.TimeReportRow, error)
+}
+
+type Folders interface {
+	Create(ctx context.Context, userId int, folder domain.Folder) (int, error)
+	GetByUserId(ctx context.Context, userId int) ([]domain.Folder, error)
+	GetById(ctx context.Context, userId, folderId int) (domain.Folder, error)
+	Update(ctx context.Context, userId, folderId int, input domain.UpdateFolderInput) error
+	Delete(ctx context.Context, userId, folderId int) error
+	Move(ctx context.Context, userId, folderId int, input domain.MoveFolderInput) error
+}
+
+type Tags interface {
+	Create(ctx context.Context, userId int, tag domain.Tag) (int, error)
+	GetByUserId(ctx context.Context, userId int) ([]domain.Tag, error)
+	GetById(ctx context.Context, userId, tagId int) (domain.Tag, error)
+	Update(ctx context.Context, userId, tagId int, input domain.UpdateTagInput) error
+	Delete(ctx context.Context, userId, tagId int) error
+	Attach(ctx context.Context, userId, itemId, tagId int) error
+	Detach(ctx context.Context, userId, itemId, tagId int) error
+}
+
+type Reminders interface {
+	Create(ctx context.Context, userId, itemId int, reminder domain.Reminder) (int, error)
+	GetByItemId(ctx context.Context, userId, itemId int) ([]domain.Reminder, error)
+	Delete(ctx context.Context, userId, reminderId int) error
+	Snooze(ctx context.Context, userId, reminderId int, until time.Time) error
+	Claim(ctx context.Context, now time.Time, limit int) ([]domain.ReminderDelivery, error)
+	Release(ctx context.Context, reminderId int) error
+}
+
+type Comments interface {
+	Create(ctx context.Context, userId, itemId int, comment domain.Comment) (int, error)
+	GetByItemId(ctx context.Context, userId, itemId int, page domain.Page) ([]domain.Comment, error)
+	Update(ctx context.Context, userId, commentId int, input domain.UpdateCommentInput) error
+	Delete(ctx context.Context, userId, commentId int) error
+}
+
+type Attachments interface {
+	Create(ctx context.Context, userId, itemId int, attachment domain.Attachment, quota int64) (int, error)
+	GetByItemId(ctx context.Context, userId, itemId int) ([]domain.Attachment, error)
+	GetById(ctx context.Context, userId, attachmentId int) (domain.Attachment, error)
+	GetLinked(ctx context.Context, attachmentId int) (domain.Attachment, error)
+	Delete(ctx context.Context, userId, attachmentId int) (string, error)
 }
 
 type Repository struct {
 	Users
 	TodoList
 	TodoItem
+	Members
+	Statuses
+	Folders
+	Reminders
+	Tags
+	Comments
+	Attachments
+	TimeEntries
 }
 
 func New(db *sqlx.DB) *Repository {
 	return &Repository{
-		Users:    NewPostgresUsersRepository(db),
-		TodoList: NewPostgresTodoListRepository(db),
-		TodoItem: NewPostgresTodoItemRepository(db),
+		Users:       NewPostgresUsersRepository(db),
+		TodoList:    NewPostgresTodoListRepository(db),
+		TodoItem:    NewPostgresTodoItemRepository(db),
+		Members:     NewPostgresMembersRepository(db),
+		Statuses:    NewPostgresStatusesRepository(db),
+		Folders:     NewPostgresFoldersRepository(db),
+		Reminders:   NewPostgresRemindersRepository(db),
+		Tags:        NewPostgresTagsRepository(db),
+		Comments:    NewPostgresCommentsRepository(db),
+		Attachments: NewPostgresAttachmentsRepository(db),
+		TimeEntries: NewPostgresTimeEntriesRepository(db),
 	}
 }
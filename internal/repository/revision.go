@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+)
+
+const (
+	itemRevisionsTable = "item_revisions"
+)
+
+// revisedColumns are the columns of an item whose changes are recorded,
+// named like the fields of the item in JSON.
+var revisedColumns = []string{"title", "description", "done", "priority", "due_date", "due_time", "rrule", "repeat_from_completion", "estimate_minutes",
+	"defer_until"}
+
+// revisedItem holds the revised columns of an item. The time the item is deferred
+// until is kept as a timestamp, empty when the item is not deferred, so its change
+// is recorded and reverted the way the change of the due date is.
+type revisedItem struct {
+	domain.TodoItem
+	DeferUntil domain.Timestamp
+}
+
+// revisedSelect selects the revised columns of the item joined as the given alias.
+func revisedSelect(alias string) string {
+
+	columns := make([]string, len(revisedColumns))
+	for i, column := range revisedColumns {
+		columns[i] = alias + "." + column
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// revisedFields returns the fields of the item the revised columns are scanned into.
+func revisedFields(item *revisedItem) []interface{} {
+	return []interface{}{&item.Title, &item.Description, &item.Done, &item.Priority, &item.DueDate, &item.DueTime,
+		&item.RRule, &item.RepeatFromCompletion, &item.EstimateMinutes, &item.DeferUntil}
+}
+
+// recordRevision records the fields which differ between the item before and
+// after the change made by the user, nothing is recorded when none differs.
+func recordRevision(tx *sql.Tx, userId, itemId int, old, updated revisedItem) error {
+
+	before, after := revisedFields(&old), revisedFields(&updated)
+
+	changes := make(domain.Changes)
+	for i, column := range revisedColumns {
+		oldValue, newValue := reflect.ValueOf(before[i]).Elem().Interface(), reflect.ValueOf(after[i]).Elem().Interface()
+		if oldValue != newValue {
+			changes[column] = domain.Change{Old: oldValue, New: newValue}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (item_id, user_id, changes) VALUES ($1, $2, $3)", itemRevisionsTable)
+	_, err := tx.Exec(query, itemId, userId, changes)
+
+	return err
+}
+
+// GetRevisions returns the history of the changes of the item, the latest change first.
+func (r *postgresTodoItemRepository) GetRevisions(ctx context.Context, userId, itemId int) ([]domain.Revision, error) {
+
+	var revisions []domain.Revision
+	query := fmt.Sprintf(`SELECT r.id, r.item_id, r.user_id, u.name AS author_name, r.changes, r.created_at FROM %s r
+									INNER JOIN %s li on li.item_id = r.item_id INNER JOIN %s ul on ul.list_id = li.list_id LEFT JOIN %s u on u.id = r.user_id
+									WHERE r.item_id = $1 AND ul.user_id = $2 ORDER BY r.created_at DESC, r.id DESC`,
+		itemRevisionsTable, listsItemsTable, usersListsTable, usersTable)
+	if err := r.db.Select(&revisions, query, itemId, userId); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// GetRevision returns a revision of the item.
+func (r *postgresTodoItemRepository) GetRevision(ctx context.Context, userId, itemId, revisionId int) (domain.Revision, error) {
+
+	var revision domain.Revision
+	query := fmt.Sprintf(`SELECT r.id, r.item_id, r.user_id, u.name AS author_name, r.changes, r.created_at FROM %s r
+									INNER JOIN %s li on li.item_id = r.item_id INNER JOIN %s ul on ul.list_id = li.list_id LEFT JOIN %s u on u.id = r.user_id
+									WHERE r.id = $1 AND r.item_id = $2 AND ul.user_id = $3`,
+		itemRevisionsTable, listsItemsTable, usersListsTable, usersTable)
+	err := r.db.Get(&revision, query, revisionId, itemId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return revision, domain.ErrRevisionNotFound
+	}
+
+	return revision, err
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestTodoItem_GetRevisions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	createdAt := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	query := fmt.Sprintf("SELECT r.id, (.+) FROM %s r (.+) WHERE r.item_id = \\$1 AND ul.user_id = \\$2 ORDER BY r.created_at DESC, r.id DESC", itemRevisionsTable)
+	columns := []string{"id", "item_id", "user_id", "author_name", "changes", "created_at"}
+
+	rows := sqlmock.NewRows(columns).
+		AddRow(2, 5, 1, "Alice", []byte(`{"done":{"old":false,"new":true}}`), createdAt.Add(time.Hour)).
+		AddRow(1, 5, nil, nil, []byte(`{"title":{"old":"title","new":"new title"}}`), createdAt)
+	mock.ExpectQuery(query).WithArgs(5, 1).WillReturnRows(rows)
+
+	got, err := todoItemRepository.GetRevisions(context.TODO(), 1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Revision{
+		{Id: 2, ItemId: 5, AuthorId: intPointer(1), AuthorName: stringPointer("Alice"), Changes: domain.Changes{"done": {Old: false, New: true}}, CreatedAt: createdAt.Add(time.Hour)},
+		{Id: 1, ItemId: 5, Changes: domain.Changes{"title": {Old: "title", New: "new title"}}, CreatedAt: createdAt},
+	}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoItem_GetRevision(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	type test struct {
+		name         string
+		mockBehavior func()
+		revisionId   int
+		want         domain.Revision
+		wantErr      error
+	}
+
+	createdAt := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	query := fmt.Sprintf("SELECT r.id, (.+) FROM %s r (.+) WHERE r.id = \\$1 AND r.item_id = \\$2 AND ul.user_id = \\$3", itemRevisionsTable)
+	columns := []string{"id", "item_id", "user_id", "author_name", "changes", "created_at"}
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows(columns).AddRow(3, 5, 1, "Alice", []byte(`{"priority":{"old":"none","new":"high"}}`), createdAt)
+				mock.ExpectQuery(query).WithArgs(3, 5, 1).WillReturnRows(rows)
+			},
+			revisionId: 3,
+			want: domain.Revision{Id: 3, ItemId: 5, AuthorId: intPointer(1), AuthorName: stringPointer("Alice"),
+				Changes: domain.Changes{"priority": {Old: "none", New: "high"}}, CreatedAt: createdAt},
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func() {
+				mock.ExpectQuery(query).WithArgs(4, 5, 1).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			revisionId: 4,
+			wantErr:    domain.ErrRevisionNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			got, err := todoItemRepository.GetRevision(context.TODO(), 1, 5, test.revisionId)
+			assert.Equal(t, test.wantErr, err)
+			if test.wantErr == nil {
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
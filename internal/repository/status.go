@@ -0,0 +1,368 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	statusesTable = "statuses"
+)
+
+var statusPositions = positions{table: statusesTable, scopeColumn: "list_id", memberColumn: "id"}
+
+type postgresStatusesRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresStatusesRepository(db *sqlx.DB) *postgresStatusesRepository {
+	return &postgresStatusesRepository{db: db}
+}
+
+// Create adds the status after the other statuses of the list and sorts
+// the items of the list which no status fitted before into it.
+func (r *postgresStatusesRepository) Create(ctx context.Context, userId, listId int, status domain.Status) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var ownerId int
+	listQuery := fmt.Sprintf(`SELECT tl.id FROM %s tl INNER JOIN %s ul on ul.list_id = tl.id WHERE tl.id = $1 AND ul.user_id = $2 AND tl.deleted_at IS NULL`,
+		todoListTable, usersListsTable)
+	if err := tx.QueryRow(listQuery, listId, userId).Scan(&ownerId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	position, err := statusPositions.last(tx, listId)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var statusId int
+	query := fmt.Sprintf("INSERT INTO %s (list_id, name, done, wip_limit, position) VALUES ($1, $2, $3, $4, $5) RETURNING id", statusesTable)
+	if err := tx.QueryRow(query, listId, status.Name, status.Done, status.WipLimit, position).Scan(&statusId); err != nil {
+		tx.Rollback()
+		return 0, statusError(err)
+	}
+
+	if err := syncStatuses(tx, listId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return statusId, tx.Commit()
+}
+
+func (r *postgresStatusesRepository) GetByListId(ctx context.Context, userId, listId int) ([]domain.Status, error) {
+
+	var statuses []domain.Status
+	query := fmt.Sprintf(`SELECT s.id, s.list_id, s.name, s.done, s.wip_limit, s.position FROM %s s INNER JOIN %s ul on ul.list_id = s.list_id
+									WHERE s.list_id = $1 AND ul.user_id = $2 ORDER BY s.position, s.id`, statusesTable, usersListsTable)
+	err := r.db.Select(&statuses, query, listId, userId)
+
+	return statuses, err
+}
+
+func (r *postgresStatusesRepository) GetById(ctx context.Context, userId, statusId int) (domain.Status, error) {
+
+	var status domain.Status
+	query := fmt.Sprintf(`SELECT s.id, s.list_id, s.name, s.done, s.wip_limit, s.position FROM %s s INNER JOIN %s ul on ul.list_id = s.list_id
+									WHERE s.id = $1 AND ul.user_id = $2`, statusesTable, usersListsTable)
+	err := r.db.Get(&status, query, statusId, userId)
+
+	return status, err
+}
+
+// Update changes the status. The live items in the status follow it when it
+// becomes done or open, a zero WIP limit removes the limit.
+func (r *postgresStatusesRepository) Update(ctx context.Context, userId, statusId int, input domain.UpdateStatusInput, rollUp domain.CompletionRollUp, blockCompletion bool) error {
+
+	setValues, args, argId := make([]string, 0), make([]interface{}, 0), 1
+
+	if input.Name != nil {
+		setValues = append(setValues, fmt.Sprintf("name=$%d", argId))
+		args = append(args, *input.Name)
+		argId++
+	}
+
+	if input.Done != nil {
+		setValues = append(setValues, fmt.Sprintf("done=$%d", argId))
+		args = append(args, *input.Done)
+		argId++
+	}
+
+	if input.WipLimit != nil {
+		setValues = append(setValues, fmt.Sprintf("wip_limit=NULLIF($%d, 0)", argId))
+		args = append(args, *input.WipLimit)
+		argId++
+	}
+
+	if len(setValues) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var listId int
+	query := fmt.Sprintf(`UPDATE %s s SET %s FROM %s ul WHERE ul.list_id = s.list_id AND s.id = $%d AND ul.user_id = $%d RETURNING s.list_id`,
+		statusesTable, strings.Join(setValues, ", "), usersListsTable, argId, argId+1)
+	args = append(args, statusId, userId)
+
+	if err := tx.QueryRow(query, args...).Scan(&listId); err != nil {
+		tx.Rollback()
+		return statusError(err)
+	}
+
+	if input.Done != nil {
+		if err := setStatusItemsDone(tx, userId, statusId, listId, *input.Done, rollUp, blockCompletion); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// setStatusItemsDone makes the live items in the status done or open like the status.
+// The items are completed one by one the way an update completes an item, and none
+// of them is completed while any of them is blocked by open items when blockCompletion
+// is set. The next occurrences of the recurring items are sorted into the open statuses.
+func setStatusItemsDone(tx *sql.Tx, userId, statusId, listId int, done bool, rollUp domain.CompletionRollUp, blockCompletion bool) error {
+
+	if !done {
+		query := fmt.Sprintf("UPDATE %s SET done = false, completed_at = NULL, updated_at = now() WHERE status_id = $1 AND done AND deleted_at IS NULL", todoItemsTable)
+		_, err := tx.Exec(query, statusId)
+		return err
+	}
+
+	if blockCompletion {
+		var blocked bool
+		blockedQuery := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s ti WHERE ti.status_id = $1 AND NOT ti.done AND ti.deleted_at IS NULL AND %s)", todoItemsTable, itemBlocked)
+		if err := tx.QueryRow(blockedQuery, statusId).Scan(&blocked); err != nil {
+			return err
+		}
+
+		if blocked {
+			return domain.ErrItemBlocked
+		}
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET done = true, completed_at = now(), updated_at = now() WHERE status_id = $1 AND NOT done AND deleted_at IS NULL RETURNING id", todoItemsTable)
+	rows, err := tx.Query(query, statusId)
+	if err != nil {
+		return err
+	}
+
+	var itemIds []int
+	for rows.Next() {
+		var itemId int
+		if err := rows.Scan(&itemId); err != nil {
+			rows.Close()
+			return err
+		}
+		itemIds = append(itemIds, itemId)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, itemId := range itemIds {
+		if err := completeItem(tx, userId, itemId, rollUp, blockCompletion); err != nil {
+			return err
+		}
+	}
+
+	return syncStatuses(tx, listId)
+}
+
+// Move changes the position of the status among the statuses of its list.
+func (r *postgresStatusesRepository) Move(ctx context.Context, userId, statusId int, input domain.MoveInput) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	listId, err := statusList(tx, userId, statusId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	position, err := statusPositions.between(tx, listId, statusId, input)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET position = $1 WHERE id = $2", statusesTable)
+	if _, err := tx.Exec(query, position, statusId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes the status, its items are sorted into the remaining statuses of the list.
+func (r *postgresStatusesRepository) Delete(ctx context.Context, userId, statusId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var listId int
+	query := fmt.Sprintf("DELETE FROM %s s USING %s ul WHERE ul.list_id = s.list_id AND s.id = $1 AND ul.user_id = $2 RETURNING s.list_id",
+		statusesTable, usersListsTable)
+	if err := tx.QueryRow(query, statusId, userId).Scan(&listId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := syncStatuses(tx, listId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetItemStatus moves the item into a status of its list, the item becomes done
+// or open with it. The status is locked while its WIP limit is checked, so
+// concurrent moves can not overfill it. Moving an open item into a done status
+// completes it the way an update does.
+func (r *postgresStatusesRepository) SetItemStatus(ctx context.Context, userId, itemId, statusId int, rollUp domain.CompletionRollUp, blockCompletion bool) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	listId, err := targetList(tx, userId, itemId, nil)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var (
+		done     bool
+		wipLimit *int
+	)
+	statusQuery := fmt.Sprintf("SELECT done, wip_limit FROM %s WHERE id = $1 AND list_id = $2 FOR UPDATE", statusesTable)
+	err = tx.QueryRow(statusQuery, statusId, listId).Scan(&done, &wipLimit)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return domain.ErrInvalidStatus
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if wipLimit != nil {
+		var count int
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE status_id = $1 AND id <> $2 AND deleted_at IS NULL", todoItemsTable)
+		if err := tx.QueryRow(countQuery, statusId, itemId).Scan(&count); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if count >= *wipLimit {
+			tx.Rollback()
+			return domain.ErrWipLimit
+		}
+	}
+
+	var wasDone bool
+	query := fmt.Sprintf(`UPDATE %s ti SET status_id = $1, done = $2, completed_at = CASE WHEN $2 THEN COALESCE(ti.completed_at, now()) END, updated_at = now() FROM %s old
+									WHERE old.id = ti.id AND ti.id = $3 AND ti.deleted_at IS NULL RETURNING old.done`, todoItemsTable, todoItemsTable)
+	if err := tx.QueryRow(query, statusId, done, itemId).Scan(&wasDone); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if done && !wasDone {
+		if err := completeItem(tx, userId, itemId, rollUp, blockCompletion); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := syncStatuses(tx, listId); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// statusList returns the list of the status, the list has to belong to the user.
+func statusList(q rowQueryer, userId, statusId int) (int, error) {
+
+	var listId int
+	query := fmt.Sprintf(`SELECT s.list_id FROM %s s INNER JOIN %s ul on ul.list_id = s.list_id WHERE s.id = $1 AND ul.user_id = $2`,
+		statusesTable, usersListsTable)
+	err := q.QueryRow(query, statusId, userId).Scan(&listId)
+
+	return listId, err
+}
+
+// syncStatuses puts every item of the list whose status is missing, belongs to
+// another list or disagrees with the item being done into the first status of
+// the list which is done or open like the item. Nothing fits an item when the
+// list has no such status, the item is left without a status then. The statuses
+// of the list are locked first, and putting more items into a status than its
+// WIP limit allows fails with domain.ErrWipLimit, like moving them there by hand.
+func syncStatuses(tx *sql.Tx, listId int) error {
+
+	lockQuery := fmt.Sprintf("SELECT id FROM %s WHERE list_id = $1 ORDER BY id FOR UPDATE", statusesTable)
+	if _, err := tx.Exec(lockQuery, listId); err != nil {
+		return err
+	}
+
+	// the select does not see the update, so the synced items are counted on top
+	var overfilled bool
+	query := fmt.Sprintf(`WITH synced AS (
+									UPDATE %s ti SET status_id = (SELECT s.id FROM %s s WHERE s.list_id = li.list_id AND s.done = ti.done ORDER BY s.position, s.id LIMIT 1), updated_at = now()
+									FROM %s li WHERE li.item_id = ti.id AND li.list_id = $1
+									AND NOT EXISTS (SELECT 1 FROM %s cur WHERE cur.id = ti.status_id AND cur.list_id = li.list_id AND cur.done = ti.done)
+									AND (ti.status_id IS NOT NULL OR EXISTS (SELECT 1 FROM %s s WHERE s.list_id = li.list_id AND s.done = ti.done))
+									RETURNING ti.status_id, ti.deleted_at
+								) SELECT EXISTS (SELECT 1 FROM %s s WHERE s.wip_limit IS NOT NULL AND s.id IN (SELECT status_id FROM synced WHERE deleted_at IS NULL)
+									AND (SELECT COUNT(*) FROM %s ti WHERE ti.status_id = s.id AND ti.deleted_at IS NULL)
+										+ (SELECT COUNT(*) FROM synced WHERE status_id = s.id AND deleted_at IS NULL) > s.wip_limit)`,
+		todoItemsTable, statusesTable, listsItemsTable, statusesTable, statusesTable, statusesTable, todoItemsTable)
+	if err := tx.QueryRow(query, listId).Scan(&overfilled); err != nil {
+		return err
+	}
+
+	if overfilled {
+		return domain.ErrWipLimit
+	}
+
+	return nil
+}
+
+// statusError reports a second status of the same name in a list as domain.ErrStatusExists.
+func statusError(err error) error {
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return domain.ErrStatusExists
+	}
+
+	return err
+}
@@ -0,0 +1,435 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// expectStatusSync expects the statuses of the list to be locked and its items
+// to be sorted into them, overfilled tells whether a WIP limit is exceeded.
+func expectStatusSync(mock sqlmock.Sqlmock, listId int, overfilled bool) {
+	mock.ExpectExec(fmt.Sprintf("SELECT id FROM %s WHERE list_id = \\$1 ORDER BY id FOR UPDATE", statusesTable)).
+		WithArgs(listId).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(fmt.Sprintf("WITH synced AS \\( UPDATE %s ti SET status_id = (.+) FROM %s li WHERE (.+) RETURNING (.+) \\) SELECT EXISTS (.+) > s.wip_limit\\)",
+		todoItemsTable, listsItemsTable)).WithArgs(listId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(overfilled))
+}
+
+func TestStatuses_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	statusesRepository := NewPostgresStatusesRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+			listId int
+			status domain.Status
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			want         int
+			wantErr      error
+		}
+	)
+
+	listQuery := fmt.Sprintf("SELECT tl.id FROM %s tl INNER JOIN %s ul on (.+) WHERE (.+)", todoListTable, usersListsTable)
+	lastQuery := fmt.Sprintf("SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM %s WHERE list_id = \\$1", statusesTable)
+	insertQuery := fmt.Sprintf("INSERT INTO %s \\(list_id, name, done, wip_limit, position\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5\\) RETURNING id", statusesTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(args.listId))
+				mock.ExpectQuery(lastQuery).WithArgs(args.listId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectQuery(insertQuery).WithArgs(args.listId, args.status.Name, args.status.Done, args.status.WipLimit, "W").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				expectStatusSync(mock, args.listId, false)
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, listId: 7, status: domain.Status{Name: "review", WipLimit: intPointer(3)}},
+			want:  4,
+		},
+		{
+			name: "Duplicate Name",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(args.listId))
+				mock.ExpectQuery(lastQuery).WithArgs(args.listId).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+				mock.ExpectQuery(insertQuery).WithArgs(args.listId, args.status.Name, args.status.Done, args.status.WipLimit, "W").
+					WillReturnError(&pq.Error{Code: uniqueViolation, Constraint: "statuses_list_name"})
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, listId: 7, status: domain.Status{Name: "done", Done: true}},
+			wantErr: domain.ErrStatusExists,
+		},
+		{
+			name: "Foreign List",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.listId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, listId: 70, status: domain.Status{Name: "review"}},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := statusesRepository.Create(context.TODO(), test.input.userId, test.input.listId, test.input.status)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.want, got)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStatuses_GetByListId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	statusesRepository := NewPostgresStatusesRepository(dbx)
+
+	query := fmt.Sprintf("SELECT s.id, s.list_id, s.name, s.done, s.wip_limit, s.position FROM %s s INNER JOIN %s ul on (.+) ORDER BY s.position, s.id", statusesTable, usersListsTable)
+	rows := sqlmock.NewRows([]string{"id", "list_id", "name", "done", "wip_limit", "position"}).
+		AddRow(3, 7, "to do", false, nil, "F").
+		AddRow(4, 7, "in progress", false, 2, "V").
+		AddRow(5, 7, "done", true, nil, "k")
+	mock.ExpectQuery(query).WithArgs(7, 1).WillReturnRows(rows)
+
+	got, err := statusesRepository.GetByListId(context.TODO(), 1, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Status{
+		{Id: 3, ListId: 7, Name: "to do", Position: "F"},
+		{Id: 4, ListId: 7, Name: "in progress", WipLimit: intPointer(2), Position: "V"},
+		{Id: 5, ListId: 7, Name: "done", Done: true, Position: "k"},
+	}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatuses_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	statusesRepository := NewPostgresStatusesRepository(dbx)
+
+	type (
+		args struct {
+			userId          int
+			statusId        int
+			input           domain.UpdateStatusInput
+			rollUp          domain.CompletionRollUp
+			blockCompletion bool
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      error
+		}
+	)
+
+	doneQuery := fmt.Sprintf("UPDATE %s s SET done=\\$1 FROM %s ul WHERE (.+) RETURNING s.list_id", statusesTable, usersListsTable)
+	blockedQuery := fmt.Sprintf("SELECT EXISTS \\(SELECT 1 FROM %s ti WHERE ti.status_id = \\$1 AND NOT ti.done AND ti.deleted_at IS NULL AND EXISTS (.+)\\)", todoItemsTable)
+	completeQuery := fmt.Sprintf("UPDATE %s SET done = true, completed_at = now\\(\\), updated_at = now\\(\\) WHERE status_id = \\$1 AND NOT done AND deleted_at IS NULL RETURNING id", todoItemsTable)
+	reopenQuery := fmt.Sprintf("UPDATE %s SET done = false, completed_at = NULL, updated_at = now\\(\\) WHERE status_id = \\$1 AND done AND deleted_at IS NULL", todoItemsTable)
+	recurrenceQuery := fmt.Sprintf("SELECT ti.rrule, (.+) FROM %s ti (.+) AND ti.rrule <> ''", todoItemsTable)
+	parentsQuery := fmt.Sprintf("SELECT ti.id, EXISTS (.+) FROM %s c INNER JOIN %s ti on ti.id = c.parent_id WHERE c.id = \\$1 (.+)", todoItemsTable, todoItemsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s s SET name=\\$1, wip_limit=NULLIF\\(\\$2, 0\\) FROM %s ul WHERE ul.list_id = s.list_id AND s.id = \\$3 AND ul.user_id = \\$4 RETURNING s.list_id", statusesTable, usersListsTable)
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(*args.input.Name, *args.input.WipLimit, args.statusId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, statusId: 4, input: domain.UpdateStatusInput{Name: stringPointer("doing"), WipLimit: intPointer(0)}},
+		},
+		{
+			name: "Ok_Done",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(doneQuery).WithArgs(true, args.statusId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(completeQuery).WithArgs(args.statusId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(2, args.userId).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(parentsQuery).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"id", "blocked"}))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(3, args.userId).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(parentsQuery).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"id", "blocked"}))
+				expectStatusSync(mock, 7, false)
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, statusId: 4, input: domain.UpdateStatusInput{Done: boolPointer(true)}, rollUp: domain.CompletionRollUp{Parent: true}},
+		},
+		{
+			name: "Ok_Reopen",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(doneQuery).WithArgs(false, args.statusId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectExec(reopenQuery).WithArgs(args.statusId).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, statusId: 4, input: domain.UpdateStatusInput{Done: boolPointer(false)}},
+		},
+		{
+			name: "Ok_NotBlocked",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(doneQuery).WithArgs(true, args.statusId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(blockedQuery).WithArgs(args.statusId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery(completeQuery).WithArgs(args.statusId).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				expectStatusSync(mock, 7, false)
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, statusId: 4, input: domain.UpdateStatusInput{Done: boolPointer(true)}, blockCompletion: true},
+		},
+		{
+			name: "Blocked",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(doneQuery).WithArgs(true, args.statusId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(blockedQuery).WithArgs(args.statusId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, statusId: 4, input: domain.UpdateStatusInput{Done: boolPointer(true)}, blockCompletion: true},
+			wantErr: domain.ErrItemBlocked,
+		},
+		{
+			name: "Duplicate Name",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s s SET name=\\$1 FROM %s ul WHERE (.+)", statusesTable, usersListsTable)
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(*args.input.Name, args.statusId, args.userId).WillReturnError(&pq.Error{Code: uniqueViolation})
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, statusId: 4, input: domain.UpdateStatusInput{Name: stringPointer("done")}},
+			wantErr: domain.ErrStatusExists,
+		},
+		{
+			name: "Foreign Status",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(doneQuery).WithArgs(true, args.statusId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, statusId: 40, input: domain.UpdateStatusInput{Done: boolPointer(true)}},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := statusesRepository.Update(context.TODO(), test.input.userId, test.input.statusId, test.input.input, test.input.rollUp, test.input.blockCompletion)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStatuses_Move(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	statusesRepository := NewPostgresStatusesRepository(dbx)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(fmt.Sprintf("SELECT s.list_id FROM %s s INNER JOIN %s ul on (.+) WHERE s.id = \\$1 AND ul.user_id = \\$2", statusesTable, usersListsTable)).
+		WithArgs(5, 1).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+	mock.ExpectQuery(fmt.Sprintf("SELECT position FROM %s WHERE list_id = \\$1 AND id = \\$2", statusesTable)).
+		WithArgs(7, 3).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("F"))
+	mock.ExpectQuery(fmt.Sprintf("SELECT position FROM %s WHERE list_id = \\$1 AND id = \\$2", statusesTable)).
+		WithArgs(7, 4).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("V"))
+	mock.ExpectExec(fmt.Sprintf("UPDATE %s SET position = \\$1 WHERE id = \\$2", statusesTable)).WithArgs("N", 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = statusesRepository.Move(context.TODO(), 1, 5, domain.MoveInput{AfterId: intPointer(3), BeforeId: intPointer(4)})
+	assert.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatuses_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	statusesRepository := NewPostgresStatusesRepository(dbx)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(fmt.Sprintf("DELETE FROM %s s USING %s ul WHERE (.+) RETURNING s.list_id", statusesTable, usersListsTable)).
+		WithArgs(4, 1).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+	expectStatusSync(mock, 7, false)
+	mock.ExpectCommit()
+
+	err = statusesRepository.Delete(context.TODO(), 1, 4)
+	assert.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatuses_SetItemStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	statusesRepository := NewPostgresStatusesRepository(dbx)
+
+	type (
+		args struct {
+			userId          int
+			itemId          int
+			statusId        int
+			rollUp          domain.CompletionRollUp
+			blockCompletion bool
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      error
+		}
+	)
+
+	listQuery := fmt.Sprintf("SELECT li.list_id FROM %s li INNER JOIN %s ul on (.+) WHERE (.+)", listsItemsTable, usersListsTable)
+	statusQuery := fmt.Sprintf("SELECT done, wip_limit FROM %s WHERE id = \\$1 AND list_id = \\$2 FOR UPDATE", statusesTable)
+	countQuery := fmt.Sprintf("SELECT COUNT\\(\\*\\) FROM %s WHERE status_id = \\$1 AND id <> \\$2 AND deleted_at IS NULL", todoItemsTable)
+	updateQuery := fmt.Sprintf("UPDATE %s ti SET status_id = \\$1, done = \\$2, completed_at = CASE WHEN \\$2 THEN COALESCE\\(ti.completed_at, now\\(\\)\\) END, updated_at = now\\(\\) FROM %s old (.+) RETURNING old.done", todoItemsTable, todoItemsTable)
+	recurrenceQuery := fmt.Sprintf("SELECT ti.rrule, (.+) FROM %s ti (.+) AND ti.rrule <> ''", todoItemsTable)
+	childrenQuery := fmt.Sprintf("WITH RECURSIVE subtree AS (.+) UPDATE %s SET done = true, (.+) RETURNING id", todoItemsTable)
+	blockedChildrenQuery := fmt.Sprintf("WITH RECURSIVE subtree AS (.+) SELECT EXISTS \\(SELECT 1 FROM %s ti WHERE ti.id IN \\(SELECT id FROM subtree\\) (.+)\\)", todoItemsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(statusQuery).WithArgs(args.statusId, 7).WillReturnRows(sqlmock.NewRows([]string{"done", "wip_limit"}).AddRow(false, 3))
+				mock.ExpectQuery(countQuery).WithArgs(args.statusId, args.itemId).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(updateQuery).WithArgs(args.statusId, false, args.itemId).WillReturnRows(sqlmock.NewRows([]string{"done"}).AddRow(false))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, itemId: 2, statusId: 4},
+		},
+		{
+			name: "Ok_Complete",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(statusQuery).WithArgs(args.statusId, 7).WillReturnRows(sqlmock.NewRows([]string{"done", "wip_limit"}).AddRow(true, nil))
+				mock.ExpectQuery(updateQuery).WithArgs(args.statusId, true, args.itemId).WillReturnRows(sqlmock.NewRows([]string{"done"}).AddRow(false))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(childrenQuery).WithArgs(args.itemId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(3, args.userId).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(4, args.userId).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				expectStatusSync(mock, 7, false)
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, itemId: 2, statusId: 5, rollUp: domain.CompletionRollUp{Children: true}},
+		},
+		{
+			name: "Blocked Subtask",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(statusQuery).WithArgs(args.statusId, 7).WillReturnRows(sqlmock.NewRows([]string{"done", "wip_limit"}).AddRow(true, nil))
+				mock.ExpectQuery(updateQuery).WithArgs(args.statusId, true, args.itemId).WillReturnRows(sqlmock.NewRows([]string{"done"}).AddRow(false))
+				mock.ExpectQuery(recurrenceQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"rrule"}))
+				mock.ExpectQuery(blockedChildrenQuery).WithArgs(args.itemId).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 2, statusId: 5, rollUp: domain.CompletionRollUp{Children: true}, blockCompletion: true},
+			wantErr: domain.ErrItemBlocked,
+		},
+		{
+			name: "WIP Limit",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(statusQuery).WithArgs(args.statusId, 7).WillReturnRows(sqlmock.NewRows([]string{"done", "wip_limit"}).AddRow(false, 2))
+				mock.ExpectQuery(countQuery).WithArgs(args.statusId, args.itemId).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 2, statusId: 4},
+			wantErr: domain.ErrWipLimit,
+		},
+		{
+			name: "Status Of Another List",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(statusQuery).WithArgs(args.statusId, 7).WillReturnRows(sqlmock.NewRows([]string{"done", "wip_limit"}))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 2, statusId: 40},
+			wantErr: domain.ErrInvalidStatus,
+		},
+		{
+			name: "Foreign Item",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 20, statusId: 4},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := statusesRepository.SetItemStatus(context.TODO(), test.input.userId, test.input.itemId, test.input.statusId, test.input.rollUp, test.input.blockCompletion)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+)
+
+// SetParent makes the item a subtask of the parent item of the same list or
+// a top-level item when the parent id is nil. The whole subtree of the item
+// has to fit into the given number of subtask levels.
+func (r *postgresTodoItemRepository) SetParent(ctx context.Context, userId, itemId int, parentId *int, maxDepth int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	listId, err := targetList(tx, userId, itemId, nil)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if parentId != nil {
+		if err := checkParent(tx, listId, itemId, *parentId, maxDepth); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET parent_id = $1, updated_at = now() WHERE id = $2 AND deleted_at IS NULL", todoItemsTable)
+	if _, err := tx.Exec(query, parentId, itemId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// checkParent makes sure the item, 0 for a new one, may become a subtask of the parent:
+// the parent is a live item of the list, it is not the item itself or one of
+// its subtasks, and the subtree of the item stays within maxDepth levels.
+func checkParent(q rowQueryer, listId, itemId, parentId, maxDepth int) error {
+
+	var depth int
+	depthQuery := fmt.Sprintf(`WITH RECURSIVE ancestors AS (
+									SELECT ti.id, ti.parent_id, 1 AS depth FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									WHERE ti.id = $1 AND li.list_id = $2 AND ti.deleted_at IS NULL
+									UNION ALL
+									SELECT p.id, p.parent_id, a.depth + 1 FROM %s p INNER JOIN ancestors a on p.id = a.parent_id
+								) SELECT COALESCE(MAX(depth), 0) FROM ancestors`, todoItemsTable, listsItemsTable, todoItemsTable)
+	if err := q.QueryRow(depthQuery, parentId, listId).Scan(&depth); err != nil {
+		return err
+	}
+
+	if depth == 0 {
+		return domain.ErrInvalidParent
+	}
+
+	if itemId != 0 {
+		var (
+			height int
+			cycle  bool
+		)
+		subtreeQuery := fmt.Sprintf(`WITH RECURSIVE subtree AS (
+										SELECT id, 0 AS height FROM %s WHERE id = $1
+										UNION ALL
+										SELECT c.id, s.height + 1 FROM %s c INNER JOIN subtree s on c.parent_id = s.id
+									) SELECT MAX(height), bool_or(id = $2) FROM subtree`, todoItemsTable, todoItemsTable)
+		if err := q.QueryRow(subtreeQuery, itemId, parentId).Scan(&height, &cycle); err != nil {
+			return err
+		}
+
+		if cycle {
+			return domain.ErrSubtaskCycle
+		}
+
+		depth += height
+	}
+
+	if depth > maxDepth {
+		return domain.ErrSubtaskTooDeep
+	}
+
+	return nil
+}
+
+// completeChildren completes all the live subtasks of the item, however deep they are,
+// and schedules the next occurrences of the recurring ones. None of them is completed
+// while any of them is blocked by open items when blockCompletion is set.
+func completeChildren(tx *sql.Tx, userId, itemId int, blockCompletion bool) error {
+
+	subtree := fmt.Sprintf(`WITH RECURSIVE subtree AS (
+									SELECT id FROM %s WHERE parent_id = $1
+									UNION ALL
+									SELECT c.id FROM %s c INNER JOIN subtree s on c.parent_id = s.id
+								)`, todoItemsTable, todoItemsTable)
+
+	if blockCompletion {
+		var blocked bool
+		blockedQuery := fmt.Sprintf(`%s SELECT EXISTS (SELECT 1 FROM %s ti WHERE ti.id IN (SELECT id FROM subtree) AND NOT ti.done AND ti.deleted_at IS NULL AND %s)`,
+			subtree, todoItemsTable, itemBlocked)
+		if err := tx.QueryRow(blockedQuery, itemId).Scan(&blocked); err != nil {
+			return err
+		}
+
+		if blocked {
+			return domain.ErrItemBlocked
+		}
+	}
+
+	query := fmt.Sprintf(`%s UPDATE %s SET done = true, completed_at = now(), updated_at = now() WHERE id IN (SELECT id FROM subtree) AND NOT done AND deleted_at IS NULL RETURNING id`,
+		subtree, todoItemsTable)
+	rows, err := tx.Query(query, itemId)
+	if err != nil {
+		return err
+	}
+
+	var childIds []int
+	for rows.Next() {
+		var childId int
+		if err := rows.Scan(&childId); err != nil {
+			rows.Close()
+			return err
+		}
+		childIds = append(childIds, childId)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, childId := range childIds {
+		if err := scheduleNext(tx, userId, childId, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// completeParents walks up from the completed item and completes every parent
+// whose live subtasks are all done, stopping at the first one with an open subtask.
+// The next occurrences of the recurring parents are scheduled, and a parent blocked
+// by open items fails the roll-up when blockCompletion is set.
+func completeParents(tx *sql.Tx, userId, itemId int, blockCompletion bool) error {
+
+	parentQuery := fmt.Sprintf(`SELECT ti.id, %s FROM %s c INNER JOIN %s ti on ti.id = c.parent_id WHERE c.id = $1
+									AND NOT ti.done AND ti.deleted_at IS NULL
+									AND NOT EXISTS (SELECT 1 FROM %s s WHERE s.parent_id = ti.id AND NOT s.done AND s.deleted_at IS NULL)`,
+		itemBlocked, todoItemsTable, todoItemsTable, todoItemsTable)
+	query := fmt.Sprintf("UPDATE %s SET done = true, completed_at = now(), updated_at = now() WHERE id = $1", todoItemsTable)
+
+	for {
+		var blocked bool
+		err := tx.QueryRow(parentQuery, itemId).Scan(&itemId, &blocked)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if blocked && blockCompletion {
+			return domain.ErrItemBlocked
+		}
+
+		if _, err := tx.Exec(query, itemId); err != nil {
+			return err
+		}
+
+		// an open next occurrence keeps the grandparent open
+		if err := scheduleNext(tx, userId, itemId, time.Now()); err != nil {
+			return err
+		}
+	}
+}
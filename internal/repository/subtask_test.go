@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestTodoItem_SetParent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	todoItemRepository := NewPostgresTodoItemRepository(dbx)
+
+	type (
+		args struct {
+			userId   int
+			itemId   int
+			parentId *int
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      error
+		}
+	)
+
+	listQuery := fmt.Sprintf("SELECT li.list_id FROM %s li INNER JOIN %s ul on (.+) WHERE (.+)", listsItemsTable, usersListsTable)
+	depthQuery := "WITH RECURSIVE ancestors AS (.+) SELECT COALESCE\\(MAX\\(depth\\), 0\\) FROM ancestors"
+	subtreeQuery := "WITH RECURSIVE subtree AS (.+) SELECT MAX\\(height\\), bool_or\\(id = \\$2\\) FROM subtree"
+	updateQuery := fmt.Sprintf("UPDATE %s SET parent_id = \\$1, updated_at = now\\(\\) WHERE id = \\$2 AND deleted_at IS NULL", todoItemsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(depthQuery).WithArgs(*args.parentId, 7).WillReturnRows(sqlmock.NewRows([]string{"depth"}).AddRow(2))
+				mock.ExpectQuery(subtreeQuery).WithArgs(args.itemId, *args.parentId).WillReturnRows(sqlmock.NewRows([]string{"height", "cycle"}).AddRow(1, false))
+				mock.ExpectExec(updateQuery).WithArgs(*args.parentId, args.itemId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, itemId: 2, parentId: intPointer(3)},
+		},
+		{
+			name: "Ok_TopLevel",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectExec(updateQuery).WithArgs(nil, args.itemId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, itemId: 2},
+		},
+		{
+			name: "Parent In Another List",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(depthQuery).WithArgs(*args.parentId, 7).WillReturnRows(sqlmock.NewRows([]string{"depth"}).AddRow(0))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 2, parentId: intPointer(30)},
+			wantErr: domain.ErrInvalidParent,
+		},
+		{
+			name: "Cycle",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(depthQuery).WithArgs(*args.parentId, 7).WillReturnRows(sqlmock.NewRows([]string{"depth"}).AddRow(2))
+				mock.ExpectQuery(subtreeQuery).WithArgs(args.itemId, *args.parentId).WillReturnRows(sqlmock.NewRows([]string{"height", "cycle"}).AddRow(1, true))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 2, parentId: intPointer(4)},
+			wantErr: domain.ErrSubtaskCycle,
+		},
+		{
+			name: "Too Deep",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(listQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(7))
+				mock.ExpectQuery(depthQuery).WithArgs(*args.parentId, 7).WillReturnRows(sqlmock.NewRows([]string{"depth"}).AddRow(2))
+				mock.ExpectQuery(subtreeQuery).WithArgs(args.itemId, *args.parentId).WillReturnRows(sqlmock.NewRows([]string{"height", "cycle"}).AddRow(2, false))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 2, parentId: intPointer(3)},
+			wantErr: domain.ErrSubtaskTooDeep,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := todoItemRepository.SetParent(context.TODO(), test.input.userId, test.input.itemId, test.input.parentId, 3)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
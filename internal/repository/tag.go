@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	tagsTable      = "tags"
+	itemsTagsTable = "items_tags"
+)
+
+// uniqueViolation is the SQLSTATE of an insert or update breaking a unique constraint.
+const uniqueViolation = "23505"
+
+type postgresTagsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresTagsRepository(db *sqlx.DB) *postgresTagsRepository {
+	return &postgresTagsRepository{db: db}
+}
+
+func (r *postgresTagsRepository) Create(ctx context.Context, userId int, tag domain.Tag) (int, error) {
+
+	var tagId int
+	query := fmt.Sprintf("INSERT INTO %s (user_id, name, color) VALUES ($1, $2, $3) RETURNING id", tagsTable)
+	err := r.db.QueryRow(query, userId, tag.Name, tag.Color).Scan(&tagId)
+
+	return tagId, tagError(err)
+}
+
+// upsertTag returns the tag of the user with the name, compared regardless of
+// case, and creates the tag when the user has none. A tag of the same name
+// created concurrently is returned instead of failing on the unique constraint.
+func upsertTag(tx *sql.Tx, userId int, name string) (domain.Tag, error) {
+
+	var tag domain.Tag
+	selectQuery := fmt.Sprintf("SELECT id, name, color FROM %s WHERE user_id = $1 AND lower(name) = lower($2) ORDER BY id LIMIT 1", tagsTable)
+	err := tx.QueryRow(selectQuery, userId, name).Scan(&tag.Id, &tag.Name, &tag.Color)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return tag, err
+	}
+
+	insertQuery := fmt.Sprintf(`INSERT INTO %s (user_id, name) VALUES ($1, $2)
+									ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name RETURNING id, name, color`, tagsTable)
+	err = tx.QueryRow(insertQuery, userId, name).Scan(&tag.Id, &tag.Name, &tag.Color)
+
+	return tag, err
+}
+
+func (r *postgresTagsRepository) GetByUserId(ctx context.Context, userId int) ([]domain.Tag, error) {
+
+	var tags []domain.Tag
+	query := fmt.Sprintf("SELECT id, name, color FROM %s WHERE user_id = $1 ORDER BY name, id", tagsTable)
+	err := r.db.Select(&tags, query, userId)
+
+	return tags, err
+}
+
+func (r *postgresTagsRepository) GetById(ctx context.Context, userId, tagId int) (domain.Tag, error) {
+
+	var tag domain.Tag
+	query := fmt.Sprintf("SELECT id, name, color FROM %s WHERE user_id = $1 AND id = $2", tagsTable)
+	err := r.db.Get(&tag, query, userId, tagId)
+
+	return tag, err
+}
+
+// Update renames or recolours the tag. The items refer to the tag by its id,
+// so the change shows up on every item labelled with it.
+func (r *postgresTagsRepository) Update(ctx context.Context, userId, tagId int, input domain.UpdateTagInput) error {
+
+	setValues, args, argId := make([]string, 0), make([]interface{}, 0), 1
+
+	if input.Name != nil {
+		setValues = append(setValues, fmt.Sprintf("name=$%d", argId))
+		args = append(args, *input.Name)
+		argId++
+	}
+
+	if input.Color != nil {
+		setValues = append(setValues, fmt.Sprintf("color=$%d", argId))
+		args = append(args, *input.Color)
+		argId++
+	}
+
+	if len(setValues) == 0 {
+		return nil
+	}
+
+	setQuery := strings.Join(setValues, ", ")
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE user_id=$%d AND id=$%d", tagsTable, setQuery, argId, argId+1)
+
+	args = append(args, userId, tagId)
+
+	_, err := r.db.Exec(query, args...)
+
+	return tagError(err)
+}
+
+// Delete removes the tag, the items labelled with it lose the label.
+func (r *postgresTagsRepository) Delete(ctx context.Context, userId, tagId int) error {
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1 AND id = $2", tagsTable)
+	_, err := r.db.Exec(query, userId, tagId)
+
+	return err
+}
+
+// Attach labels the item with the tag. Both the tag and the item have to belong
+// to the user, attaching a tag once again changes nothing.
+func (r *postgresTagsRepository) Attach(ctx context.Context, userId, itemId, tagId int) error {
+
+	query := fmt.Sprintf(`INSERT INTO %s (item_id, tag_id) SELECT ti.id, t.id FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id INNER JOIN %s t on t.user_id = ul.user_id
+									WHERE ti.id = $1 AND ul.user_id = $2 AND t.id = $3 AND ti.deleted_at IS NULL
+									ON CONFLICT (item_id, tag_id) DO UPDATE SET tag_id = EXCLUDED.tag_id`,
+		itemsTagsTable, todoItemsTable, listsItemsTable, usersListsTable, tagsTable)
+	result, err := r.db.Exec(query, itemId, userId, tagId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *postgresTagsRepository) Detach(ctx context.Context, userId, itemId, tagId int) error {
+
+	query := fmt.Sprintf("DELETE FROM %s it USING %s t WHERE it.tag_id = t.id AND t.user_id = $1 AND it.item_id = $2 AND t.id = $3",
+		itemsTagsTable, tagsTable)
+	_, err := r.db.Exec(query, userId, itemId, tagId)
+
+	return err
+}
+
+// tagError reports a tag taking the name of another tag of the user as domain.ErrTagExists.
+func tagError(err error) error {
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return domain.ErrTagExists
+	}
+
+	return err
+}
+
+// itemTag is a tag together with the item it labels.
+type itemTag struct {
+	ItemId int `db:"item_id"`
+	domain.Tag
+}
+
+// attachTags fills in the tags of the user on the given items with a single query.
+func attachTags(q sqlx.Queryer, userId int, items []domain.TodoItem) error {
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	placeholders, args, index := make([]string, 0, len(items)), []interface{}{userId}, make(map[int]int, len(items))
+	for i, item := range items {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+2))
+		args = append(args, item.Id)
+		index[item.Id] = i
+	}
+
+	var tags []itemTag
+	query := fmt.Sprintf(`SELECT it.item_id, t.id, t.name, t.color FROM %s it INNER JOIN %s t on t.id = it.tag_id
+									WHERE t.user_id = $1 AND it.item_id IN (%s) ORDER BY t.name, t.id`,
+		itemsTagsTable, tagsTable, strings.Join(placeholders, ", "))
+	if err := sqlx.Select(q, &tags, query, args...); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		i := index[tag.ItemId]
+		items[i].Tags = append(items[i].Tags, tag.Tag)
+	}
+
+	return nil
+}
@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func TestTag_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	tagsRepository := NewPostgresTagsRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+			tag    domain.Tag
+		}
+
+		test struct {
+			name         string
+			input        args
+			mockBehavior func(args args, id int)
+			wantId       int
+			wantErr      error
+		}
+	)
+
+	query := fmt.Sprintf("INSERT INTO %s \\(user_id, name, color\\) VALUES (.+) RETURNING id", tagsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			input: args{
+				userId: 1,
+				tag:    domain.Tag{Name: "@waiting", Color: "#00ff00"},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectQuery(query).WithArgs(args.userId, args.tag.Name, args.tag.Color).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			},
+			wantId: 1,
+		},
+		{
+			name: "Duplicate Name",
+			input: args{
+				userId: 1,
+				tag:    domain.Tag{Name: "@waiting"},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectQuery(query).WithArgs(args.userId, args.tag.Name, args.tag.Color).WillReturnError(&pq.Error{Code: "23505"})
+			},
+			wantErr: domain.ErrTagExists,
+		},
+		{
+			name: "Database error",
+			input: args{
+				userId: 1,
+				tag:    domain.Tag{Name: "@waiting"},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectQuery(query).WithArgs(args.userId, args.tag.Name, args.tag.Color).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input, test.wantId)
+
+			gotId, err := tagsRepository.Create(context.TODO(), test.input.userId, test.input.tag)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantId, gotId)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTag_GetByUserId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	tagsRepository := NewPostgresTagsRepository(dbx)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "color"}).
+		AddRow(2, "#errand", "#ff0000").
+		AddRow(1, "@waiting", "")
+	query := fmt.Sprintf("SELECT id, name, color FROM %s WHERE user_id = (.+) ORDER BY name, id", tagsTable)
+	mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+
+	got, err := tagsRepository.GetByUserId(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Tag{{Id: 2, Name: "#errand", Color: "#ff0000"}, {Id: 1, Name: "@waiting"}}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTag_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	tagsRepository := NewPostgresTagsRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+			tagId  int
+			input  domain.UpdateTagInput
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      error
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok_Rename",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s SET name=(.+) WHERE user_id=(.+) AND id=(.+)", tagsTable)
+				mock.ExpectExec(query).WithArgs("@later", args.userId, args.tagId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId: 1,
+				tagId:  2,
+				input:  domain.UpdateTagInput{Name: stringPointer("@later")},
+			},
+		},
+		{
+			name: "Ok_AllFields",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s SET name=(.+), color=(.+) WHERE user_id=(.+) AND id=(.+)", tagsTable)
+				mock.ExpectExec(query).WithArgs("@later", "#0000ff", args.userId, args.tagId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId: 1,
+				tagId:  2,
+				input:  domain.UpdateTagInput{Name: stringPointer("@later"), Color: stringPointer("#0000ff")},
+			},
+		},
+		{
+			name:         "Ok_Nothing",
+			mockBehavior: func(args args) {},
+			input: args{
+				userId: 1,
+				tagId:  2,
+			},
+		},
+		{
+			name: "Duplicate Name",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s SET name=(.+) WHERE (.+)", tagsTable)
+				mock.ExpectExec(query).WithArgs("#errand", args.userId, args.tagId).WillReturnError(&pq.Error{Code: "23505"})
+			},
+			input: args{
+				userId: 1,
+				tagId:  2,
+				input:  domain.UpdateTagInput{Name: stringPointer("#errand")},
+			},
+			wantErr: domain.ErrTagExists,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := tagsRepository.Update(context.TODO(), test.input.userId, test.input.tagId, test.input.input)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTag_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	tagsRepository := NewPostgresTagsRepository(dbx)
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = (.+) AND id = (.+)", tagsTable)
+	mock.ExpectExec(query).WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, tagsRepository.Delete(context.TODO(), 1, 2))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTag_Attach(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	tagsRepository := NewPostgresTagsRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+			itemId int
+			tagId  int
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      error
+		}
+	)
+
+	query := fmt.Sprintf("INSERT INTO %s \\(item_id, tag_id\\) SELECT ti.id, t.id FROM %s ti (.+) ON CONFLICT \\(item_id, tag_id\\) DO UPDATE SET (.+)",
+		itemsTagsTable, todoItemsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.itemId, args.userId, args.tagId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId: 1,
+				itemId: 3,
+				tagId:  2,
+			},
+		},
+		{
+			name: "Foreign Tag Or Item",
+			mockBehavior: func(args args) {
+				mock.ExpectExec(query).WithArgs(args.itemId, args.userId, args.tagId).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			input: args{
+				userId: 1,
+				itemId: 3,
+				tagId:  5,
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := tagsRepository.Attach(context.TODO(), test.input.userId, test.input.itemId, test.input.tagId)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTag_Detach(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	tagsRepository := NewPostgresTagsRepository(dbx)
+
+	query := fmt.Sprintf("DELETE FROM %s it USING %s t WHERE it.tag_id = t.id AND t.user_id = (.+) AND it.item_id = (.+) AND t.id = (.+)", itemsTagsTable, tagsTable)
+	mock.ExpectExec(query).WithArgs(1, 3, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, tagsRepository.Detach(context.TODO(), 1, 3, 2))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	timeEntriesTable = "time_entries"
+)
+
+const (
+	// timeEntryRange is the constraint keeping the end of an entry after its start.
+	timeEntryRange = "time_entry_range"
+
+	// timeEntriesRunning is the unique index keeping a single running timer per user.
+	timeEntriesRunning = "time_entries_running"
+)
+
+// timeEntryDuration is the length of the entry joined as te in seconds, a running timer counts up to now.
+const timeEntryDuration = "EXTRACT(EPOCH FROM COALESCE(te.ended_at, now()) - te.started_at)"
+
+type postgresTimeEntriesRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresTimeEntriesRepository(db *sqlx.DB) *postgresTimeEntriesRepository {
+	return &postgresTimeEntriesRepository{db: db}
+}
+
+// Start starts a timer of the user on the item, the running timer of the user is stopped first.
+func (r *postgresTimeEntriesRepository) Start(ctx context.Context, userId, itemId int) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	stopQuery := fmt.Sprintf("UPDATE %s SET ended_at = now() WHERE user_id = $1 AND ended_at IS NULL", timeEntriesTable)
+	if _, err := tx.Exec(stopQuery, userId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var entryId int
+	query := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, started_at) SELECT ti.id, ul.user_id, now() FROM %s ti
+									INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE ti.id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL RETURNING id`,
+		timeEntriesTable, todoItemsTable, listsItemsTable, usersListsTable)
+	if err := tx.QueryRow(query, itemId, userId).Scan(&entryId); err != nil {
+		tx.Rollback()
+		return 0, timeEntryError(err)
+	}
+
+	return entryId, tx.Commit()
+}
+
+// Stop stops the running timer of the user and returns the stopped entry.
+func (r *postgresTimeEntriesRepository) Stop(ctx context.Context, userId int) (domain.TimeEntry, error) {
+
+	var entry domain.TimeEntry
+	query := fmt.Sprintf(`UPDATE %s te SET ended_at = now() WHERE te.user_id = $1 AND te.ended_at IS NULL
+									RETURNING te.id, te.item_id, te.user_id, te.started_at, te.ended_at, %s::bigint AS duration`,
+		timeEntriesTable, timeEntryDuration)
+	err := r.db.Get(&entry, query, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.TimeEntry{}, domain.ErrNoRunningTimer
+	}
+
+	return entry, err
+}
+
+// Create adds a finished entry of the user to the item, the item has to be in one of the lists of the user.
+func (r *postgresTimeEntriesRepository) Create(ctx context.Context, userId, itemId int, entry domain.TimeEntry) (int, error) {
+
+	var entryId int
+	query := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, started_at, ended_at) SELECT ti.id, ul.user_id, $3, $4 FROM %s ti
+									INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE ti.id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL RETURNING id`,
+		timeEntriesTable, todoItemsTable, listsItemsTable, usersListsTable)
+	err := r.db.QueryRow(query, itemId, userId, entry.StartedAt, entry.EndedAt).Scan(&entryId)
+
+	return entryId, timeEntryError(err)
+}
+
+// GetByItemId returns the entries of all the members of the list on the item, the latest first.
+func (r *postgresTimeEntriesRepository) GetByItemId(ctx context.Context, userId, itemId int) ([]domain.TimeEntry, error) {
+
+	var entries []domain.TimeEntry
+	query := fmt.Sprintf(`SELECT te.id, te.item_id, te.user_id, te.started_at, te.ended_at, %s::bigint AS duration FROM %s te
+									INNER JOIN %s li on li.item_id = te.item_id INNER JOIN %s ul on ul.list_id = li.list_id
+									WHERE te.item_id = $1 AND ul.user_id = $2 ORDER BY te.started_at DESC, te.id DESC`,
+		timeEntryDuration, timeEntriesTable, listsItemsTable, usersListsTable)
+	if err := r.db.Select(&entries, query, itemId, userId); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Update moves the start or the end of an entry of the user.
+func (r *postgresTimeEntriesRepository) Update(ctx context.Context, userId, entryId int, input domain.UpdateTimeEntryInput) error {
+
+	setValues, args, argId := make([]string, 0), make([]interface{}, 0), 1
+
+	if input.StartedAt != nil {
+		setValues = append(setValues, fmt.Sprintf("started_at=$%d", argId))
+		args = append(args, *input.StartedAt)
+		argId++
+	}
+
+	if input.EndedAt != nil {
+		setValues = append(setValues, fmt.Sprintf("ended_at=$%d", argId))
+		args = append(args, *input.EndedAt)
+		argId++
+	}
+
+	if len(setValues) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = $%d AND user_id = $%d", timeEntriesTable, strings.Join(setValues, ", "), argId, argId+1)
+	args = append(args, entryId, userId)
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return timeEntryError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Delete removes an entry of the user.
+func (r *postgresTimeEntriesRepository) Delete(ctx context.Context, userId, entryId int) error {
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", timeEntriesTable)
+	result, err := r.db.Exec(query, entryId, userId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetItemTotal sums up the time all the members of the list spent on the item.
+func (r *postgresTimeEntriesRepository) GetItemTotal(ctx context.Context, userId, itemId int) (domain.TimeTotal, error) {
+
+	var total domain.TimeTotal
+	query := fmt.Sprintf(`SELECT COALESCE(SUM(%s), 0)::bigint AS spent_seconds, ti.estimate_minutes FROM %s ti
+									INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									LEFT JOIN %s te on te.item_id = ti.id
+									WHERE ti.id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL GROUP BY ti.id, ti.estimate_minutes`,
+		timeEntryDuration, todoItemsTable, listsItemsTable, usersListsTable, timeEntriesTable)
+	err := r.db.Get(&total, query, itemId, userId)
+
+	return total, err
+}
+
+// GetListTotal sums up the time all the members of the list spent on its live items and their estimates.
+func (r *postgresTimeEntriesRepository) GetListTotal(ctx context.Context, userId, listId int) (domain.TimeTotal, error) {
+
+	var total domain.TimeTotal
+	query := fmt.Sprintf(`SELECT
+									COALESCE((SELECT SUM(%s) FROM %s te INNER JOIN %s ti on ti.id = te.item_id INNER JOIN %s li on li.item_id = ti.id
+										WHERE li.list_id = tl.id AND ti.deleted_at IS NULL), 0)::bigint AS spent_seconds,
+									COALESCE((SELECT SUM(ti.estimate_minutes) FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+										WHERE li.list_id = tl.id AND ti.deleted_at IS NULL), 0) AS estimate_minutes
+								FROM %s tl INNER JOIN %s ul on ul.list_id = tl.id WHERE tl.id = $1 AND ul.user_id = $2 AND tl.deleted_at IS NULL`,
+		timeEntryDuration, timeEntriesTable, todoItemsTable, listsItemsTable, todoItemsTable, listsItemsTable, todoListTable, usersListsTable)
+	err := r.db.Get(&total, query, listId, userId)
+
+	return total, err
+}
+
+// GetReport sums up the time the user spent per list and per day of the user's
+// timezone. An entry is split at midnight, so each day only gets its own part.
+func (r *postgresTimeEntriesRepository) GetReport(ctx context.Context, userId int, filter domain.TimeReportFilter) ([]domain.TimeReportRow, error) {
+
+	var rows []domain.TimeReportRow
+	query := fmt.Sprintf(`SELECT list_id, list_title, date, SUM(seconds)::bigint AS seconds FROM (
+									SELECT li.list_id, tl.title AS list_title, d.day::date AS date,
+										EXTRACT(EPOCH FROM LEAST(COALESCE(te.ended_at, now()), (d.day + interval '1 day') AT TIME ZONE u.timezone)
+											- GREATEST(te.started_at, d.day AT TIME ZONE u.timezone)) AS seconds
+									FROM %s te INNER JOIN %s u on u.id = te.user_id
+									INNER JOIN %s li on li.item_id = te.item_id INNER JOIN %s tl on tl.id = li.list_id
+									CROSS JOIN LATERAL generate_series((te.started_at AT TIME ZONE u.timezone)::date,
+										(COALESCE(te.ended_at, now()) AT TIME ZONE u.timezone)::date, interval '1 day') AS d(day)
+									WHERE te.user_id = $1 AND d.day::date BETWEEN $2 AND $3
+								) parts WHERE seconds > 0 GROUP BY list_id, list_title, date ORDER BY date, list_title, list_id`,
+		timeEntriesTable, usersTable, listsItemsTable, todoListTable)
+	if err := r.db.Select(&rows, query, userId, filter.From, filter.To); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// timeEntryError reports an entry ending before it starts as domain.ErrInvalidTimeEntry
+// and a timer started while another one has just been started as domain.ErrTimerRunning.
+func timeEntryError(err error) error {
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Constraint {
+		case timeEntryRange:
+			return domain.ErrInvalidTimeEntry
+		case timeEntriesRunning:
+			return domain.ErrTimerRunning
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,405 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/dvln/testify/assert"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func TestTimeEntries_Start(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	timeEntriesRepository := NewPostgresTimeEntriesRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+			itemId int
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			want         int
+			wantErr      error
+		}
+	)
+
+	stopQuery := fmt.Sprintf("UPDATE %s SET ended_at = now\\(\\) WHERE user_id = \\$1 AND ended_at IS NULL", timeEntriesTable)
+	insertQuery := fmt.Sprintf("INSERT INTO %s \\(item_id, user_id, started_at\\) SELECT ti.id, ul.user_id, now\\(\\) FROM %s ti (.+) RETURNING id", timeEntriesTable, todoItemsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec(stopQuery).WithArgs(args.userId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(insertQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectCommit()
+			},
+			input: args{userId: 1, itemId: 2},
+			want:  5,
+		},
+		{
+			name: "Foreign Item",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec(stopQuery).WithArgs(args.userId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(insertQuery).WithArgs(args.itemId, args.userId).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 20},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "Concurrent Start",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec(stopQuery).WithArgs(args.userId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(insertQuery).WithArgs(args.itemId, args.userId).
+					WillReturnError(&pq.Error{Code: uniqueViolation, Constraint: timeEntriesRunning})
+				mock.ExpectRollback()
+			},
+			input:   args{userId: 1, itemId: 2},
+			wantErr: domain.ErrTimerRunning,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := timeEntriesRepository.Start(context.TODO(), test.input.userId, test.input.itemId)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.want, got)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimeEntries_Stop(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	timeEntriesRepository := NewPostgresTimeEntriesRepository(dbx)
+
+	type test struct {
+		name         string
+		mockBehavior func()
+		want         domain.TimeEntry
+		wantErr      error
+	}
+
+	startedAt := time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(90 * time.Minute)
+	query := fmt.Sprintf("UPDATE %s te SET ended_at = now\\(\\) WHERE te.user_id = \\$1 AND te.ended_at IS NULL RETURNING (.+)", timeEntriesTable)
+	columns := []string{"id", "item_id", "user_id", "started_at", "ended_at", "duration"}
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows(columns).AddRow(5, 2, 1, startedAt, endedAt, 5400))
+			},
+			want: domain.TimeEntry{Id: 5, ItemId: 2, UserId: 1, StartedAt: startedAt, EndedAt: &endedAt, Duration: 5400},
+		},
+		{
+			name: "No Running Timer",
+			mockBehavior: func() {
+				mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			wantErr: domain.ErrNoRunningTimer,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			got, err := timeEntriesRepository.Stop(context.TODO(), 1)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.want, got)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimeEntries_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	timeEntriesRepository := NewPostgresTimeEntriesRepository(dbx)
+
+	type (
+		args struct {
+			userId int
+			itemId int
+			entry  domain.TimeEntry
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			want         int
+			wantErr      error
+		}
+	)
+
+	startedAt := time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(time.Hour)
+	query := fmt.Sprintf("INSERT INTO %s \\(item_id, user_id, started_at, ended_at\\) SELECT ti.id, ul.user_id, \\$3, \\$4 FROM %s ti (.+) RETURNING id", timeEntriesTable, todoItemsTable)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.entry.StartedAt, args.entry.EndedAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+			},
+			input: args{userId: 1, itemId: 2, entry: domain.TimeEntry{StartedAt: startedAt, EndedAt: &endedAt}},
+			want:  5,
+		},
+		{
+			name: "Foreign Item",
+			mockBehavior: func(args args) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.entry.StartedAt, args.entry.EndedAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			input:   args{userId: 1, itemId: 20, entry: domain.TimeEntry{StartedAt: startedAt, EndedAt: &endedAt}},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "Invalid Range",
+			mockBehavior: func(args args) {
+				mock.ExpectQuery(query).WithArgs(args.itemId, args.userId, args.entry.StartedAt, args.entry.EndedAt).
+					WillReturnError(&pq.Error{Code: "23514", Constraint: timeEntryRange})
+			},
+			input:   args{userId: 1, itemId: 2, entry: domain.TimeEntry{StartedAt: endedAt, EndedAt: &startedAt}},
+			wantErr: domain.ErrInvalidTimeEntry,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			got, err := timeEntriesRepository.Create(context.TODO(), test.input.userId, test.input.itemId, test.input.entry)
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.want, got)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimeEntries_GetByItemId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	timeEntriesRepository := NewPostgresTimeEntriesRepository(dbx)
+
+	startedAt := time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(time.Hour)
+	query := fmt.Sprintf("SELECT te.id, te.item_id, te.user_id, te.started_at, te.ended_at, (.+) FROM %s te (.+) ORDER BY te.started_at DESC, te.id DESC", timeEntriesTable)
+	rows := sqlmock.NewRows([]string{"id", "item_id", "user_id", "started_at", "ended_at", "duration"}).
+		AddRow(6, 2, 3, startedAt.Add(2*time.Hour), nil, 600).
+		AddRow(5, 2, 1, startedAt, endedAt, 3600)
+	mock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(rows)
+
+	got, err := timeEntriesRepository.GetByItemId(context.TODO(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.TimeEntry{
+		{Id: 6, ItemId: 2, UserId: 3, StartedAt: startedAt.Add(2 * time.Hour), Duration: 600},
+		{Id: 5, ItemId: 2, UserId: 1, StartedAt: startedAt, EndedAt: &endedAt, Duration: 3600},
+	}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTimeEntries_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	timeEntriesRepository := NewPostgresTimeEntriesRepository(dbx)
+
+	type (
+		args struct {
+			userId  int
+			entryId int
+			input   domain.UpdateTimeEntryInput
+		}
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      error
+		}
+	)
+
+	startedAt := time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(time.Hour)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s SET started_at=\\$1, ended_at=\\$2 WHERE id = \\$3 AND user_id = \\$4", timeEntriesTable)
+				mock.ExpectExec(query).WithArgs(*args.input.StartedAt, *args.input.EndedAt, args.entryId, args.userId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{userId: 1, entryId: 5, input: domain.UpdateTimeEntryInput{StartedAt: &startedAt, EndedAt: &endedAt}},
+		},
+		{
+			name: "End Before Start",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s SET ended_at=\\$1 WHERE id = \\$2 AND user_id = \\$3", timeEntriesTable)
+				mock.ExpectExec(query).WithArgs(*args.input.EndedAt, args.entryId, args.userId).
+					WillReturnError(&pq.Error{Code: "23514", Constraint: timeEntryRange})
+			},
+			input:   args{userId: 1, entryId: 5, input: domain.UpdateTimeEntryInput{EndedAt: &startedAt}},
+			wantErr: domain.ErrInvalidTimeEntry,
+		},
+		{
+			name: "Foreign Entry",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s SET ended_at=\\$1 WHERE id = \\$2 AND user_id = \\$3", timeEntriesTable)
+				mock.ExpectExec(query).WithArgs(*args.input.EndedAt, args.entryId, args.userId).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			input:   args{userId: 1, entryId: 50, input: domain.UpdateTimeEntryInput{EndedAt: &endedAt}},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := timeEntriesRepository.Update(context.TODO(), test.input.userId, test.input.entryId, test.input.input)
+			assert.Equal(t, test.wantErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimeEntries_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	timeEntriesRepository := NewPostgresTimeEntriesRepository(dbx)
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = \\$1 AND user_id = \\$2", timeEntriesTable)
+	mock.ExpectExec(query).WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = timeEntriesRepository.Delete(context.TODO(), 1, 5)
+	assert.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTimeEntries_GetItemTotal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	timeEntriesRepository := NewPostgresTimeEntriesRepository(dbx)
+
+	query := fmt.Sprintf("SELECT COALESCE\\(SUM\\((.+)\\), 0\\)::bigint AS spent_seconds, ti.estimate_minutes FROM %s ti (.+) GROUP BY ti.id, ti.estimate_minutes", todoItemsTable)
+	mock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"spent_seconds", "estimate_minutes"}).AddRow(5400, 120))
+
+	got, err := timeEntriesRepository.GetItemTotal(context.TODO(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TimeTotal{SpentSeconds: 5400, EstimateMinutes: 120}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTimeEntries_GetListTotal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	timeEntriesRepository := NewPostgresTimeEntriesRepository(dbx)
+
+	query := fmt.Sprintf("SELECT (.+) AS spent_seconds, (.+) AS estimate_minutes FROM %s tl INNER JOIN %s ul (.+) WHERE tl.id = \\$1 AND ul.user_id = \\$2", todoListTable, usersListsTable)
+	mock.ExpectQuery(query).WithArgs(7, 1).WillReturnRows(sqlmock.NewRows([]string{"spent_seconds", "estimate_minutes"}).AddRow(9000, 300))
+
+	got, err := timeEntriesRepository.GetListTotal(context.TODO(), 1, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TimeTotal{SpentSeconds: 9000, EstimateMinutes: 300}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTimeEntries_GetReport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	timeEntriesRepository := NewPostgresTimeEntriesRepository(dbx)
+
+	query := "SELECT list_id, list_title, date, SUM\\(seconds\\)::bigint AS seconds FROM (.+) WHERE te.user_id = \\$1 AND d.day::date BETWEEN \\$2 AND \\$3 (.+) GROUP BY list_id, list_title, date ORDER BY date, list_title, list_id"
+	rows := sqlmock.NewRows([]string{"list_id", "list_title", "date", "seconds"}).
+		AddRow(7, "home", time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC), 3600).
+		AddRow(8, "work", time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC), 1800).
+		AddRow(7, "home", time.Date(2026, time.April, 2, 0, 0, 0, 0, time.UTC), 600)
+	filter := domain.TimeReportFilter{From: "2026-04-01", To: "2026-04-07"}
+	mock.ExpectQuery(query).WithArgs(1, filter.From, filter.To).WillReturnRows(rows)
+
+	got, err := timeEntriesRepository.GetReport(context.TODO(), 1, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.TimeReportRow{
+		{ListId: 7, ListTitle: "home", Date: "2026-04-01", Seconds: 3600},
+		{ListId: 8, ListTitle: "work", Date: "2026-04-01", Seconds: 1800},
+		{ListId: 7, ListTitle: "home", Date: "2026-04-02", Seconds: 600},
+	}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -22,8 +22,8 @@ func NewPostgresUsersRepository(db *sqlx.DB) *postgresUsersRepository {
 
 func (r *postgresUsersRepository) Create(ctx context.Context, user domain.User) (int, error) {
 	var id int
-	query := fmt.Sprintf("INSERT INTO %s (name, email, password_hash) VALUES ($1, $2, $3) RETURNING id", usersTable)
-	row := r.db.QueryRow(query, user.Name, user.Email, user.Password)
+	query := fmt.Sprintf("INSERT INTO %s (name, email, password_hash, timezone) VALUES ($1, $2, $3, $4) RETURNING id", usersTable)
+	row := r.db.QueryRow(query, user.Name, user.Email, user.Password, user.Timezone)
 	if err := row.Scan(&id); err != nil {
 		return 0, err
 	}
@@ -37,3 +37,18 @@ func (r *postgresUsersRepository) GetByCredentials(ctx context.Context, email, p
 
 	return user, err
 }
+
+func (r *postgresUsersRepository) GetById(ctx context.Context, userId int) (domain.User, error) {
+	var user domain.User
+	query := fmt.Sprintf("SELECT id, name, email, timezone, created_at, updated_at FROM %s WHERE id=$1", usersTable)
+	err := r.db.Get(&user, query, userId)
+
+	return user, err
+}
+
+func (r *postgresUsersRepository) SetTimezone(ctx context.Context, userId int, timezone string) error {
+	query := fmt.Sprintf("UPDATE %s SET timezone=$1, updated_at = now() WHERE id=$2", usersTable)
+	_, err := r.db.Exec(query, timezone, userId)
+
+	return err
+}
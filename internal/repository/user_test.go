@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/andredubov/todo-backend/internal/domain"
@@ -37,12 +39,13 @@ func TestUser_Create(t *testing.T) {
 			mockBehavior: func(user domain.User) {
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
 				query := fmt.Sprintf("INSERT INTO %s", usersTable)
-				mock.ExpectQuery(query).WithArgs(user.Name, user.Email, user.Password).WillReturnRows(rows)
+				mock.ExpectQuery(query).WithArgs(user.Name, user.Email, user.Password, user.Timezone).WillReturnRows(rows)
 			},
 			input: domain.User{
 				Name:     "test name",
 				Email:    "test email",
 				Password: "test password",
+				Timezone: "Europe/Berlin",
 			},
 			want: 1,
 		},
@@ -51,7 +54,7 @@ func TestUser_Create(t *testing.T) {
 			mockBehavior: func(user domain.User) {
 				rows := sqlmock.NewRows([]string{"id"})
 				query := fmt.Sprintf("INSERT INTO %s", usersTable)
-				mock.ExpectQuery(query).WithArgs(user.Name, user.Email, user.Password).WillReturnRows(rows)
+				mock.ExpectQuery(query).WithArgs(user.Name, user.Email, user.Password, user.Timezone).WillReturnRows(rows)
 			},
 			wantErr: true,
 		},
@@ -146,3 +149,130 @@ func TestUser_GetByCredentials(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_GetById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	usersRepository := NewPostgresUsersRepository(dbx)
+
+	type test struct {
+		name         string
+		mockBehavior func()
+		userId       int
+		want         domain.User
+		wantErr      bool
+	}
+
+	createdAt := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	query := fmt.Sprintf("SELECT id, name, email, timezone, created_at, updated_at FROM %s WHERE id=\\$1", usersTable)
+	columns := []string{"id", "name", "email", "timezone", "created_at", "updated_at"}
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows(columns).AddRow(1, "Alice", "alice@example.com", "Europe/Berlin", createdAt, createdAt)
+				mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+			},
+			userId: 1,
+			want:   domain.User{Id: 1, Name: "Alice", Email: "alice@example.com", Timezone: "Europe/Berlin", CreatedAt: &createdAt, UpdatedAt: &createdAt},
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func() {
+				mock.ExpectQuery(query).WithArgs(2).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			userId:  2,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior()
+
+			got, err := usersRepository.GetById(context.TODO(), test.userId)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUser_SetTimezone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbx := sqlx.NewDb(db, "sqlmock")
+	usersRepository := NewPostgresUsersRepository(dbx)
+
+	type (
+		args struct {
+			userId   int
+			timezone string
+		}
+
+		test struct {
+			name         string
+			mockBehavior func(args)
+			input        args
+			wantErr      bool
+		}
+	)
+
+	tests := []test{
+		{
+			name: "Ok",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s SET timezone=(.+) WHERE id=(.+)", usersTable)
+				mock.ExpectExec(query).WithArgs(args.timezone, args.userId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId:   1,
+				timezone: "America/New_York",
+			},
+		},
+		{
+			name: "Database error",
+			mockBehavior: func(args args) {
+				query := fmt.Sprintf("UPDATE %s SET timezone=(.+) WHERE id=(.+)", usersTable)
+				mock.ExpectExec(query).WithArgs(args.timezone, args.userId).WillReturnError(errors.New("connection refused"))
+			},
+			input: args{
+				userId:   1,
+				timezone: "America/New_York",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			test.mockBehavior(test.input)
+
+			err := usersRepository.SetTimezone(context.TODO(), test.input.userId, test.input.timezone)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
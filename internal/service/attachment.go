@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/config"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/repository"
+	"github.com/andredubov/todo-backend/pkg/blob"
+)
+
+// sniffLength is the number of leading bytes the content type of a file is detected by.
+const sniffLength = 512
+
+type attachmentsService struct {
+	repo  repository.Attachments
+	store blob.BlobStore
+	cfg   config.AttachmentsConfig
+}
+
+func NewAttachmentsService(repo repository.Attachments, store blob.BlobStore, cfg config.AttachmentsConfig) *attachmentsService {
+	return &attachmentsService{
+		repo:  repo,
+		store: store,
+		cfg:   cfg,
+	}
+}
+
+// Create stores the uploaded file and attaches it to the item. The content
+// type is detected from the content of the file rather than trusted from the
+// client. The stored file is removed again when it can not be attached.
+func (s *attachmentsService) Create(ctx context.Context, userId, itemId int, upload domain.Upload) (int, error) {
+
+	if upload.Size > s.cfg.MaxSize {
+		return 0, domain.ErrAttachmentTooLarge
+	}
+
+	contentType, err := s.contentType(upload.Content)
+	if err != nil {
+		return 0, err
+	}
+
+	key, err := newStorageKey()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.store.Put(ctx, key, upload.Content, upload.Size, contentType); err != nil {
+		return 0, err
+	}
+
+	attachment := domain.Attachment{Name: upload.Name, ContentType: contentType, Size: upload.Size, StorageKey: key}
+	attachmentId, err := s.repo.Create(ctx, userId, itemId, attachment, s.cfg.Quota)
+	if err != nil {
+		s.store.Delete(ctx, key)
+		return 0, err
+	}
+
+	return attachmentId, nil
+}
+
+// contentType detects the content type of the file and rewinds it.
+func (s *attachmentsService) contentType(content io.ReadSeeker) (string, error) {
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", domain.ErrAttachmentType
+	}
+
+	for _, allowed := range s.cfg.ContentTypes {
+		if mediaType == allowed {
+			return contentType, nil
+		}
+	}
+
+	return "", domain.ErrAttachmentType
+}
+
+func (s *attachmentsService) GetByItemId(ctx context.Context, userId, itemId int) ([]domain.Attachment, error) {
+	return s.repo.GetByItemId(ctx, userId, itemId)
+}
+
+// GetURL signs a link to download the attachment by, the link expires after the configured time.
+func (s *attachmentsService) GetURL(ctx context.Context, userId, attachmentId int) (domain.AttachmentURL, error) {
+
+	if _, err := s.repo.GetById(ctx, userId, attachmentId); err != nil {
+		return domain.AttachmentURL{}, err
+	}
+
+	expiresAt := time.Now().Add(s.cfg.URLTTL).Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.signature(attachmentId, expires))
+
+	return domain.AttachmentURL{
+		URL:       fmt.Sprintf("/api/attachments/%d/download?%s", attachmentId, query.Encode()),
+		ExpiresAt: expiresAt.UTC(),
+	}, nil
+}
+
+// Open checks the signature and the expiry of a download link and opens the attachment it points to.
+func (s *attachmentsService) Open(ctx context.Context, attachmentId int, expires, signature string) (domain.Attachment, io.ReadCloser, error) {
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return domain.Attachment{}, nil, domain.ErrInvalidDownloadLink
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(attachmentId, expires))) {
+		return domain.Attachment{}, nil, domain.ErrInvalidDownloadLink
+	}
+
+	attachment, err := s.repo.GetLinked(ctx, attachmentId)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+// Delete detaches the attachment from the item and removes its file.
+func (s *attachmentsService) Delete(ctx context.Context, userId, attachmentId int) error {
+
+	key, err := s.repo.Delete(ctx, userId, attachmentId)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Delete(ctx, key)
+}
+
+func (s *attachmentsService) signature(attachmentId int, expires string) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.SigningKey))
+	fmt.Fprintf(mac, "%d:%s", attachmentId, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newStorageKey makes up a random key to store a file under.
+func newStorageKey() (string, error) {
+
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/repository"
+	"gopkg.in/validator.v2"
+)
+
+type commentsService struct {
+	repo repository.Comments
+}
+
+func NewCommentsService(repo repository.Comments) *commentsService {
+	return &commentsService{
+		repo: repo,
+	}
+}
+
+func (s *commentsService) Validate(comment domain.Comment) error {
+
+	if err := validator.Validate(comment); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *commentsService) ValidateUpdate(input domain.UpdateCommentInput) error {
+
+	if err := validator.Validate(input); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *commentsService) Create(ctx context.Context, userId, itemId int, comment domain.Comment) (int, error) {
+	return s.repo.Create(ctx, userId, itemId, comment)
+}
+
+// GetByItemId returns a page of the comment threads of the item, the replies come nested in the comments they reply to.
+func (s *commentsService) GetByItemId(ctx context.Context, userId, itemId int, page domain.Page) ([]domain.Comment, error) {
+
+	if err := page.Validate(); err != nil {
+		return nil, err
+	}
+
+	if page.Limit == 0 {
+		page.Limit = domain.DefaultPageLimit
+	}
+
+	comments, err := s.repo.GetByItemId(ctx, userId, itemId, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return nestComments(comments), nil
+}
+
+func (s *commentsService) Update(ctx context.Context, userId, commentId int, input domain.UpdateCommentInput) error {
+	return s.repo.Update(ctx, userId, commentId, input)
+}
+
+func (s *commentsService) Delete(ctx context.Context, userId, commentId int) error {
+	return s.repo.Delete(ctx, userId, commentId)
+}
+
+// nestComments puts every reply into the comment it replies to.
+func nestComments(comments []domain.Comment) []domain.Comment {
+
+	threads, replies := make([]domain.Comment, 0), make(map[int][]domain.Comment)
+	for _, comment := range comments {
+		if comment.ParentId == nil {
+			threads = append(threads, comment)
+			continue
+		}
+		replies[*comment.ParentId] = append(replies[*comment.ParentId], comment)
+	}
+
+	var build func(comments []domain.Comment) []domain.Comment
+	build = func(comments []domain.Comment) []domain.Comment {
+
+		for i := range comments {
+			comments[i].Replies = build(replies[comments[i].Id])
+		}
+
+		return comments
+	}
+
+	return build(threads)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/repository"
+	"gopkg.in/validator.v2"
+)
+
+type foldersService struct {
+	repo     repository.Folders
+	listRepo repository.TodoList
+}
+
+func NewFoldersService(repo repository.Folders, listRepo repository.TodoList) *foldersService {
+	return &foldersService{
+		repo:     repo,
+		listRepo: listRepo,
+	}
+}
+
+func (s *foldersService) Validate(folder domain.Folder) error {
+
+	if err := validator.Validate(folder); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *foldersService) Create(ctx context.Context, userId int, folder domain.Folder) (int, error) {
+	return s.repo.Create(ctx, userId, folder)
+}
+
+func (s *foldersService) GetByUserId(ctx context.Context, userId int) ([]domain.Folder, error) {
+	return s.repo.GetByUserId(ctx, userId)
+}
+
+func (s *foldersService) GetById(ctx context.Context, userId, folderId int) (domain.Folder, error) {
+	return s.repo.GetById(ctx, userId, folderId)
+}
+
+func (s *foldersService) Update(ctx context.Context, userId, folderId int, input domain.UpdateFolderInput) error {
+
+	if input.Name != nil && strings.TrimSpace(*input.Name) == "" {
+		return domain.ErrEmptyFolder
+	}
+
+	return s.repo.Update(ctx, userId, folderId, input)
+}
+
+func (s *foldersService) Delete(ctx context.Context, userId, folderId int) error {
+	return s.repo.Delete(ctx, userId, folderId)
+}
+
+func (s *foldersService) Move(ctx context.Context, userId, folderId int, input domain.MoveFolderInput) error {
+
+	if err := validateMove(folderId, input.MoveInput); err != nil {
+		return err
+	}
+
+	if input.ParentId != nil && *input.ParentId == folderId {
+		return domain.ErrFolderCycle
+	}
+
+	return moveError(s.repo.Move(ctx, userId, folderId, input))
+}
+
+// GetTree returns the folders of the user nested into each other with the lists
+// placed into their folders. Folders and lists keep the user-defined order.
+func (s *foldersService) GetTree(ctx context.Context, userId int) (domain.FolderTree, error) {
+
+	folders, err := s.repo.GetByUserId(ctx, userId)
+	if err != nil {
+		return domain.FolderTree{}, err
+	}
+
+	lists, err := s.listRepo.GetByUserId(ctx, userId, nil, 0)
+	if err != nil {
+		return domain.FolderTree{}, err
+	}
+
+	childFolders, folderLists := make(map[int][]domain.Folder), make(map[int][]domain.TodoList)
+	tree := domain.FolderTree{Folders: make([]domain.FolderNode, 0), Lists: make([]domain.TodoList, 0)}
+
+	var roots []domain.Folder
+	for _, folder := range folders {
+		if folder.ParentId == nil {
+			roots = append(roots, folder)
+			continue
+		}
+		childFolders[*folder.ParentId] = append(childFolders[*folder.ParentId], folder)
+	}
+
+	for _, list := range lists {
+		if list.FolderId == nil {
+			tree.Lists = append(tree.Lists, list)
+			continue
+		}
+		folderLists[*list.FolderId] = append(folderLists[*list.FolderId], list)
+	}
+
+	var build func(folder domain.Folder) domain.FolderNode
+	build = func(folder domain.Folder) domain.FolderNode {
+
+		node := domain.FolderNode{Folder: folder, Folders: make([]domain.FolderNode, 0), Lists: make([]domain.TodoList, 0)}
+		for _, child := range childFolders[folder.Id] {
+			node.Folders = append(node.Folders, build(child))
+		}
+		node.Lists = append(node.Lists, folderLists[folder.Id]...)
+
+		return node
+	}
+
+	for _, folder := range roots {
+		tree.Folders = append(tree.Folders, build(folder))
+	}
+
+	return tree, nil
+}
@@ -2,19 +2,33 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"time"
 
+	"github.com/andredubov/todo-backend/internal/config"
 	"github.com/andredubov/todo-backend/internal/domain"
 	"github.com/andredubov/todo-backend/internal/repository"
+	"github.com/andredubov/todo-backend/pkg/markdown"
+	"github.com/andredubov/todo-backend/pkg/rrule"
 	"gopkg.in/validator.v2"
 )
 
+// setTaskAttempts is how many times a task is set again in a description that
+// was changed concurrently.
+const setTaskAttempts = 3
+
 type todoItemService struct {
-	repo repository.TodoItem
+	repo         repository.TodoItem
+	subtasks     config.SubtasksConfig
+	dependencies config.DependenciesConfig
 }
 
-func NewTodoItemService(repo repository.TodoItem) *todoItemService {
+func NewTodoItemService(repo repository.TodoItem, subtasks config.SubtasksConfig, dependencies config.DependenciesConfig) *todoItemService {
 	return &todoItemService{
-		repo: repo,
+		repo:         repo,
+		subtasks:     subtasks,
+		dependencies: dependencies,
 	}
 }
 
@@ -24,15 +38,237 @@ func (s *todoItemService) Validate(todoItem domain.TodoItem) error {
 		return err
 	}
 
+	if err := todoItem.Priority.Validate(); err != nil {
+		return err
+	}
+
+	if todoItem.DueTime != "" && todoItem.DueDate == "" {
+		return domain.ErrDueTimeWithoutDate
+	}
+
+	if todoItem.EstimateMinutes < 0 {
+		return domain.ErrInvalidEstimate
+	}
+
+	if todoItem.RRule != "" {
+		if _, err := rrule.Parse(todoItem.RRule); err != nil {
+			return err
+		}
+
+		if todoItem.DueDate == "" {
+			return domain.ErrRRuleWithoutDate
+		}
+	}
+
+	return validateDue(todoItem.DueDate, todoItem.DueTime)
+}
+
+func (s *todoItemService) ValidateUpdate(input domain.UpdateTodoItemInput) error {
+
+	if err := validator.Validate(input); err != nil {
+		return err
+	}
+
+	if input.Priority != nil {
+		if err := input.Priority.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if input.EstimateMinutes != nil && *input.EstimateMinutes < 0 {
+		return domain.ErrInvalidEstimate
+	}
+
+	if input.DeferUntil != nil && *input.DeferUntil != "" {
+		if err := input.DeferUntil.Validate(); err != nil {
+			return err
+		}
+	}
+
+	var dueDate domain.Date
+	if input.DueDate != nil {
+		dueDate = *input.DueDate
+	}
+
+	var dueTime domain.TimeOfDay
+	if input.DueTime != nil {
+		dueTime = *input.DueTime
+	}
+
+	if input.DueDate != nil && dueDate == "" && dueTime != "" {
+		return domain.ErrDueTimeWithoutDate
+	}
+
+	if input.RRule != nil && *input.RRule != "" {
+		if _, err := rrule.Parse(*input.RRule); err != nil {
+			return err
+		}
+
+		if input.DueDate != nil && dueDate == "" {
+			return domain.ErrRRuleWithoutDate
+		}
+	}
+
+	return validateDue(dueDate, dueTime)
+}
+
+// validateDue checks the format of the due date and time, empty values are valid.
+func validateDue(dueDate domain.Date, dueTime domain.TimeOfDay) error {
+
+	if dueDate != "" {
+		if err := dueDate.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if dueTime != "" {
+		if err := dueTime.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (s *todoItemService) Create(ctx context.Context, listId int, item domain.TodoItem) (int, error) {
-	return s.repo.Create(ctx, listId, item)
+	return s.repo.Create(ctx, listId, item, s.subtasks.MaxDepth)
+}
+
+func (s *todoItemService) CreateTagged(ctx context.Context, userId, listId int, item domain.TodoItem) (int, []domain.Tag, error) {
+	return s.repo.CreateTagged(ctx, userId, listId, item, s.subtasks.MaxDepth)
+}
+
+// GetAll returns the page of the items of the list and the cursor of the next page,
+// the cursor is empty on the last page. Nested items are not paged, a subtask
+// and its parent could otherwise fall onto different pages.
+func (s *todoItemService) GetAll(ctx context.Context, userId, listId int, filter domain.TodoItemFilter, page domain.CursorPage) ([]domain.TodoItem, string, error) {
+
+	if err := validateFilter(filter); err != nil {
+		return nil, "", err
+	}
+
+	if err := page.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	if filter.Nested && page.Paged() {
+		return nil, "", domain.ErrNestedPage
+	}
+
+	var (
+		after  *domain.ItemKey
+		limit  int
+		cursor string
+	)
+
+	if page.Paged() {
+		// One item more than asked for tells whether there is a next page.
+		limit = page.PageLimit() + 1
+
+		if page.Cursor != "" {
+			after = new(domain.ItemKey)
+			if err := domain.DecodeCursor(page.Cursor, after); err != nil {
+				return nil, "", err
+			}
+
+			if err := after.Priority.Validate(); err != nil {
+				return nil, "", domain.ErrInvalidCursorPage
+			}
+		}
+	}
+
+	items, err := s.repo.GetAll(ctx, userId, listId, filter, after, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if limit > 0 && len(items) == limit {
+		items = items[:limit-1]
+		last := items[len(items)-1]
+		if cursor, err = domain.EncodeCursor(domain.ItemKey{Priority: last.Priority, Position: last.Position, Id: last.Id}); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if !filter.Nested {
+		return items, cursor, nil
+	}
+
+	return nestItems(items), cursor, nil
+}
+
+func (s *todoItemService) GetByUserId(ctx context.Context, userId int, filter domain.TodoItemFilter) ([]domain.TodoItem, error) {
+
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetByUserId(ctx, userId, filter)
+	if err != nil || !filter.Nested {
+		return items, err
+	}
+
+	return nestItems(items), nil
+}
+
+// nestItems puts the subtasks into their parents keeping the order of the items.
+// A subtask whose parent was filtered out is returned at the top level.
+func nestItems(items []domain.TodoItem) []domain.TodoItem {
+
+	present := make(map[int]bool, len(items))
+	for _, item := range items {
+		present[item.Id] = true
+	}
+
+	roots, children := make([]domain.TodoItem, 0), make(map[int][]domain.TodoItem)
+	for _, item := range items {
+		if item.ParentId == nil || !present[*item.ParentId] {
+			roots = append(roots, item)
+			continue
+		}
+		children[*item.ParentId] = append(children[*item.ParentId], item)
+	}
+
+	var build func(items []domain.TodoItem) []domain.TodoItem
+	build = func(items []domain.TodoItem) []domain.TodoItem {
+
+		for i := range items {
+			items[i].Children = build(children[items[i].Id])
+		}
+
+		return items
+	}
+
+	return build(roots)
 }
 
-func (s *todoItemService) GetAll(ctx context.Context, userId, listId int) ([]domain.TodoItem, error) {
-	return s.repo.GetAll(ctx, userId, listId)
+func validateFilter(filter domain.TodoItemFilter) error {
+
+	if err := validateDue(filter.DueFrom, ""); err != nil {
+		return err
+	}
+
+	if err := validateDue(filter.DueTo, ""); err != nil {
+		return err
+	}
+
+	for _, priority := range filter.Priorities {
+		if err := priority.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if filter.OrderBy != "" && filter.OrderBy != domain.ItemOrderPosition && filter.OrderBy != domain.ItemOrderPriority {
+		return domain.ErrInvalidItemOrder
+	}
+
+	if filter.Assignee != "" && filter.Assignee != domain.AssigneeMe && filter.Assignee != domain.AssigneeNone {
+		if id, err := strconv.Atoi(filter.Assignee); err != nil || id <= 0 {
+			return domain.ErrInvalidAssigneeFilter
+		}
+	}
+
+	return nil
 }
 
 func (s *todoItemService) GetById(ctx context.Context, userId, itemId int) (domain.TodoItem, error) {
@@ -43,6 +279,153 @@ func (s *todoItemService) Delete(ctx context.Context, userId, itemId int) error
 	return s.repo.Delete(ctx, userId, itemId)
 }
 
+// Update changes the item. An item blocked by open items can not be completed
+// when the dependencies are configured to block the completion, neither can the
+// subtasks and parents the completion rolls onto.
 func (s *todoItemService) Update(ctx context.Context, userId, itemId int, input domain.UpdateTodoItemInput) error {
-	return s.repo.Update(ctx, userId, itemId, input)
+
+	if s.dependencies.BlockCompletion && input.Done != nil && *input.Done {
+		blockers, err := s.repo.GetBlockers(ctx, userId, itemId)
+		if err != nil {
+			return err
+		}
+
+		for _, blocker := range blockers {
+			if !blocker.Done {
+				return domain.ErrItemBlocked
+			}
+		}
+	}
+
+	rollUp := domain.CompletionRollUp{
+		Children: s.subtasks.CompleteChildren,
+		Parent:   s.subtasks.CompleteParent,
+	}
+
+	return s.repo.Update(ctx, userId, itemId, input, rollUp, s.dependencies.BlockCompletion)
+}
+
+// Snooze defers the item by the given duration and returns the time it is deferred until.
+func (s *todoItemService) Snooze(ctx context.Context, userId, itemId int, input domain.SnoozeTodoItemInput) (time.Time, error) {
+
+	if input.Days < 0 || input.Hours < 0 || input.Minutes < 0 || input.Days+input.Hours+input.Minutes == 0 {
+		return time.Time{}, domain.ErrInvalidDeferral
+	}
+
+	return s.repo.Snooze(ctx, userId, itemId, input)
+}
+
+// SetTask checks or unchecks the task of the description with the given index.
+// The description is replaced only if nobody has changed it since it was read,
+// otherwise the task is looked up again in the new description, up to
+// setTaskAttempts times.
+func (s *todoItemService) SetTask(ctx context.Context, userId, itemId, index int, input domain.DescriptionTaskInput) error {
+
+	for attempt := 0; attempt < setTaskAttempts; attempt++ {
+		item, err := s.repo.GetById(ctx, userId, itemId)
+		if err != nil {
+			return err
+		}
+
+		description, ok := markdown.SetTask(item.Description, index, input.Done)
+		if !ok {
+			return domain.ErrTaskNotFound
+		}
+
+		if description == item.Description {
+			return nil
+		}
+
+		err = s.repo.ReplaceDescription(ctx, userId, itemId, item.Description, description)
+		if !errors.Is(err, domain.ErrDescriptionChanged) {
+			return err
+		}
+	}
+
+	return domain.ErrDescriptionChanged
+}
+
+func (s *todoItemService) SetParent(ctx context.Context, userId, itemId int, input domain.TodoItemParentInput) error {
+
+	if input.ParentId != nil && *input.ParentId == itemId {
+		return domain.ErrSubtaskCycle
+	}
+
+	return s.repo.SetParent(ctx, userId, itemId, input.ParentId, s.subtasks.MaxDepth)
+}
+
+func (s *todoItemService) Restore(ctx context.Context, userId, itemId int) error {
+	return s.repo.Restore(ctx, userId, itemId)
+}
+
+func (s *todoItemService) Move(ctx context.Context, userId, itemId int, input domain.TransferTodoItemInput) error {
+
+	if err := validateMove(itemId, input.MoveInput); err != nil {
+		return err
+	}
+
+	return moveError(s.repo.Move(ctx, userId, itemId, input))
+}
+
+// Copy places a copy of the item into the target list. The copy may be placed
+// next to the original item, so only the anchors themselves are checked.
+func (s *todoItemService) Copy(ctx context.Context, userId, itemId int, input domain.TransferTodoItemInput) (int, error) {
+
+	if err := validateMove(0, input.MoveInput); err != nil {
+		return 0, err
+	}
+
+	copyId, err := s.repo.Copy(ctx, userId, itemId, input)
+
+	return copyId, moveError(err)
+}
+
+func (s *todoItemService) Assign(ctx context.Context, userId, itemId int, input domain.TodoItemAssigneeInput) error {
+	return s.repo.Assign(ctx, userId, itemId, input.AssigneeId)
+}
+
+func (s *todoItemService) GetAssignments(ctx context.Context, userId, itemId int) ([]domain.Assignment, error) {
+	return s.repo.GetAssignments(ctx, userId, itemId)
+}
+
+func (s *todoItemService) AddBlocker(ctx context.Context, userId, itemId, blockerId int) error {
+
+	if itemId == blockerId {
+		return domain.ErrDependencyCycle
+	}
+
+	return s.repo.AddBlocker(ctx, userId, itemId, blockerId)
+}
+
+func (s *todoItemService) RemoveBlocker(ctx context.Context, userId, itemId, blockerId int) error {
+	return s.repo.RemoveBlocker(ctx, userId, itemId, blockerId)
+}
+
+func (s *todoItemService) GetBlockers(ctx context.Context, userId, itemId int) ([]domain.TodoItem, error) {
+	return s.repo.GetBlockers(ctx, userId, itemId)
+}
+
+func (s *todoItemService) GetRevisions(ctx context.Context, userId, itemId int) ([]domain.Revision, error) {
+	return s.repo.GetRevisions(ctx, userId, itemId)
+}
+
+// Revert changes the fields changed by the revision back to their values before
+// it. Reverting is a change of its own, recorded as a revision by the user.
+func (s *todoItemService) Revert(ctx context.Context, userId, itemId, revisionId int) error {
+
+	revision, err := s.repo.GetRevision(ctx, userId, itemId, revisionId)
+	if err != nil {
+		return err
+	}
+
+	input, err := revision.Changes.Revert()
+	if err != nil {
+		return err
+	}
+
+	if err := s.ValidateUpdate(input); err != nil {
+		return err
+	}
+
+	return s.Update(ctx, userId, itemId, input)
 }
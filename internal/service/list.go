@@ -27,12 +27,54 @@ func (s *todoListService) Validate(todolist domain.TodoList) error {
 	return nil
 }
 
+func (s *todoListService) ValidateUpdate(input domain.UpdateTodoListInput) error {
+
+	if err := validator.Validate(input); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *todoListService) Create(ctx context.Context, todolist domain.TodoList, userID int) (int, error) {
 	return s.repo.Create(ctx, todolist, userID)
 }
 
-func (s *todoListService) GetByUserId(ctx context.Context, userId int) ([]domain.TodoList, error) {
-	return s.repo.GetByUserId(ctx, userId)
+// GetByUserId returns the page of the lists of the user and the cursor of the next page,
+// the cursor is empty on the last page.
+func (s *todoListService) GetByUserId(ctx context.Context, userId int, page domain.CursorPage) ([]domain.TodoList, string, error) {
+
+	if err := page.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	var (
+		after *domain.ListKey
+		limit int
+	)
+
+	if page.Paged() {
+		// One list more than asked for tells whether there is a next page.
+		limit = page.PageLimit() + 1
+
+		if page.Cursor != "" {
+			after = new(domain.ListKey)
+			if err := domain.DecodeCursor(page.Cursor, after); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	lists, err := s.repo.GetByUserId(ctx, userId, after, limit)
+	if err != nil || limit == 0 || len(lists) < limit {
+		return lists, "", err
+	}
+
+	lists = lists[:limit-1]
+	last := lists[len(lists)-1]
+	cursor, err := domain.EncodeCursor(domain.ListKey{Pinned: last.Pinned, Position: last.Position, Id: last.Id})
+
+	return lists, cursor, err
 }
 
 func (s *todoListService) GetById(ctx context.Context, userId, listId int) (domain.TodoList, error) {
@@ -46,3 +88,60 @@ func (s *todoListService) Update(ctx context.Context, userId, listId int, input
 func (s *todoListService) Delete(ctx context.Context, userId, listId int) error {
 	return s.repo.Delete(ctx, userId, listId)
 }
+
+func (s *todoListService) Restore(ctx context.Context, userId, listId int) error {
+	return s.repo.Restore(ctx, userId, listId)
+}
+
+func (s *todoListService) Move(ctx context.Context, userId, listId int, input domain.MoveInput) error {
+
+	if err := validateMove(listId, input); err != nil {
+		return err
+	}
+
+	return moveError(s.repo.Move(ctx, userId, listId, input))
+}
+
+func (s *todoListService) GetTemplates(ctx context.Context, userId int) ([]domain.TodoList, error) {
+	return s.repo.GetTemplates(ctx, userId)
+}
+
+// Duplicate copies the list with all its items. Unless a title is given
+// the copy is named after the original list.
+func (s *todoListService) Duplicate(ctx context.Context, userId, listId int, input domain.CopyTodoListInput) (int, error) {
+
+	if input.Title == nil {
+		todoList, err := s.repo.GetById(ctx, userId, listId)
+		if err != nil {
+			return 0, err
+		}
+
+		title := todoList.Title + " (copy)"
+		input.Title = &title
+	}
+
+	return s.repo.Copy(ctx, userId, listId, input)
+}
+
+// Instantiate creates a regular list from the template.
+func (s *todoListService) Instantiate(ctx context.Context, userId, templateId int, input domain.CopyTodoListInput) (int, error) {
+
+	template, err := s.repo.GetById(ctx, userId, templateId)
+	if err != nil {
+		return 0, err
+	}
+
+	if !template.IsTemplate {
+		return 0, domain.ErrNotTemplate
+	}
+
+	if input.Title == nil {
+		input.Title = &template.Title
+	}
+
+	return s.repo.Copy(ctx, userId, templateId, input)
+}
+
+func (s *todoListService) SetFolder(ctx context.Context, userId, listId int, input domain.TodoListFolderInput) error {
+	return s.repo.SetFolder(ctx, userId, listId, input.FolderId)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/repository"
+	"gopkg.in/validator.v2"
+)
+
+type membersService struct {
+	repo repository.Members
+}
+
+func NewMembersService(repo repository.Members) *membersService {
+	return &membersService{
+		repo: repo,
+	}
+}
+
+func (s *membersService) Validate(input domain.ShareTodoListInput) error {
+
+	if err := validator.Validate(input); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *membersService) GetByListId(ctx context.Context, userId, listId int) ([]domain.Member, error) {
+	return s.repo.GetByListId(ctx, userId, listId)
+}
+
+func (s *membersService) Add(ctx context.Context, userId, listId int, input domain.ShareTodoListInput) (int, error) {
+	return s.repo.Add(ctx, userId, listId, input.Email)
+}
+
+func (s *membersService) Remove(ctx context.Context, userId, listId, memberId int) error {
+	return s.repo.Remove(ctx, userId, listId, memberId)
+}
@@ -6,7 +6,9 @@ package mock_service
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/andredubov/todo-backend/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -65,6 +67,20 @@ func (mr *MockUsersMockRecorder) GetByCredentials(ctx, credentials interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCredentials", reflect.TypeOf((*MockUsers)(nil).GetByCredentials), ctx, credentials)
 }
 
+// SetTimezone mocks base method.
+func (m *MockUsers) SetTimezone(ctx context.Context, userId int, input domain.TimezoneInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTimezone", ctx, userId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTimezone indicates an expected call of SetTimezone.
+func (mr *MockUsersMockRecorder) SetTimezone(ctx, userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimezone", reflect.TypeOf((*MockUsers)(nil).SetTimezone), ctx, userId, input)
+}
+
 // Validate mocks base method.
 func (m *MockUsers) Validate(user domain.User) error {
 	m.ctrl.T.Helper()
@@ -131,6 +147,21 @@ func (mr *MockTodoListMockRecorder) Delete(ctx, userId, listId interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), ctx, userId, listId)
 }
 
+// Duplicate mocks base method.
+func (m *MockTodoList) Duplicate(ctx context.Context, userId, listId int, input domain.CopyTodoListInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Duplicate", ctx, userId, listId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Duplicate indicates an expected call of Duplicate.
+func (mr *MockTodoListMockRecorder) Duplicate(ctx, userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Duplicate", reflect.TypeOf((*MockTodoList)(nil).Duplicate), ctx, userId, listId, input)
+}
+
 // GetById mocks base method.
 func (m *MockTodoList) GetById(ctx context.Context, userId, listId int) (domain.TodoList, error) {
 	m.ctrl.T.Helper()
@@ -147,18 +178,91 @@ func (mr *MockTodoListMockRecorder) GetById(ctx, userId, listId interface{}) *go
 }
 
 // GetByUserId mocks base method.
-func (m *MockTodoList) GetByUserId(ctx context.Context, userId int) ([]domain.TodoList, error) {
+func (m *MockTodoList) GetByUserId(ctx context.Context, userId int, page domain.CursorPage) ([]domain.TodoList, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByUserId", ctx, userId)
+	ret := m.ctrl.Call(m, "GetByUserId", ctx, userId, page)
+	ret0, _ := ret[0].([]domain.TodoList)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockTodoListMockRecorder) GetByUserId(ctx, userId, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockTodoList)(nil).GetByUserId), ctx, userId, page)
+}
+
+// GetTemplates mocks base method.
+func (m *MockTodoList) GetTemplates(ctx context.Context, userId int) ([]domain.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplates", ctx, userId)
 	ret0, _ := ret[0].([]domain.TodoList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByUserId indicates an expected call of GetByUserId.
-func (mr *MockTodoListMockRecorder) GetByUserId(ctx, userId interface{}) *gomock.Call {
+// GetTemplates indicates an expected call of GetTemplates.
+func (mr *MockTodoListMockRecorder) GetTemplates(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplates", reflect.TypeOf((*MockTodoList)(nil).GetTemplates), ctx, userId)
+}
+
+// Instantiate mocks base method.
+func (m *MockTodoList) Instantiate(ctx context.Context, userId, templateId int, input domain.CopyTodoListInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instantiate", ctx, userId, templateId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Instantiate indicates an expected call of Instantiate.
+func (mr *MockTodoListMockRecorder) Instantiate(ctx, userId, templateId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instantiate", reflect.TypeOf((*MockTodoList)(nil).Instantiate), ctx, userId, templateId, input)
+}
+
+// Move mocks base method.
+func (m *MockTodoList) Move(ctx context.Context, userId, listId int, input domain.MoveInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, userId, listId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTodoListMockRecorder) Move(ctx, userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoList)(nil).Move), ctx, userId, listId, input)
+}
+
+// Restore mocks base method.
+func (m *MockTodoList) Restore(ctx context.Context, userId, listId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, userId, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTodoListMockRecorder) Restore(ctx, userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTodoList)(nil).Restore), ctx, userId, listId)
+}
+
+// SetFolder mocks base method.
+func (m *MockTodoList) SetFolder(ctx context.Context, userId, listId int, input domain.TodoListFolderInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFolder", ctx, userId, listId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFolder indicates an expected call of SetFolder.
+func (mr *MockTodoListMockRecorder) SetFolder(ctx, userId, listId, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockTodoList)(nil).GetByUserId), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFolder", reflect.TypeOf((*MockTodoList)(nil).SetFolder), ctx, userId, listId, input)
 }
 
 // Update mocks base method.
@@ -189,6 +293,20 @@ func (mr *MockTodoListMockRecorder) Validate(list interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockTodoList)(nil).Validate), list)
 }
 
+// ValidateUpdate mocks base method.
+func (m *MockTodoList) ValidateUpdate(input domain.UpdateTodoListInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateUpdate", input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateUpdate indicates an expected call of ValidateUpdate.
+func (mr *MockTodoListMockRecorder) ValidateUpdate(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUpdate", reflect.TypeOf((*MockTodoList)(nil).ValidateUpdate), input)
+}
+
 // MockTodoItem is a mock of TodoItem interface.
 type MockTodoItem struct {
 	ctrl     *gomock.Controller
@@ -212,6 +330,49 @@ func (m *MockTodoItem) EXPECT() *MockTodoItemMockRecorder {
 	return m.recorder
 }
 
+// AddBlocker mocks base method.
+func (m *MockTodoItem) AddBlocker(ctx context.Context, userId, itemId, blockerId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBlocker", ctx, userId, itemId, blockerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBlocker indicates an expected call of AddBlocker.
+func (mr *MockTodoItemMockRecorder) AddBlocker(ctx, userId, itemId, blockerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlocker", reflect.TypeOf((*MockTodoItem)(nil).AddBlocker), ctx, userId, itemId, blockerId)
+}
+
+// Assign mocks base method.
+func (m *MockTodoItem) Assign(ctx context.Context, userId, itemId int, input domain.TodoItemAssigneeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, userId, itemId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockTodoItemMockRecorder) Assign(ctx, userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockTodoItem)(nil).Assign), ctx, userId, itemId, input)
+}
+
+// Copy mocks base method.
+func (m *MockTodoItem) Copy(ctx context.Context, userId, itemId int, input domain.TransferTodoItemInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", ctx, userId, itemId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Copy indicates an expected call of Copy.
+func (mr *MockTodoItemMockRecorder) Copy(ctx, userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockTodoItem)(nil).Copy), ctx, userId, itemId, input)
+}
+
 // Create mocks base method.
 func (m *MockTodoItem) Create(ctx context.Context, listId int, item domain.TodoItem) (int, error) {
 	m.ctrl.T.Helper()
@@ -227,6 +388,22 @@ func (mr *MockTodoItemMockRecorder) Create(ctx, listId, item interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoItem)(nil).Create), ctx, listId, item)
 }
 
+// CreateTagged mocks base method.
+func (m *MockTodoItem) CreateTagged(ctx context.Context, userId, listId int, item domain.TodoItem) (int, []domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTagged", ctx, userId, listId, item)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]domain.Tag)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateTagged indicates an expected call of CreateTagged.
+func (mr *MockTodoItemMockRecorder) CreateTagged(ctx, userId, listId, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTagged", reflect.TypeOf((*MockTodoItem)(nil).CreateTagged), ctx, userId, listId, item)
+}
+
 // Delete mocks base method.
 func (m *MockTodoItem) Delete(ctx context.Context, userId, itemId int) error {
 	m.ctrl.T.Helper()
@@ -242,18 +419,49 @@ func (mr *MockTodoItemMockRecorder) Delete(ctx, userId, itemId interface{}) *gom
 }
 
 // GetAll mocks base method.
-func (m *MockTodoItem) GetAll(ctx context.Context, userId, listId int) ([]domain.TodoItem, error) {
+func (m *MockTodoItem) GetAll(ctx context.Context, userId, listId int, filter domain.TodoItemFilter, page domain.CursorPage) ([]domain.TodoItem, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, userId, listId)
+	ret := m.ctrl.Call(m, "GetAll", ctx, userId, listId, filter, page)
 	ret0, _ := ret[0].([]domain.TodoItem)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTodoItemMockRecorder) GetAll(ctx, userId, listId, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), ctx, userId, listId, filter, page)
+}
+
+// GetAssignments mocks base method.
+func (m *MockTodoItem) GetAssignments(ctx context.Context, userId, itemId int) ([]domain.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignments", ctx, userId, itemId)
+	ret0, _ := ret[0].([]domain.Assignment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAll indicates an expected call of GetAll.
-func (mr *MockTodoItemMockRecorder) GetAll(ctx, userId, listId interface{}) *gomock.Call {
+// GetAssignments indicates an expected call of GetAssignments.
+func (mr *MockTodoItemMockRecorder) GetAssignments(ctx, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignments", reflect.TypeOf((*MockTodoItem)(nil).GetAssignments), ctx, userId, itemId)
+}
+
+// GetBlockers mocks base method.
+func (m *MockTodoItem) GetBlockers(ctx context.Context, userId, itemId int) ([]domain.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockers", ctx, userId, itemId)
+	ret0, _ := ret[0].([]domain.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockers indicates an expected call of GetBlockers.
+func (mr *MockTodoItemMockRecorder) GetBlockers(ctx, userId, itemId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), ctx, userId, listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockers", reflect.TypeOf((*MockTodoItem)(nil).GetBlockers), ctx, userId, itemId)
 }
 
 // GetById mocks base method.
@@ -271,6 +479,135 @@ func (mr *MockTodoItemMockRecorder) GetById(ctx, userId, itemId interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoItem)(nil).GetById), ctx, userId, itemId)
 }
 
+// GetByUserId mocks base method.
+func (m *MockTodoItem) GetByUserId(ctx context.Context, userId int, filter domain.TodoItemFilter) ([]domain.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", ctx, userId, filter)
+	ret0, _ := ret[0].([]domain.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockTodoItemMockRecorder) GetByUserId(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockTodoItem)(nil).GetByUserId), ctx, userId, filter)
+}
+
+// GetRevisions mocks base method.
+func (m *MockTodoItem) GetRevisions(ctx context.Context, userId, itemId int) ([]domain.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", ctx, userId, itemId)
+	ret0, _ := ret[0].([]domain.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockTodoItemMockRecorder) GetRevisions(ctx, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockTodoItem)(nil).GetRevisions), ctx, userId, itemId)
+}
+
+// Move mocks base method.
+func (m *MockTodoItem) Move(ctx context.Context, userId, itemId int, input domain.TransferTodoItemInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, userId, itemId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTodoItemMockRecorder) Move(ctx, userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoItem)(nil).Move), ctx, userId, itemId, input)
+}
+
+// RemoveBlocker mocks base method.
+func (m *MockTodoItem) RemoveBlocker(ctx context.Context, userId, itemId, blockerId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBlocker", ctx, userId, itemId, blockerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBlocker indicates an expected call of RemoveBlocker.
+func (mr *MockTodoItemMockRecorder) RemoveBlocker(ctx, userId, itemId, blockerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBlocker", reflect.TypeOf((*MockTodoItem)(nil).RemoveBlocker), ctx, userId, itemId, blockerId)
+}
+
+// Restore mocks base method.
+func (m *MockTodoItem) Restore(ctx context.Context, userId, itemId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, userId, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTodoItemMockRecorder) Restore(ctx, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTodoItem)(nil).Restore), ctx, userId, itemId)
+}
+
+// Revert mocks base method.
+func (m *MockTodoItem) Revert(ctx context.Context, userId, itemId, revisionId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, userId, itemId, revisionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockTodoItemMockRecorder) Revert(ctx, userId, itemId, revisionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockTodoItem)(nil).Revert), ctx, userId, itemId, revisionId)
+}
+
+// SetParent mocks base method.
+func (m *MockTodoItem) SetParent(ctx context.Context, userId, itemId int, input domain.TodoItemParentInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetParent", ctx, userId, itemId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetParent indicates an expected call of SetParent.
+func (mr *MockTodoItemMockRecorder) SetParent(ctx, userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParent", reflect.TypeOf((*MockTodoItem)(nil).SetParent), ctx, userId, itemId, input)
+}
+
+// SetTask mocks base method.
+func (m *MockTodoItem) SetTask(ctx context.Context, userId, itemId, index int, input domain.DescriptionTaskInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTask", ctx, userId, itemId, index, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTask indicates an expected call of SetTask.
+func (mr *MockTodoItemMockRecorder) SetTask(ctx, userId, itemId, index, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTask", reflect.TypeOf((*MockTodoItem)(nil).SetTask), ctx, userId, itemId, index, input)
+}
+
+// Snooze mocks base method.
+func (m *MockTodoItem) Snooze(ctx context.Context, userId, itemId int, input domain.SnoozeTodoItemInput) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snooze", ctx, userId, itemId, input)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snooze indicates an expected call of Snooze.
+func (mr *MockTodoItemMockRecorder) Snooze(ctx, userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snooze", reflect.TypeOf((*MockTodoItem)(nil).Snooze), ctx, userId, itemId, input)
+}
+
 // Update mocks base method.
 func (m *MockTodoItem) Update(ctx context.Context, userId, itemId int, input domain.UpdateTodoItemInput) error {
 	m.ctrl.T.Helper()
@@ -298,3 +635,1162 @@ func (mr *MockTodoItemMockRecorder) Validate(item interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockTodoItem)(nil).Validate), item)
 }
+
+// ValidateUpdate mocks base method.
+func (m *MockTodoItem) ValidateUpdate(input domain.UpdateTodoItemInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateUpdate", input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateUpdate indicates an expected call of ValidateUpdate.
+func (mr *MockTodoItemMockRecorder) ValidateUpdate(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUpdate", reflect.TypeOf((*MockTodoItem)(nil).ValidateUpdate), input)
+}
+
+// MockQuickAdd is a mock of QuickAdd interface.
+type MockQuickAdd struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuickAddMockRecorder
+}
+
+// MockQuickAddMockRecorder is the mock recorder for MockQuickAdd.
+type MockQuickAddMockRecorder struct {
+	mock *MockQuickAdd
+}
+
+// NewMockQuickAdd creates a new mock instance.
+func NewMockQuickAdd(ctrl *gomock.Controller) *MockQuickAdd {
+	mock := &MockQuickAdd{ctrl: ctrl}
+	mock.recorder = &MockQuickAddMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuickAdd) EXPECT() *MockQuickAddMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockQuickAdd) Add(ctx context.Context, userId, listId int, input domain.QuickAddInput, dryRun bool) (domain.QuickAdd, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, userId, listId, input, dryRun)
+	ret0, _ := ret[0].(domain.QuickAdd)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockQuickAddMockRecorder) Add(ctx, userId, listId, input, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockQuickAdd)(nil).Add), ctx, userId, listId, input, dryRun)
+}
+
+// Validate mocks base method.
+func (m *MockQuickAdd) Validate(input domain.QuickAddInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockQuickAddMockRecorder) Validate(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockQuickAdd)(nil).Validate), input)
+}
+
+// MockMembers is a mock of Members interface.
+type MockMembers struct {
+	ctrl     *gomock.Controller
+	recorder *MockMembersMockRecorder
+}
+
+// MockMembersMockRecorder is the mock recorder for MockMembers.
+type MockMembersMockRecorder struct {
+	mock *MockMembers
+}
+
+// NewMockMembers creates a new mock instance.
+func NewMockMembers(ctrl *gomock.Controller) *MockMembers {
+	mock := &MockMembers{ctrl: ctrl}
+	mock.recorder = &MockMembersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMembers) EXPECT() *MockMembersMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockMembers) Add(ctx context.Context, userId, listId int, input domain.ShareTodoListInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, userId, listId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockMembersMockRecorder) Add(ctx, userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockMembers)(nil).Add), ctx, userId, listId, input)
+}
+
+// GetByListId mocks base method.
+func (m *MockMembers) GetByListId(ctx context.Context, userId, listId int) ([]domain.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByListId", ctx, userId, listId)
+	ret0, _ := ret[0].([]domain.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByListId indicates an expected call of GetByListId.
+func (mr *MockMembersMockRecorder) GetByListId(ctx, userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByListId", reflect.TypeOf((*MockMembers)(nil).GetByListId), ctx, userId, listId)
+}
+
+// Remove mocks base method.
+func (m *MockMembers) Remove(ctx context.Context, userId, listId, memberId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, userId, listId, memberId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockMembersMockRecorder) Remove(ctx, userId, listId, memberId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockMembers)(nil).Remove), ctx, userId, listId, memberId)
+}
+
+// Validate mocks base method.
+func (m *MockMembers) Validate(input domain.ShareTodoListInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockMembersMockRecorder) Validate(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockMembers)(nil).Validate), input)
+}
+
+// MockStatuses is a mock of Statuses interface.
+type MockStatuses struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusesMockRecorder
+}
+
+// MockStatusesMockRecorder is the mock recorder for MockStatuses.
+type MockStatusesMockRecorder struct {
+	mock *MockStatuses
+}
+
+// NewMockStatuses creates a new mock instance.
+func NewMockStatuses(ctrl *gomock.Controller) *MockStatuses {
+	mock := &MockStatuses{ctrl: ctrl}
+	mock.recorder = &MockStatusesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatuses) EXPECT() *MockStatusesMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStatuses) Create(ctx context.Context, userId, listId int, status domain.Status) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, listId, status)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStatusesMockRecorder) Create(ctx, userId, listId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStatuses)(nil).Create), ctx, userId, listId, status)
+}
+
+// Delete mocks base method.
+func (m *MockStatuses) Delete(ctx context.Context, userId, statusId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, statusId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStatusesMockRecorder) Delete(ctx, userId, statusId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStatuses)(nil).Delete), ctx, userId, statusId)
+}
+
+// GetBoard mocks base method.
+func (m *MockStatuses) GetBoard(ctx context.Context, userId, listId int) (domain.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", ctx, userId, listId)
+	ret0, _ := ret[0].(domain.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockStatusesMockRecorder) GetBoard(ctx, userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockStatuses)(nil).GetBoard), ctx, userId, listId)
+}
+
+// GetByListId mocks base method.
+func (m *MockStatuses) GetByListId(ctx context.Context, userId, listId int) ([]domain.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByListId", ctx, userId, listId)
+	ret0, _ := ret[0].([]domain.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByListId indicates an expected call of GetByListId.
+func (mr *MockStatusesMockRecorder) GetByListId(ctx, userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByListId", reflect.TypeOf((*MockStatuses)(nil).GetByListId), ctx, userId, listId)
+}
+
+// Move mocks base method.
+func (m *MockStatuses) Move(ctx context.Context, userId, statusId int, input domain.MoveInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, userId, statusId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockStatusesMockRecorder) Move(ctx, userId, statusId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockStatuses)(nil).Move), ctx, userId, statusId, input)
+}
+
+// SetItemStatus mocks base method.
+func (m *MockStatuses) SetItemStatus(ctx context.Context, userId, itemId int, input domain.TodoItemStatusInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemStatus", ctx, userId, itemId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemStatus indicates an expected call of SetItemStatus.
+func (mr *MockStatusesMockRecorder) SetItemStatus(ctx, userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemStatus", reflect.TypeOf((*MockStatuses)(nil).SetItemStatus), ctx, userId, itemId, input)
+}
+
+// Update mocks base method.
+func (m *MockStatuses) Update(ctx context.Context, userId, statusId int, input domain.UpdateStatusInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userId, statusId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStatusesMockRecorder) Update(ctx, userId, statusId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStatuses)(nil).Update), ctx, userId, statusId, input)
+}
+
+// Validate mocks base method.
+func (m *MockStatuses) Validate(status domain.Status) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockStatusesMockRecorder) Validate(status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockStatuses)(nil).Validate), status)
+}
+
+// ValidateUpdate mocks base method.
+func (m *MockStatuses) ValidateUpdate(input domain.UpdateStatusInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateUpdate", input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateUpdate indicates an expected call of ValidateUpdate.
+func (mr *MockStatusesMockRecorder) ValidateUpdate(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUpdate", reflect.TypeOf((*MockStatuses)(nil).ValidateUpdate), input)
+}
+
+// MockFolders is a mock of Folders interface.
+type MockFolders struct {
+	ctrl     *gomock.Controller
+	recorder *MockFoldersMockRecorder
+}
+
+// MockFoldersMockRecorder is the mock recorder for MockFolders.
+type MockFoldersMockRecorder struct {
+	mock *MockFolders
+}
+
+// NewMockFolders creates a new mock instance.
+func NewMockFolders(ctrl *gomock.Controller) *MockFolders {
+	mock := &MockFolders{ctrl: ctrl}
+	mock.recorder = &MockFoldersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFolders) EXPECT() *MockFoldersMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFolders) Create(ctx context.Context, userId int, folder domain.Folder) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, folder)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFoldersMockRecorder) Create(ctx, userId, folder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFolders)(nil).Create), ctx, userId, folder)
+}
+
+// Delete mocks base method.
+func (m *MockFolders) Delete(ctx context.Context, userId, folderId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, folderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFoldersMockRecorder) Delete(ctx, userId, folderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFolders)(nil).Delete), ctx, userId, folderId)
+}
+
+// GetById mocks base method.
+func (m *MockFolders) GetById(ctx context.Context, userId, folderId int) (domain.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, userId, folderId)
+	ret0, _ := ret[0].(domain.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockFoldersMockRecorder) GetById(ctx, userId, folderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockFolders)(nil).GetById), ctx, userId, folderId)
+}
+
+// GetByUserId mocks base method.
+func (m *MockFolders) GetByUserId(ctx context.Context, userId int) ([]domain.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", ctx, userId)
+	ret0, _ := ret[0].([]domain.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockFoldersMockRecorder) GetByUserId(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockFolders)(nil).GetByUserId), ctx, userId)
+}
+
+// GetTree mocks base method.
+func (m *MockFolders) GetTree(ctx context.Context, userId int) (domain.FolderTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree", ctx, userId)
+	ret0, _ := ret[0].(domain.FolderTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockFoldersMockRecorder) GetTree(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockFolders)(nil).GetTree), ctx, userId)
+}
+
+// Move mocks base method.
+func (m *MockFolders) Move(ctx context.Context, userId, folderId int, input domain.MoveFolderInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, userId, folderId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockFoldersMockRecorder) Move(ctx, userId, folderId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockFolders)(nil).Move), ctx, userId, folderId, input)
+}
+
+// Update mocks base method.
+func (m *MockFolders) Update(ctx context.Context, userId, folderId int, input domain.UpdateFolderInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userId, folderId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFoldersMockRecorder) Update(ctx, userId, folderId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFolders)(nil).Update), ctx, userId, folderId, input)
+}
+
+// Validate mocks base method.
+func (m *MockFolders) Validate(folder domain.Folder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", folder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockFoldersMockRecorder) Validate(folder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockFolders)(nil).Validate), folder)
+}
+
+// MockTrash is a mock of Trash interface.
+type MockTrash struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashMockRecorder
+}
+
+// MockTrashMockRecorder is the mock recorder for MockTrash.
+type MockTrashMockRecorder struct {
+	mock *MockTrash
+}
+
+// NewMockTrash creates a new mock instance.
+func NewMockTrash(ctrl *gomock.Controller) *MockTrash {
+	mock := &MockTrash{ctrl: ctrl}
+	mock.recorder = &MockTrashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrash) EXPECT() *MockTrashMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockTrash) Get(ctx context.Context, userId int) (domain.Trash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userId)
+	ret0, _ := ret[0].(domain.Trash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTrashMockRecorder) Get(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTrash)(nil).Get), ctx, userId)
+}
+
+// Purge mocks base method.
+func (m *MockTrash) Purge(ctx context.Context, before time.Time) (int64, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTrashMockRecorder) Purge(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTrash)(nil).Purge), ctx, before)
+}
+
+// MockTags is a mock of Tags interface.
+type MockTags struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagsMockRecorder
+}
+
+// MockTagsMockRecorder is the mock recorder for MockTags.
+type MockTagsMockRecorder struct {
+	mock *MockTags
+}
+
+// NewMockTags creates a new mock instance.
+func NewMockTags(ctrl *gomock.Controller) *MockTags {
+	mock := &MockTags{ctrl: ctrl}
+	mock.recorder = &MockTagsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTags) EXPECT() *MockTagsMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockTags) Attach(ctx context.Context, userId, itemId, tagId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", ctx, userId, itemId, tagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockTagsMockRecorder) Attach(ctx, userId, itemId, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockTags)(nil).Attach), ctx, userId, itemId, tagId)
+}
+
+// Create mocks base method.
+func (m *MockTags) Create(ctx context.Context, userId int, tag domain.Tag) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, tag)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTagsMockRecorder) Create(ctx, userId, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTags)(nil).Create), ctx, userId, tag)
+}
+
+// Delete mocks base method.
+func (m *MockTags) Delete(ctx context.Context, userId, tagId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, tagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagsMockRecorder) Delete(ctx, userId, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTags)(nil).Delete), ctx, userId, tagId)
+}
+
+// Detach mocks base method.
+func (m *MockTags) Detach(ctx context.Context, userId, itemId, tagId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", ctx, userId, itemId, tagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockTagsMockRecorder) Detach(ctx, userId, itemId, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockTags)(nil).Detach), ctx, userId, itemId, tagId)
+}
+
+// GetById mocks base method.
+func (m *MockTags) GetById(ctx context.Context, userId, tagId int) (domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, userId, tagId)
+	ret0, _ := ret[0].(domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTagsMockRecorder) GetById(ctx, userId, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTags)(nil).GetById), ctx, userId, tagId)
+}
+
+// GetByUserId mocks base method.
+func (m *MockTags) GetByUserId(ctx context.Context, userId int) ([]domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", ctx, userId)
+	ret0, _ := ret[0].([]domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockTagsMockRecorder) GetByUserId(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockTags)(nil).GetByUserId), ctx, userId)
+}
+
+// Update mocks base method.
+func (m *MockTags) Update(ctx context.Context, userId, tagId int, input domain.UpdateTagInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userId, tagId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTagsMockRecorder) Update(ctx, userId, tagId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTags)(nil).Update), ctx, userId, tagId, input)
+}
+
+// Validate mocks base method.
+func (m *MockTags) Validate(tag domain.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockTagsMockRecorder) Validate(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockTags)(nil).Validate), tag)
+}
+
+// ValidateUpdate mocks base method.
+func (m *MockTags) ValidateUpdate(input domain.UpdateTagInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateUpdate", input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateUpdate indicates an expected call of ValidateUpdate.
+func (mr *MockTagsMockRecorder) ValidateUpdate(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUpdate", reflect.TypeOf((*MockTags)(nil).ValidateUpdate), input)
+}
+
+// MockReminders is a mock of Reminders interface.
+type MockReminders struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemindersMockRecorder
+}
+
+// MockRemindersMockRecorder is the mock recorder for MockReminders.
+type MockRemindersMockRecorder struct {
+	mock *MockReminders
+}
+
+// NewMockReminders creates a new mock instance.
+func NewMockReminders(ctrl *gomock.Controller) *MockReminders {
+	mock := &MockReminders{ctrl: ctrl}
+	mock.recorder = &MockRemindersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminders) EXPECT() *MockRemindersMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockReminders) Claim(ctx context.Context, now time.Time, limit int) ([]domain.ReminderDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.ReminderDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockRemindersMockRecorder) Claim(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockReminders)(nil).Claim), ctx, now, limit)
+}
+
+// Create mocks base method.
+func (m *MockReminders) Create(ctx context.Context, userId, itemId int, reminder domain.Reminder) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, itemId, reminder)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRemindersMockRecorder) Create(ctx, userId, itemId, reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReminders)(nil).Create), ctx, userId, itemId, reminder)
+}
+
+// Delete mocks base method.
+func (m *MockReminders) Delete(ctx context.Context, userId, reminderId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, reminderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRemindersMockRecorder) Delete(ctx, userId, reminderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReminders)(nil).Delete), ctx, userId, reminderId)
+}
+
+// GetByItemId mocks base method.
+func (m *MockReminders) GetByItemId(ctx context.Context, userId, itemId int) ([]domain.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByItemId", ctx, userId, itemId)
+	ret0, _ := ret[0].([]domain.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByItemId indicates an expected call of GetByItemId.
+func (mr *MockRemindersMockRecorder) GetByItemId(ctx, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByItemId", reflect.TypeOf((*MockReminders)(nil).GetByItemId), ctx, userId, itemId)
+}
+
+// Release mocks base method.
+func (m *MockReminders) Release(ctx context.Context, reminderId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, reminderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockRemindersMockRecorder) Release(ctx, reminderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockReminders)(nil).Release), ctx, reminderId)
+}
+
+// Snooze mocks base method.
+func (m *MockReminders) Snooze(ctx context.Context, userId, reminderId int, input domain.SnoozeReminderInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snooze", ctx, userId, reminderId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Snooze indicates an expected call of Snooze.
+func (mr *MockRemindersMockRecorder) Snooze(ctx, userId, reminderId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snooze", reflect.TypeOf((*MockReminders)(nil).Snooze), ctx, userId, reminderId, input)
+}
+
+// Validate mocks base method.
+func (m *MockReminders) Validate(reminder domain.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockRemindersMockRecorder) Validate(reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockReminders)(nil).Validate), reminder)
+}
+
+// MockComments is a mock of Comments interface.
+type MockComments struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentsMockRecorder
+}
+
+// MockCommentsMockRecorder is the mock recorder for MockComments.
+type MockCommentsMockRecorder struct {
+	mock *MockComments
+}
+
+// NewMockComments creates a new mock instance.
+func NewMockComments(ctrl *gomock.Controller) *MockComments {
+	mock := &MockComments{ctrl: ctrl}
+	mock.recorder = &MockCommentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComments) EXPECT() *MockCommentsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockComments) Create(ctx context.Context, userId, itemId int, comment domain.Comment) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, itemId, comment)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentsMockRecorder) Create(ctx, userId, itemId, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockComments)(nil).Create), ctx, userId, itemId, comment)
+}
+
+// Delete mocks base method.
+func (m *MockComments) Delete(ctx context.Context, userId, commentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, commentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentsMockRecorder) Delete(ctx, userId, commentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockComments)(nil).Delete), ctx, userId, commentId)
+}
+
+// GetByItemId mocks base method.
+func (m *MockComments) GetByItemId(ctx context.Context, userId, itemId int, page domain.Page) ([]domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByItemId", ctx, userId, itemId, page)
+	ret0, _ := ret[0].([]domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByItemId indicates an expected call of GetByItemId.
+func (mr *MockCommentsMockRecorder) GetByItemId(ctx, userId, itemId, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByItemId", reflect.TypeOf((*MockComments)(nil).GetByItemId), ctx, userId, itemId, page)
+}
+
+// Update mocks base method.
+func (m *MockComments) Update(ctx context.Context, userId, commentId int, input domain.UpdateCommentInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userId, commentId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentsMockRecorder) Update(ctx, userId, commentId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockComments)(nil).Update), ctx, userId, commentId, input)
+}
+
+// Validate mocks base method.
+func (m *MockComments) Validate(comment domain.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockCommentsMockRecorder) Validate(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockComments)(nil).Validate), comment)
+}
+
+// ValidateUpdate mocks base method.
+func (m *MockComments) ValidateUpdate(input domain.UpdateCommentInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateUpdate", input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateUpdate indicates an expected call of ValidateUpdate.
+func (mr *MockCommentsMockRecorder) ValidateUpdate(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUpdate", reflect.TypeOf((*MockComments)(nil).ValidateUpdate), input)
+}
+
+// MockAttachments is a mock of Attachments interface.
+type MockAttachments struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentsMockRecorder
+}
+
+// MockAttachmentsMockRecorder is the mock recorder for MockAttachments.
+type MockAttachmentsMockRecorder struct {
+	mock *MockAttachments
+}
+
+// NewMockAttachments creates a new mock instance.
+func NewMockAttachments(ctrl *gomock.Controller) *MockAttachments {
+	mock := &MockAttachments{ctrl: ctrl}
+	mock.recorder = &MockAttachmentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachments) EXPECT() *MockAttachmentsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachments) Create(ctx context.Context, userId, itemId int, upload domain.Upload) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, itemId, upload)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentsMockRecorder) Create(ctx, userId, itemId, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachments)(nil).Create), ctx, userId, itemId, upload)
+}
+
+// Delete mocks base method.
+func (m *MockAttachments) Delete(ctx context.Context, userId, attachmentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, attachmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentsMockRecorder) Delete(ctx, userId, attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachments)(nil).Delete), ctx, userId, attachmentId)
+}
+
+// GetByItemId mocks base method.
+func (m *MockAttachments) GetByItemId(ctx context.Context, userId, itemId int) ([]domain.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByItemId", ctx, userId, itemId)
+	ret0, _ := ret[0].([]domain.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByItemId indicates an expected call of GetByItemId.
+func (mr *MockAttachmentsMockRecorder) GetByItemId(ctx, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByItemId", reflect.TypeOf((*MockAttachments)(nil).GetByItemId), ctx, userId, itemId)
+}
+
+// GetURL mocks base method.
+func (m *MockAttachments) GetURL(ctx context.Context, userId, attachmentId int) (domain.AttachmentURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURL", ctx, userId, attachmentId)
+	ret0, _ := ret[0].(domain.AttachmentURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURL indicates an expected call of GetURL.
+func (mr *MockAttachmentsMockRecorder) GetURL(ctx, userId, attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURL", reflect.TypeOf((*MockAttachments)(nil).GetURL), ctx, userId, attachmentId)
+}
+
+// Open mocks base method.
+func (m *MockAttachments) Open(ctx context.Context, attachmentId int, expires, signature string) (domain.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, attachmentId, expires, signature)
+	ret0, _ := ret[0].(domain.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockAttachmentsMockRecorder) Open(ctx, attachmentId, expires, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockAttachments)(nil).Open), ctx, attachmentId, expires, signature)
+}
+
+// MockTimeEntries is a mock of TimeEntries interface.
+type MockTimeEntries struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeEntriesMockRecorder
+}
+
+// MockTimeEntriesMockRecorder is the mock recorder for MockTimeEntries.
+type MockTimeEntriesMockRecorder struct {
+	mock *MockTimeEntries
+}
+
+// NewMockTimeEntries creates a new mock instance.
+func NewMockTimeEntries(ctrl *gomock.Controller) *MockTimeEntries {
+	mock := &MockTimeEntries{ctrl: ctrl}
+	mock.recorder = &MockTimeEntriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeEntries) EXPECT() *MockTimeEntriesMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTimeEntries) Create(ctx context.Context, userId, itemId int, entry domain.TimeEntry) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, itemId, entry)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTimeEntriesMockRecorder) Create(ctx, userId, itemId, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTimeEntries)(nil).Create), ctx, userId, itemId, entry)
+}
+
+// Delete mocks base method.
+func (m *MockTimeEntries) Delete(ctx context.Context, userId, entryId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, entryId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTimeEntriesMockRecorder) Delete(ctx, userId, entryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTimeEntries)(nil).Delete), ctx, userId, entryId)
+}
+
+// GetByItemId mocks base method.
+func (m *MockTimeEntries) GetByItemId(ctx context.Context, userId, itemId int) ([]domain.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByItemId", ctx, userId, itemId)
+	ret0, _ := ret[0].([]domain.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByItemId indicates an expected call of GetByItemId.
+func (mr *MockTimeEntriesMockRecorder) GetByItemId(ctx, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByItemId", reflect.TypeOf((*MockTimeEntries)(nil).GetByItemId), ctx, userId, itemId)
+}
+
+// GetItemTotal mocks base method.
+func (m *MockTimeEntries) GetItemTotal(ctx context.Context, userId, itemId int) (domain.TimeTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemTotal", ctx, userId, itemId)
+	ret0, _ := ret[0].(domain.TimeTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemTotal indicates an expected call of GetItemTotal.
+func (mr *MockTimeEntriesMockRecorder) GetItemTotal(ctx, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemTotal", reflect.TypeOf((*MockTimeEntries)(nil).GetItemTotal), ctx, userId, itemId)
+}
+
+// GetListTotal mocks base method.
+func (m *MockTimeEntries) GetListTotal(ctx context.Context, userId, listId int) (domain.TimeTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListTotal", ctx, userId, listId)
+	ret0, _ := ret[0].(domain.TimeTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListTotal indicates an expected call of GetListTotal.
+func (mr *MockTimeEntriesMockRecorder) GetListTotal(ctx, userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListTotal", reflect.TypeOf((*MockTimeEntries)(nil).GetListTotal), ctx, userId, listId)
+}
+
+// GetReport mocks base method.
+func (m *MockTimeEntries) GetReport(ctx context.Context, userId int, filter domain.TimeReportFilter) ([]domain.TimeReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", ctx, userId, filter)
+	ret0, _ := ret[0].([]domain.TimeReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockTimeEntriesMockRecorder) GetReport(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockTimeEntries)(nil).GetReport), ctx, userId, filter)
+}
+
+// Start mocks base method.
+func (m *MockTimeEntries) Start(ctx context.Context, userId, itemId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, userId, itemId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockTimeEntriesMockRecorder) Start(ctx, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockTimeEntries)(nil).Start), ctx, userId, itemId)
+}
+
+// Stop mocks base method.
+func (m *MockTimeEntries) Stop(ctx context.Context, userId int) (domain.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", ctx, userId)
+	ret0, _ := ret[0].(domain.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockTimeEntriesMockRecorder) Stop(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockTimeEntries)(nil).Stop), ctx, userId)
+}
+
+// Update mocks base method.
+func (m *MockTimeEntries) Update(ctx context.Context, userId, entryId int, input domain.UpdateTimeEntryInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userId, entryId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTimeEntriesMockRecorder) Update(ctx, userId, entryId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTimeEntries)(nil).Update), ctx, userId, entryId, input)
+}
+
+// Validate mocks base method.
+func (m *MockTimeEntries) Validate(entry domain.TimeEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockTimeEntriesMockRecorder) Validate(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockTimeEntries)(nil).Validate), entry)
+}
+
+// ValidateUpdate mocks base method.
+func (m *MockTimeEntries) ValidateUpdate(input domain.UpdateTimeEntryInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateUpdate", input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateUpdate indicates an expected call of ValidateUpdate.
+func (mr *MockTimeEntriesMockRecorder) ValidateUpdate(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUpdate", reflect.TypeOf((*MockTimeEntries)(nil).ValidateUpdate), input)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/pkg/rank"
+)
+
+func validateMove(id int, input domain.MoveInput) error {
+
+	if input.AfterId != nil && *input.AfterId == id {
+		return domain.ErrInvalidMove
+	}
+
+	if input.BeforeId != nil && *input.BeforeId == id {
+		return domain.ErrInvalidMove
+	}
+
+	if input.AfterId != nil && input.BeforeId != nil && *input.AfterId == *input.BeforeId {
+		return domain.ErrInvalidMove
+	}
+
+	return nil
+}
+
+// moveError reports anchors given in the wrong order as an invalid move.
+func moveError(err error) error {
+
+	if errors.Is(err, rank.ErrInvalidPair) {
+		return domain.ErrInvalidMove
+	}
+
+	return err
+}
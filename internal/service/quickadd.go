@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/repository"
+	"github.com/andredubov/todo-backend/pkg/quickadd"
+	"gopkg.in/validator.v2"
+)
+
+type quickAddService struct {
+	users repository.Users
+	lists repository.TodoList
+	items TodoItem
+}
+
+func NewQuickAddService(users repository.Users, lists repository.TodoList, items TodoItem) *quickAddService {
+	return &quickAddService{
+		users: users,
+		lists: lists,
+		items: items,
+	}
+}
+
+func (s *quickAddService) Validate(input domain.QuickAddInput) error {
+
+	if err := validator.Validate(input); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Add reads the item out of the text, dates and times in the timezone of the
+// user, and creates it in the list unless it is a dry run. The item and its
+// tags are created in one transaction, see TodoItem.CreateTagged.
+func (s *quickAddService) Add(ctx context.Context, userId, listId int, input domain.QuickAddInput, dryRun bool) (domain.QuickAdd, error) {
+
+	if _, err := s.lists.GetById(ctx, userId, listId); err != nil {
+		return domain.QuickAdd{}, err
+	}
+
+	user, err := s.users.GetById(ctx, userId)
+	if err != nil {
+		return domain.QuickAdd{}, err
+	}
+
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return domain.QuickAdd{}, err
+	}
+
+	parsed := quickadd.Parse(input.Text, time.Now().In(location))
+
+	result := domain.QuickAdd{
+		Item: domain.TodoItem{
+			ListId:   listId,
+			Title:    parsed.Title,
+			DueDate:  domain.Date(parsed.DueDate),
+			DueTime:  domain.TimeOfDay(parsed.DueTime),
+			Priority: domain.Priority(parsed.Priority),
+			RRule:    parsed.RRule,
+		},
+		Recognised: make([]domain.QuickAddToken, 0, len(parsed.Tokens)),
+	}
+
+	for _, token := range parsed.Tokens {
+		result.Recognised = append(result.Recognised, domain.QuickAddToken{Text: token.Text, Kind: string(token.Kind), Value: token.Value})
+	}
+
+	for _, name := range parsed.Tags {
+		tag := domain.Tag{Name: name}
+		if err := validator.Validate(tag); err != nil {
+			return result, err
+		}
+
+		result.Item.Tags = append(result.Item.Tags, tag)
+	}
+
+	if err := s.items.Validate(result.Item); err != nil {
+		return result, err
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	itemId, tags, err := s.items.CreateTagged(ctx, userId, listId, result.Item)
+	if err != nil {
+		return result, err
+	}
+
+	result.Item.Id, result.Item.Tags = itemId, tags
+
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/repository"
+)
+
+type remindersService struct {
+	repo repository.Reminders
+}
+
+func NewRemindersService(repo repository.Reminders) *remindersService {
+	return &remindersService{
+		repo: repo,
+	}
+}
+
+// Validate accepts a reminder with either the time to fire at or
+// a non-negative number of minutes before the due time.
+func (s *remindersService) Validate(reminder domain.Reminder) error {
+
+	if (reminder.RemindAt == nil) == (reminder.MinutesBefore == nil) {
+		return domain.ErrInvalidReminder
+	}
+
+	if reminder.MinutesBefore != nil && *reminder.MinutesBefore < 0 {
+		return domain.ErrInvalidReminder
+	}
+
+	return nil
+}
+
+func (s *remindersService) Create(ctx context.Context, userId, itemId int, reminder domain.Reminder) (int, error) {
+	return s.repo.Create(ctx, userId, itemId, reminder)
+}
+
+func (s *remindersService) GetByItemId(ctx context.Context, userId, itemId int) ([]domain.Reminder, error) {
+	return s.repo.GetByItemId(ctx, userId, itemId)
+}
+
+func (s *remindersService) Delete(ctx context.Context, userId, reminderId int) error {
+	return s.repo.Delete(ctx, userId, reminderId)
+}
+
+// Snooze makes a fired reminder fire once again the given number of minutes from now.
+func (s *remindersService) Snooze(ctx context.Context, userId, reminderId int, input domain.SnoozeReminderInput) error {
+
+	if input.Minutes <= 0 {
+		return domain.ErrInvalidSnooze
+	}
+
+	return s.repo.Snooze(ctx, userId, reminderId, time.Now().Add(time.Duration(input.Minutes)*time.Minute))
+}
+
+func (s *remindersService) Claim(ctx context.Context, now time.Time, limit int) ([]domain.ReminderDelivery, error) {
+	return s.repo.Claim(ctx, now, limit)
+}
+
+func (s *remindersService) Release(ctx context.Context, reminderId int) error {
+	return s.repo.Release(ctx, reminderId)
+}
@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/andredubov/todo-backend/internal/config"
 	"github.com/andredubov/todo-backend/internal/domain"
 	"github.com/andredubov/todo-backend/internal/repository"
+	"github.com/andredubov/todo-backend/pkg/blob"
 	"github.com/andredubov/todo-backend/pkg/hash"
 )
 
@@ -13,37 +17,176 @@ import (
 type Users interface {
 	Create(ctx context.Context, user domain.User) (int, error)
 	GetByCredentials(ctx context.Context, credentials domain.Credentials) (domain.User, error)
+	SetTimezone(ctx context.Context, userId int, input domain.TimezoneInput) error
 	Validate(user domain.User) error
 }
 
 type TodoList interface {
 	Create(ctx context.Context, todolist domain.TodoList, userId int) (int, error)
-	GetByUserId(ctx context.Context, userId int) ([]domain.TodoList, error)
+	GetByUserId(ctx context.Context, userId int, page domain.CursorPage) ([]domain.TodoList, string, error)
 	GetById(ctx context.Context, userId, listId int) (domain.TodoList, error)
 	Delete(ctx context.Context, userId, listId int) error
 	Update(ctx context.Context, userId, listId int, input domain.UpdateTodoListInput) error
+	Move(ctx context.Context, userId, listId int, input domain.MoveInput) error
+	SetFolder(ctx context.Context, userId, listId int, input domain.TodoListFolderInput) error
+	Duplicate(ctx context.Context, userId, listId int, input domain.CopyTodoListInput) (int, error)
+	GetTemplates(ctx context.Context, userId int) ([]domain.TodoList, error)
+	Instantiate(ctx context.Context, userId, templateId int, input domain.CopyTodoListInput) (int, error)
+	Restore(ctx context.Context, userId, listId int) error
 	Validate(list domain.TodoList) error
+	ValidateUpdate(input domain.UpdateTodoListInput) error
 }
 
 type TodoItem interface {
 	Create(ctx context.Context, listId int, item domain.TodoItem) (int, error)
-	GetAll(ctx context.Context, userId, listId int) ([]domain.TodoItem, error)
+	CreateTagged(ctx context.Context, userId, listId int, item domain.TodoItem) (int, []domain.Tag, error)
+	GetAll(ctx context.Context, userId, listId int, filter domain.TodoItemFilter, page domain.CursorPage) ([]domain.TodoItem, string, error)
+	GetByUserId(ctx context.Context, userId int, filter domain.TodoItemFilter) ([]domain.TodoItem, error)
 	GetById(ctx context.Context, userId, itemId int) (domain.TodoItem, error)
 	Delete(ctx context.Context, userId, itemId int) error
 	Update(ctx context.Context, userId, itemId int, input domain.UpdateTodoItemInput) error
+	Snooze(ctx context.Context, userId, itemId int, input domain.SnoozeTodoItemInput) (time.Time, error)
+	SetTask(ctx context.Context, userId, itemId, index int, input domain.DescriptionTaskInput) error
+	Move(ctx context.Context, userId, itemId int, input domain.TransferTodoItemInput) error
+	Copy(ctx context.Context, userId, itemId int, input domain.TransferTodoItemInput) (int, error)
+	SetParent(ctx context.Context, userId, itemId int, input domain.TodoItemParentInput) error
+	Assign(ctx context.Context, userId, itemId int, input domain.TodoItemAssigneeInput) error
+	GetAssignments(ctx context.Context, userId, itemId int) ([]domain.Assignment, error)
+	AddBlocker(ctx context.Context, userId, itemId, blockerId int) error
+	RemoveBlocker(ctx context.Context, userId, itemId, blockerId int) error
+	GetBlockers(ctx context.Context, userId, itemId int) ([]domain.TodoItem, error)
+	GetRevisions(ctx context.Context, userId, itemId int) ([]domain.Revision, error)
+	Revert(ctx context.Context, userId, itemId, revisionId int) error
+	Restore(ctx context.Context, userId, itemId int) error
 	Validate(item domain.TodoItem) error
+	ValidateUpdate(input domain.UpdateTodoItemInput) error
+}
+
+type QuickAdd interface {
+	Add(ctx context.Context, userId, listId int, input domain.QuickAddInput, dryRun bool) (domain.QuickAdd, error)
+	Validate(input domain.QuickAddInput) error
+}
+
+type Members interface {
+	GetByListId(ctx context.Context, userId, listId int) ([]domain.Member, error)
+	Add(ctx context.Context, userId, listId int, input domain.ShareTodoListInput) (int, error)
+	Remove(ctx context.Context, userId, listId, memberId int) error
+	Validate(input domain.ShareTodoListInput) error
+}
+
+type Statuses interface {
+	Create(ctx context.Context, userId, listId int, status domain.Status) (int, error)
+	GetByListId(ctx context.Context, userId, listId int) ([]domain.Status, error)
+	Update(ctx context.Context, userId, statusId int, input domain.UpdateStatusInput) error
+	Move(ctx context.Context, userId, statusId int, input domain.MoveInput) error
+	Delete(ctx context.Context, userId, statusId int) error
+	SetItemStatus(ctx context.Context, userId, itemId int, input domain.TodoItemStatusInput) error
+	GetBoard(ctx context.Context, userId, listId int) (domain.Board, error)
+	Validate(status domain.Status) error
+	ValidateUpdate(input domain.UpdateStatusInput) error
+}
+
+type Folders interface {
+	Create(ctx context.Context, userId int, folder domain.Folder) (int, error)
+	GetByUserId(ctx context.Context, userId int) ([]domain.Folder, error)
+	GetById(ctx context.Context, userId, folderId int) (domain.Folder, error)
+	GetTree(ctx context.Context, userId int) (domain.FolderTree, error)
+	Update(ctx context.Context, userId, folderId int, input domain.UpdateFolderInput) error
+	Delete(ctx context.Context, userId, folderId int) error
+	Move(ctx context.Context, userId, folderId int, input domain.MoveFolderInput) error
+	Validate(folder domain.Folder) error
+}
+
+type Trash interface {
+	Get(ctx context.Context, userId int) (domain.Trash, error)
+	Purge(ctx context.Context, before time.Time) (int64, []string, error)
+}
+
+type Tags interface {
+	Create(ctx context.Context, userId int, tag domain.Tag) (int, error)
+	GetByUserId(ctx context.Context, userId int) ([]domain.Tag, error)
+	GetById(ctx context.Context, userId, tagId int) (domain.Tag, error)
+	Update(ctx context.Context, userId, tagId int, input domain.UpdateTagInput) error
+	Delete(ctx context.Context, userId, tagId int) error
+	Attach(ctx context.Context, userId, itemId, tagId int) error
+	Detach(ctx context.Context, userId, itemId, tagId int) error
+	Validate(tag domain.Tag) error
+	ValidateUpdate(input domain.UpdateTagInput) error
+}
+
+type Reminders interface {
+	Create(ctx context.Context, userId, itemId int, reminder domain.Reminder) (int, error)
+	GetByItemId(ctx context.Context, userId, itemId int) ([]domain.Reminder, error)
+	Delete(ctx context.Context, userId, reminderId int) error
+	Snooze(ctx context.Context, userId, reminderId int, input domain.SnoozeReminderInput) error
+	Claim(ctx context.Context, now time.Time, limit int) ([]domain.ReminderDelivery, error)
+	Release(ctx context.Context, reminderId int) error
+	Validate(reminder domain.Reminder) error
+}
+
+type Comments interface {
+	Create(ctx context.Context, userId, itemId int, comment domain.Comment) (int, error)
+	GetByItemId(ctx context.Context, userId, itemId int, page domain.Page) ([]domain.Comment, error)
+	Update(ctx context.Context, userId, commentId int, input domain.UpdateCommentInput) error
+	Delete(ctx context.Context, userId, commentId int) error
+	Validate(comment domain.Comment) error
+	ValidateUpdate(input domain.UpdateCommentInput) error
+}
+
+type Attachments interface {
+	Create(ctx context.Context, userId, itemId int, upload domain.Upload) (int, error)
+	GetByItemId(ctx context.Context, userId, itemId int) ([]domain.Attachment, error)
+	GetURL(ctx context.Context, userId, attachmentId int) (domain.AttachmentURL, error)
+	Open(ctx context.Context, attachmentId int, expires, signature string) (domain.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, userId, attachmentId int) error
+}
+
+type TimeEntries interface {
+	Start(ctx context.Context, userId, itemId int) (int, error)
+	Stop(ctx context.Context, userId int) (domain.TimeEntry, error)
+	Create(ctx context.Context, userId, itemId int, entry domain.TimeEntry) (int, error)
+	GetByItemId(ctx context.Context, userId, itemId int) ([]domain.TimeEntry, error)
+	Update(ctx context.Context, userId, entryId int, input domain.UpdateTimeEntryInput) error
+	Delete(ctx context.Context, userId, entryId int) error
+	GetItemTotal(ctx context.Context, userId, itemId int) (domain.TimeTotal, error)
+	GetListTotal(ctx context.Context, userId, listId int) (domain.TimeTotal, error)
+	GetReport(ctx context.Context, userId int, filter domain.TimeReportFilter) ([]domain.TimeReportRow, error)
+	Validate(entry domain.TimeEntry) error
+	ValidateUpdate(input domain.UpdateTimeEntryInput) error
 }
 
 type Service struct {
 	Users
 	TodoList
 	TodoItem
+	QuickAdd
+	Members
+	Statuses
+	Folders
+	Trash
+	Reminders
+	Tags
+	Comments
+	Attachments
+	TimeEntries
 }
 
-func New(repo *repository.Repository, hasher hash.PasswordHasher) *Service {
+func New(repo *repository.Repository, hasher hash.PasswordHasher, store blob.BlobStore, cfg config.Config) *Service {
+	todoItems := NewTodoItemService(repo.TodoItem, cfg.Subtasks, cfg.Dependencies)
+
 	return &Service{
-		Users:    NewUsersService(repo.Users, hasher),
-		TodoList: NewTodoListService(repo.TodoList),
-		TodoItem: NewTodoItemService(repo.TodoItem),
+		Users:       NewUsersService(repo.Users, hasher),
+		TodoList:    NewTodoListService(repo.TodoList),
+		TodoItem:    todoItems,
+		QuickAdd:    NewQuickAddService(repo.Users, repo.TodoList, todoItems),
+		Members:     NewMembersService(repo.Members),
+		Statuses:    NewStatusesService(repo.Statuses, repo.TodoItem, cfg.Subtasks, cfg.Dependencies),
+		Folders:     NewFoldersService(repo.Folders, repo.TodoList),
+		Trash:       NewTrashService(repo.TodoList, repo.TodoItem),
+		Reminders:   NewRemindersService(repo.Reminders),
+		Tags:        NewTagsService(repo.Tags),
+		Comments:    NewCommentsService(repo.Comments),
+		Attachments: NewAttachmentsService(repo.Attachments, store, cfg.Attachments),
+		TimeEntries: NewTimeEntriesService(repo.TimeEntries),
 	}
 }
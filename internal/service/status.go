@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+
+	"github.com/andredubov/todo-backend/internal/config"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/repository"
+	"gopkg.in/validator.v2"
+)
+
+type statusesService struct {
+	repo         repository.Statuses
+	items        repository.TodoItem
+	subtasks     config.SubtasksConfig
+	dependencies config.DependenciesConfig
+}
+
+func NewStatusesService(repo repository.Statuses, items repository.TodoItem, subtasks config.SubtasksConfig, dependencies config.DependenciesConfig) *statusesService {
+	return &statusesService{
+		repo:         repo,
+		items:        items,
+		subtasks:     subtasks,
+		dependencies: dependencies,
+	}
+}
+
+func (s *statusesService) Validate(status domain.Status) error {
+
+	if err := validator.Validate(status); err != nil {
+		return err
+	}
+
+	if status.WipLimit != nil && *status.WipLimit <= 0 {
+		return domain.ErrInvalidWipLimit
+	}
+
+	return nil
+}
+
+// ValidateUpdate checks the changes of the status, a zero WIP limit removes the limit.
+func (s *statusesService) ValidateUpdate(input domain.UpdateStatusInput) error {
+
+	if err := validator.Validate(input); err != nil {
+		return err
+	}
+
+	if input.WipLimit != nil && *input.WipLimit < 0 {
+		return domain.ErrInvalidWipLimit
+	}
+
+	return nil
+}
+
+func (s *statusesService) Create(ctx context.Context, userId, listId int, status domain.Status) (int, error) {
+	return s.repo.Create(ctx, userId, listId, status)
+}
+
+func (s *statusesService) GetByListId(ctx context.Context, userId, listId int) ([]domain.Status, error) {
+	return s.repo.GetByListId(ctx, userId, listId)
+}
+
+// Update changes the status. Making the status done completes its items the way
+// moving them into a done status does, so it is refused while one of them is
+// blocked by open items when the dependencies are configured to block the completion.
+func (s *statusesService) Update(ctx context.Context, userId, statusId int, input domain.UpdateStatusInput) error {
+
+	rollUp := domain.CompletionRollUp{
+		Children: s.subtasks.CompleteChildren,
+		Parent:   s.subtasks.CompleteParent,
+	}
+
+	return s.repo.Update(ctx, userId, statusId, input, rollUp, s.dependencies.BlockCompletion)
+}
+
+func (s *statusesService) Move(ctx context.Context, userId, statusId int, input domain.MoveInput) error {
+
+	if err := validateMove(statusId, input); err != nil {
+		return err
+	}
+
+	return moveError(s.repo.Move(ctx, userId, statusId, input))
+}
+
+func (s *statusesService) Delete(ctx context.Context, userId, statusId int) error {
+	return s.repo.Delete(ctx, userId, statusId)
+}
+
+// SetItemStatus moves the item into the status. Moving the item into a done
+// status completes it, so an item blocked by open items can not be moved there
+// when the dependencies are configured to block the completion.
+func (s *statusesService) SetItemStatus(ctx context.Context, userId, itemId int, input domain.TodoItemStatusInput) error {
+
+	if s.dependencies.BlockCompletion {
+		status, err := s.repo.GetById(ctx, userId, input.StatusId)
+		if err != nil {
+			return err
+		}
+
+		if status.Done {
+			blockers, err := s.items.GetBlockers(ctx, userId, itemId)
+			if err != nil {
+				return err
+			}
+
+			for _, blocker := range blockers {
+				if !blocker.Done {
+					return domain.ErrItemBlocked
+				}
+			}
+		}
+	}
+
+	rollUp := domain.CompletionRollUp{
+		Children: s.subtasks.CompleteChildren,
+		Parent:   s.subtasks.CompleteParent,
+	}
+
+	return s.repo.SetItemStatus(ctx, userId, itemId, input.StatusId, rollUp, s.dependencies.BlockCompletion)
+}
+
+// GetBoard groups the items of the list by their status in the order of the
+// statuses, the items keep the order of the list within a column. Deferred
+// items are shown as well, every item of the list belongs to some column.
+func (s *statusesService) GetBoard(ctx context.Context, userId, listId int) (domain.Board, error) {
+
+	statuses, err := s.repo.GetByListId(ctx, userId, listId)
+	if err != nil {
+		return domain.Board{}, err
+	}
+
+	items, err := s.items.GetAll(ctx, userId, listId, domain.TodoItemFilter{IncludeDeferred: true}, nil, 0)
+	if err != nil {
+		return domain.Board{}, err
+	}
+
+	board := domain.Board{Columns: make([]domain.BoardColumn, len(statuses))}
+
+	columns := make(map[int]int, len(statuses))
+	for i, status := range statuses {
+		board.Columns[i] = domain.BoardColumn{Status: status, Items: make([]domain.TodoItem, 0)}
+		columns[status.Id] = i
+	}
+
+	for _, item := range items {
+		if item.StatusId != nil {
+			if i, ok := columns[*item.StatusId]; ok {
+				board.Columns[i].Items = append(board.Columns[i].Items, item)
+				continue
+			}
+		}
+		board.Unsorted = append(board.Unsorted, item)
+	}
+
+	return board, nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/repository"
+	"gopkg.in/validator.v2"
+)
+
+type tagsService struct {
+	repo repository.Tags
+}
+
+func NewTagsService(repo repository.Tags) *tagsService {
+	return &tagsService{
+		repo: repo,
+	}
+}
+
+func (s *tagsService) Validate(tag domain.Tag) error {
+
+	if err := validator.Validate(tag); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *tagsService) ValidateUpdate(input domain.UpdateTagInput) error {
+
+	if err := validator.Validate(input); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *tagsService) Create(ctx context.Context, userId int, tag domain.Tag) (int, error) {
+	return s.repo.Create(ctx, userId, tag)
+}
+
+func (s *tagsService) GetByUserId(ctx context.Context, userId int) ([]domain.Tag, error) {
+	return s.repo.GetByUserId(ctx, userId)
+}
+
+func (s *tagsService) GetById(ctx context.Context, userId, tagId int) (domain.Tag, error) {
+	return s.repo.GetById(ctx, userId, tagId)
+}
+
+func (s *tagsService) Update(ctx context.Context, userId, tagId int, input domain.UpdateTagInput) error {
+	return s.repo.Update(ctx, userId, tagId, input)
+}
+
+func (s *tagsService) Delete(ctx context.Context, userId, tagId int) error {
+	return s.repo.Delete(ctx, userId, tagId)
+}
+
+func (s *tagsService) Attach(ctx context.Context, userId, itemId, tagId int) error {
+	return s.repo.Attach(ctx, userId, itemId, tagId)
+}
+
+func (s *tagsService) Detach(ctx context.Context, userId, itemId, tagId int) error {
+	return s.repo.Detach(ctx, userId, itemId, tagId)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/repository"
+)
+
+type timeEntriesService struct {
+	repo repository.TimeEntries
+}
+
+func NewTimeEntriesService(repo repository.TimeEntries) *timeEntriesService {
+	return &timeEntriesService{
+		repo: repo,
+	}
+}
+
+// Validate checks an entry added by hand, it has to end after it starts.
+func (s *timeEntriesService) Validate(entry domain.TimeEntry) error {
+
+	if entry.StartedAt.IsZero() || entry.EndedAt == nil || !entry.EndedAt.After(entry.StartedAt) {
+		return domain.ErrInvalidTimeEntry
+	}
+
+	return nil
+}
+
+// ValidateUpdate checks the new bounds of an entry when both of them are given,
+// the database checks a bound moved on its own against the other one.
+func (s *timeEntriesService) ValidateUpdate(input domain.UpdateTimeEntryInput) error {
+
+	if input.StartedAt != nil && input.EndedAt != nil && !input.EndedAt.After(*input.StartedAt) {
+		return domain.ErrInvalidTimeEntry
+	}
+
+	return nil
+}
+
+func (s *timeEntriesService) Start(ctx context.Context, userId, itemId int) (int, error) {
+	return s.repo.Start(ctx, userId, itemId)
+}
+
+func (s *timeEntriesService) Stop(ctx context.Context, userId int) (domain.TimeEntry, error) {
+	return s.repo.Stop(ctx, userId)
+}
+
+func (s *timeEntriesService) Create(ctx context.Context, userId, itemId int, entry domain.TimeEntry) (int, error) {
+	return s.repo.Create(ctx, userId, itemId, entry)
+}
+
+func (s *timeEntriesService) GetByItemId(ctx context.Context, userId, itemId int) ([]domain.TimeEntry, error) {
+	return s.repo.GetByItemId(ctx, userId, itemId)
+}
+
+func (s *timeEntriesService) Update(ctx context.Context, userId, entryId int, input domain.UpdateTimeEntryInput) error {
+	return s.repo.Update(ctx, userId, entryId, input)
+}
+
+func (s *timeEntriesService) Delete(ctx context.Context, userId, entryId int) error {
+	return s.repo.Delete(ctx, userId, entryId)
+}
+
+func (s *timeEntriesService) GetItemTotal(ctx context.Context, userId, itemId int) (domain.TimeTotal, error) {
+	return s.repo.GetItemTotal(ctx, userId, itemId)
+}
+
+func (s *timeEntriesService) GetListTotal(ctx context.Context, userId, listId int) (domain.TimeTotal, error) {
+	return s.repo.GetListTotal(ctx, userId, listId)
+}
+
+// GetReport needs both ends of the range, the range may cover a single day.
+func (s *timeEntriesService) GetReport(ctx context.Context, userId int, filter domain.TimeReportFilter) ([]domain.TimeReportRow, error) {
+
+	if filter.From == "" || filter.To == "" {
+		return nil, domain.ErrInvalidTimeReport
+	}
+
+	if err := validateDue(filter.From, ""); err != nil {
+		return nil, err
+	}
+
+	if err := validateDue(filter.To, ""); err != nil {
+		return nil, err
+	}
+
+	if filter.From > filter.To {
+		return nil, domain.ErrInvalidTimeReport
+	}
+
+	return s.repo.GetReport(ctx, userId, filter)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/repository"
+)
+
+type trashService struct {
+	listRepo repository.TodoList
+	itemRepo repository.TodoItem
+}
+
+func NewTrashService(listRepo repository.TodoList, itemRepo repository.TodoItem) *trashService {
+	return &trashService{
+		listRepo: listRepo,
+		itemRepo: itemRepo,
+	}
+}
+
+func (s *trashService) Get(ctx context.Context, userId int) (domain.Trash, error) {
+
+	lists, err := s.listRepo.GetDeleted(ctx, userId)
+	if err != nil {
+		return domain.Trash{}, err
+	}
+
+	items, err := s.itemRepo.GetDeleted(ctx, userId)
+	if err != nil {
+		return domain.Trash{}, err
+	}
+
+	return domain.Trash{Lists: lists, Items: items}, nil
+}
+
+// Purge permanently removes everything trashed before the given time and returns
+// the number of removed lists and items and the storage keys of their attached files.
+func (s *trashService) Purge(ctx context.Context, before time.Time) (int64, []string, error) {
+
+	// items go first: they are only reachable through the lists they belong to
+	items, itemKeys, err := s.itemRepo.Purge(ctx, before)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	lists, listKeys, err := s.listRepo.Purge(ctx, before)
+	if err != nil {
+		return items, itemKeys, err
+	}
+
+	return items + lists, append(itemKeys, listKeys...), nil
+}
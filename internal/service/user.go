@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"net/mail"
+	"time"
 
 	"github.com/andredubov/todo-backend/internal/domain"
 	"github.com/andredubov/todo-backend/internal/repository"
@@ -10,6 +11,9 @@ import (
 	"gopkg.in/validator.v2"
 )
 
+// defaultTimezone is used for the users who did not tell their timezone.
+const defaultTimezone = "UTC"
+
 type UsersService struct {
 	repo           repository.Users
 	passwordHasher hash.PasswordHasher
@@ -32,6 +36,12 @@ func (s *UsersService) Validate(user domain.User) error {
 		return err
 	}
 
+	if user.Timezone != "" {
+		if err := validateTimezone(user.Timezone); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -44,6 +54,10 @@ func (s *UsersService) Create(ctx context.Context, user domain.User) (int, error
 
 	user.Password = hash
 
+	if user.Timezone == "" {
+		user.Timezone = defaultTimezone
+	}
+
 	return s.repo.Create(ctx, user)
 }
 
@@ -56,3 +70,27 @@ func (s *UsersService) GetByCredentials(ctx context.Context, credentials domain.
 
 	return s.repo.GetByCredentials(ctx, credentials.Email, hash)
 }
+
+func (s *UsersService) SetTimezone(ctx context.Context, userId int, input domain.TimezoneInput) error {
+
+	if err := validateTimezone(input.Timezone); err != nil {
+		return err
+	}
+
+	return s.repo.SetTimezone(ctx, userId, input.Timezone)
+}
+
+// validateTimezone accepts IANA timezone names only, the names Go reserves
+// for itself are unknown to the database.
+func validateTimezone(timezone string) error {
+
+	if timezone == "" || timezone == "Local" {
+		return domain.ErrInvalidTimezone
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return domain.ErrInvalidTimezone
+	}
+
+	return nil
+}
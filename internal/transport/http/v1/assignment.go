@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// @Summary Set assignee of todo-item
+// @Security ApiKeyAuth
+// @Tags items
+// @Description assign todo-item to a member of its todo-list, without assignee_id the item is unassigned
+// @ID set-item-assignee
+// @Accept json
+// @Produce json
+// @Param input body domain.TodoItemAssigneeInput true "assignee"
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/assignee [put]
+func (h *Handler) setItemAssignee(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	var assigneeInput domain.TodoItemAssigneeInput
+	if err := json.NewDecoder(r.Body).Decode(&assigneeInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TodoItem.Assign(ctx, userId, itemId, assigneeInput); err != nil {
+		if errors.Is(err, domain.ErrInvalidAssignee) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to assign a todo-item"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get Assignments
+// @Security ApiKeyAuth
+// @Tags items
+// @Description get the history of the assignees of todo-item, the oldest change first
+// @ID get-item-assignments
+// @Accept json
+// @Produce json
+// @Success 200 {object} GetAssignmentsResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/assignments [get]
+func (h *Handler) getAssignments(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	assignments, err := h.services.TodoItem.GetAssignments(ctx, userId, itemId)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to find the assignments of a todo-item"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(GetAssignmentsResponse{Data: assignments}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
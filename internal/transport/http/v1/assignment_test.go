@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_setItemAssignee(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+		input  domain.TodoItemAssigneeInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTodoItem, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"assignee_id": 3}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemAssigneeInput{AssigneeId: intPointer(3)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Assign(gomock.Any(), args.userId, args.itemId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "OK_Unassign",
+			inputRequestBody: `{"assignee_id": null}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemAssigneeInput{},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Assign(gomock.Any(), args.userId, args.itemId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Not A Member",
+			inputRequestBody: `{"assignee_id": 9}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemAssigneeInput{AssigneeId: intPointer(9)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Assign(gomock.Any(), args.userId, args.itemId, args.input).Return(domain.ErrInvalidAssignee)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the assignee has to be a member of the list\"}",
+		},
+		{
+			name:             "Invalid JSON",
+			inputRequestBody: `{"assignee_id": "bob"}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: json: cannot unmarshal string into Go struct field TodoItemAssigneeInput.assignee_id of type int\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{"assignee_id": 3}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemAssigneeInput{AssigneeId: intPointer(3)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Assign(gomock.Any(), args.userId, args.itemId, args.input).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to assign a todo-item: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(todoItem, test.input)
+
+			w := serveRequest(t, &service.Service{TodoItem: todoItem}, http.MethodPut, "/api/items/{id:[0-9]+}/assignee", (*Handler).setItemAssignee,
+				fmt.Sprintf("/api/items/%d/assignee", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getAssignments(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTodoItem, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetAssignments(gomock.Any(), args.userId, args.itemId).Return([]domain.Assignment{
+					{Id: 1, ItemId: 2, AssigneeId: intPointer(3), AssigneeName: stringPointer("Bob"), AssignedById: intPointer(1), AssignedByName: stringPointer("Alice"),
+						AssignedAt: time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)},
+					{Id: 2, ItemId: 2, AssignedById: intPointer(1), AssignedByName: stringPointer("Alice"), AssignedAt: time.Date(2024, time.March, 2, 9, 0, 0, 0, time.UTC)},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":1,\"item_id\":2,\"assignee_id\":3,\"assignee_name\":\"Bob\",\"assigned_by_id\":1,\"assigned_by_name\":\"Alice\",\"assigned_at\":\"2024-03-01T09:00:00Z\"},{\"id\":2,\"item_id\":2,\"assigned_by_id\":1,\"assigned_by_name\":\"Alice\",\"assigned_at\":\"2024-03-02T09:00:00Z\"}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetAssignments(gomock.Any(), args.userId, args.itemId).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find the assignments of a todo-item: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(todoItem, test.input)
+
+			w := serveRequest(t, &service.Service{TodoItem: todoItem}, http.MethodGet, "/api/items/{id:[0-9]+}/assignments", (*Handler).getAssignments,
+				fmt.Sprintf("/api/items/%d/assignments", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
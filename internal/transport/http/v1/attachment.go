@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+const (
+	// multipartMemory is the part of an upload kept in memory, the rest goes to a temporary file.
+	multipartMemory = 1 << 20
+	// multipartOverhead is the room left for the form around the file.
+	multipartOverhead = 1 << 20
+	// transferTimeout bounds moving a file to or from the blob store.
+	transferTimeout = 5 * time.Minute
+)
+
+// limitBody rejects the requests with a body larger than a file of the given size in a multipart form.
+func (h *Handler) limitBody(maxSize int64, next http.HandlerFunc) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize+multipartOverhead)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// @Summary Create attachment
+// @Security ApiKeyAuth
+// @Tags attachments
+// @Description attach a file to the item, the content type is detected from the content of the file
+// @ID create-attachment
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "file to attach"
+// @Success 200 {object} domain.Attachment
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/attachments [post]
+func (h *Handler) createAttachment(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert an item id"))
+		return
+	}
+
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not a valid multipart form"))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the form has no file"))
+		return
+	}
+	defer file.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), transferTimeout)
+	defer cancel()
+
+	attachmentId, err := h.services.Attachments.Create(ctx, userId, itemId, domain.Upload{Name: header.Filename, Size: header.Size, Content: file})
+	if err != nil {
+		if isAttachmentError(err) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to create an attachment"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(domain.Attachment{Id: attachmentId}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+func isAttachmentError(err error) bool {
+	return errors.Is(err, domain.ErrAttachmentTooLarge) || errors.Is(err, domain.ErrAttachmentType) || errors.Is(err, domain.ErrStorageQuota)
+}
+
+// @Summary Get Attachments
+// @Security ApiKeyAuth
+// @Tags attachments
+// @Description get the attachments of the item
+// @ID get-attachments
+// @Accept json
+// @Produce json
+// @Success 200 {object} GetAttachmentsResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/attachments [get]
+func (h *Handler) getAttachments(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert an item id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	attachments, err := h.services.Attachments.GetByItemId(ctx, userId, itemId)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to find any attachments by item id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(GetAttachmentsResponse{Data: attachments}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get attachment download link
+// @Security ApiKeyAuth
+// @Tags attachments
+// @Description get a signed link to download the attachment by without signing in, the link expires after a while
+// @ID get-attachment-url
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.AttachmentURL
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/attachments/:id/url [get]
+func (h *Handler) getAttachmentURL(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	attachmentId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert an attachment id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	attachmentURL, err := h.services.Attachments.GetURL(ctx, userId, attachmentId)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to sign a download link"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(attachmentURL); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Download attachment
+// @Tags attachments
+// @Description download the attachment by a signed link
+// @ID download-attachment
+// @Produce octet-stream
+// @Param expires query int true "expiry of the link, unix time"
+// @Param signature query string true "signature of the link"
+// @Success 200 {file} file
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/attachments/:id/download [get]
+func (h *Handler) downloadAttachment(w http.ResponseWriter, r *http.Request) {
+
+	vars, query := mux.Vars(r), r.URL.Query()
+
+	attachmentId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert an attachment id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), transferTimeout)
+	defer cancel()
+
+	attachment, content, err := h.services.Attachments.Open(ctx, attachmentId, query.Get("expires"), query.Get("signature"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidDownloadLink) {
+			h.writeResponseWithError(w, http.StatusForbidden, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to open an attachment"))
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	io.Copy(w, content)
+}
+
+// @Summary Delete attachment by Id
+// @Security ApiKeyAuth
+// @Tags attachments
+// @Description delete attachment by id together with its file
+// @ID delete-attachment-by-id
+// @Accept json
+// @Produce json
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/attachments/:id [delete]
+func (h *Handler) deleteAttachmentByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	attachmentId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert an attachment id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), transferTimeout)
+	defer cancel()
+
+	if err := h.services.Attachments.Delete(ctx, userId, attachmentId); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to delete an attachment by id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
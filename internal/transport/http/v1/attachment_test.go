@@ -0,0 +1,444 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+// uploadForm and fieldForm are multipart forms with and without a file.
+const (
+	uploadForm = "--boundary\r\n" +
+		"Content-Disposition: form-data; name=\"file\"; filename=\"notes.txt\"\r\n" +
+		"Content-Type: text/plain\r\n\r\n" +
+		"hello\r\n" +
+		"--boundary--\r\n"
+	fieldForm = "--boundary\r\n" +
+		"Content-Disposition: form-data; name=\"title\"\r\n\r\n" +
+		"hello\r\n" +
+		"--boundary--\r\n"
+)
+
+func TestHandler_createAttachment(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+		upload domain.Upload
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockAttachments, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: uploadForm,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				upload: domain.Upload{Name: "notes.txt", Size: 5},
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userId, itemId int, upload domain.Upload) (int, error) {
+						content, _ := io.ReadAll(upload.Content)
+						assert.Equal(t, args.upload, domain.Upload{Name: upload.Name, Size: upload.Size})
+						assert.Equal(t, "hello", string(content))
+						return 3, nil
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":3}\n",
+		},
+		{
+			name:             "Quota Exceeded",
+			inputRequestBody: uploadForm,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				upload: domain.Upload{Name: "notes.txt", Size: 5},
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userId, itemId int, upload domain.Upload) (int, error) {
+						content, _ := io.ReadAll(upload.Content)
+						assert.Equal(t, args.upload, domain.Upload{Name: upload.Name, Size: upload.Size})
+						assert.Equal(t, "hello", string(content))
+						return 0, domain.ErrStorageQuota
+					})
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the storage quota is exceeded\"}",
+		},
+		{
+			name:             "Unsupported Type",
+			inputRequestBody: uploadForm,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				upload: domain.Upload{Name: "notes.txt", Size: 5},
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userId, itemId int, upload domain.Upload) (int, error) {
+						content, _ := io.ReadAll(upload.Content)
+						assert.Equal(t, args.upload, domain.Upload{Name: upload.Name, Size: upload.Size})
+						assert.Equal(t, "hello", string(content))
+						return 0, domain.ErrAttachmentType
+					})
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"files of this type can not be attached\"}",
+		},
+		{
+			name:             "No File",
+			inputRequestBody: fieldForm,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the form has no file: http: no such file\"}",
+		},
+		{
+			name:             "Not A Form",
+			inputRequestBody: `hello`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not a valid multipart form: multipart: NextPart: EOF\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: uploadForm,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				upload: domain.Upload{Name: "notes.txt", Size: 5},
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userId, itemId int, upload domain.Upload) (int, error) {
+						content, _ := io.ReadAll(upload.Content)
+						assert.Equal(t, args.upload, domain.Upload{Name: upload.Name, Size: upload.Size})
+						assert.Equal(t, "hello", string(content))
+						return 0, errors.New("service failure")
+					})
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to create an attachment: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			attachments := mock_service.NewMockAttachments(controller)
+			test.mockBehavior(attachments, test.input)
+
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/items/%d/attachments", test.input.itemId), bytes.NewBufferString(test.inputRequestBody))
+			r.Header.Set("Content-Type", "multipart/form-data; boundary=boundary")
+
+			w := serveUserRequest(t, &service.Service{Attachments: attachments}, "/api/items/{id:[0-9]+}/attachments", (*Handler).createAttachment, r, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getAttachments(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockAttachments, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().GetByItemId(gomock.Any(), args.userId, args.itemId).Return([]domain.Attachment{
+					{Id: 3, ItemId: 2, UploaderId: 1, Name: "receipt.pdf", ContentType: "application/pdf", Size: 300, StorageKey: "a1b2"},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":3,\"item_id\":2,\"uploader_id\":1,\"name\":\"receipt.pdf\",\"content_type\":\"application/pdf\",\"size\":300}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().GetByItemId(gomock.Any(), args.userId, args.itemId).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find any attachments by item id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			attachments := mock_service.NewMockAttachments(controller)
+			test.mockBehavior(attachments, test.input)
+
+			w := serveRequest(t, &service.Service{Attachments: attachments}, http.MethodGet, "/api/items/{id:[0-9]+}/attachments", (*Handler).getAttachments,
+				fmt.Sprintf("/api/items/%d/attachments", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getAttachmentURL(t *testing.T) {
+	type args struct {
+		userId       int
+		attachmentId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockAttachments, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:       1,
+				attachmentId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().GetURL(gomock.Any(), args.userId, args.attachmentId).Return(domain.AttachmentURL{
+					URL:       "/api/attachments/3/download?expires=1774775700&signature=ab12",
+					ExpiresAt: time.Date(2026, time.March, 29, 9, 15, 0, 0, time.UTC),
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"url\":\"/api/attachments/3/download?expires=1774775700\\u0026signature=ab12\",\"expires_at\":\"2026-03-29T09:15:00Z\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId:       1,
+				attachmentId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().GetURL(gomock.Any(), args.userId, args.attachmentId).Return(domain.AttachmentURL{}, sql.ErrNoRows)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to sign a download link: sql: no rows in result set\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			attachments := mock_service.NewMockAttachments(controller)
+			test.mockBehavior(attachments, test.input)
+
+			w := serveRequest(t, &service.Service{Attachments: attachments}, http.MethodGet, "/api/attachments/{id:[0-9]+}/url", (*Handler).getAttachmentURL,
+				fmt.Sprintf("/api/attachments/%d/url", test.input.attachmentId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_downloadAttachment(t *testing.T) {
+	type args struct {
+		attachmentId int
+		query        string
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockAttachments, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				attachmentId: 3,
+				query:        "?expires=1774775700&signature=ab12",
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().Open(gomock.Any(), args.attachmentId, "1774775700", "ab12").Return(
+					domain.Attachment{Id: 3, Name: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 5}, io.NopCloser(strings.NewReader("hello")), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "hello",
+		},
+		{
+			name:             "Expired Link",
+			inputRequestBody: ``,
+			input: args{
+				attachmentId: 3,
+				query:        "?expires=1774775700&signature=ab12",
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().Open(gomock.Any(), args.attachmentId, "1774775700", "ab12").Return(domain.Attachment{}, nil, domain.ErrInvalidDownloadLink)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "{\"message\": \"the download link is invalid or has expired\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				attachmentId: 3,
+				query:        "?expires=1774775700&signature=ab12",
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().Open(gomock.Any(), args.attachmentId, "1774775700", "ab12").Return(domain.Attachment{}, nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to open an attachment: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			attachments := mock_service.NewMockAttachments(controller)
+			test.mockBehavior(attachments, test.input)
+
+			// the download link is signed, it is served without the user identity
+			h, _ := newTestHandler(t, &service.Service{Attachments: attachments})
+
+			router := mux.NewRouter()
+			router.Methods(http.MethodGet).Subrouter().HandleFunc("/api/attachments/{id:[0-9]+}/download", h.downloadAttachment)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/attachments/%d/download%s", test.input.attachmentId, test.input.query), bytes.NewBufferString(test.inputRequestBody))
+			router.ServeHTTP(w, r) // perform request
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteAttachmentByID(t *testing.T) {
+	type args struct {
+		userId       int
+		attachmentId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockAttachments, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:       1,
+				attachmentId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.attachmentId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId:       1,
+				attachmentId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockAttachments, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.attachmentId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to delete an attachment by id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			attachments := mock_service.NewMockAttachments(controller)
+			test.mockBehavior(attachments, test.input)
+
+			w := serveRequest(t, &service.Service{Attachments: attachments}, http.MethodDelete, "/api/attachments/{id:[0-9]+}", (*Handler).deleteAttachmentByID,
+				fmt.Sprintf("/api/attachments/%d", test.input.attachmentId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// @Summary Create comment
+// @Security ApiKeyAuth
+// @Tags comments
+// @Description comment on the item or reply to one of its comments, the item has to be in a list shared with the user
+// @ID create-comment
+// @Accept json
+// @Produce json
+// @Param input body domain.Comment true "comment info"
+// @Success 200 {object} domain.Comment
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/comments [post]
+func (h *Handler) createComment(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert an item id"))
+		return
+	}
+
+	var comment domain.Comment
+	if err := json.NewDecoder(r.Body).Decode(&comment); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	if err := h.services.Comments.Validate(comment); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	commentId, err := h.services.Comments.Create(ctx, userId, itemId, comment)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCommentParent) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to create a comment"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(domain.Comment{Id: commentId}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get Comments
+// @Security ApiKeyAuth
+// @Tags comments
+// @Description get a page of the comment threads of the item, the oldest first, with the replies nested in them
+// @ID get-comments
+// @Accept json
+// @Produce json
+// @Param limit query int false "number of threads, 20 by default and 100 at most"
+// @Param offset query int false "number of threads to skip"
+// @Success 200 {object} GetCommentsResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/comments [get]
+func (h *Handler) getComments(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert an item id"))
+		return
+	}
+
+	page, err := queryPage(r.URL.Query())
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	comments, err := h.services.Comments.GetByItemId(ctx, userId, itemId, page)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPage) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to find any comments by item id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(GetCommentsResponse{Data: comments}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Update comment by Id
+// @Security ApiKeyAuth
+// @Tags comments
+// @Description edit comment by id, only the author of the comment can edit it
+// @ID update-comment-by-id
+// @Accept json
+// @Produce json
+// @Param input body domain.UpdateCommentInput true "comment info"
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/comments/:id [put]
+func (h *Handler) updateCommentByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	commentId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a comment id"))
+		return
+	}
+
+	var updateCommentInput domain.UpdateCommentInput
+	if err := json.NewDecoder(r.Body).Decode(&updateCommentInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	if err := h.services.Comments.ValidateUpdate(updateCommentInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.Comments.Update(ctx, userId, commentId, updateCommentInput); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to update a comment by id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Delete comment by Id
+// @Security ApiKeyAuth
+// @Tags comments
+// @Description delete comment by id together with the replies to it, only the author of the comment can delete it
+// @ID delete-comment-by-id
+// @Accept json
+// @Produce json
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/comments/:id [delete]
+func (h *Handler) deleteCommentByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	commentId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a comment id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.Comments.Delete(ctx, userId, commentId); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to delete a comment by id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
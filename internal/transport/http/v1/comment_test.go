@@ -0,0 +1,413 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_createComment(t *testing.T) {
+	type args struct {
+		userId  int
+		itemId  int
+		comment domain.Comment
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockComments, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"body": "Looks good"}`,
+			input: args{
+				userId:  1,
+				itemId:  2,
+				comment: domain.Comment{Body: "Looks good"},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.comment).Return(nil),
+					s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, args.comment).Return(3, nil),
+				)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":3}\n",
+		},
+		{
+			name:             "OK_Reply",
+			inputRequestBody: `{"parent_id": 3, "body": "Agreed"}`,
+			input: args{
+				userId:  1,
+				itemId:  2,
+				comment: domain.Comment{ParentId: intPointer(3), Body: "Agreed"},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.comment).Return(nil),
+					s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, args.comment).Return(4, nil),
+				)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":4}\n",
+		},
+		{
+			name:             "Reply To Another Item",
+			inputRequestBody: `{"parent_id": 30, "body": "Agreed"}`,
+			input: args{
+				userId:  1,
+				itemId:  2,
+				comment: domain.Comment{ParentId: intPointer(30), Body: "Agreed"},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.comment).Return(nil),
+					s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, args.comment).Return(0, domain.ErrInvalidCommentParent),
+				)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"a comment can only reply to a comment on the same item\"}",
+		},
+		{
+			name:             "Empty Body",
+			inputRequestBody: `{"body": ""}`,
+			input: args{
+				userId:  1,
+				itemId:  2,
+				comment: domain.Comment{},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				s.EXPECT().Validate(args.comment).Return(errors.New("Body: zero value"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"Body: zero value\"}",
+		},
+		{
+			name:             "Invalid JSON",
+			inputRequestBody: `{"body": 1}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: json: cannot unmarshal number into Go struct field Comment.body of type string\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{"body": "Looks good"}`,
+			input: args{
+				userId:  1,
+				itemId:  2,
+				comment: domain.Comment{Body: "Looks good"},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.comment).Return(nil),
+					s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, args.comment).Return(0, errors.New("service failure")),
+				)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to create a comment: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			comments := mock_service.NewMockComments(controller)
+			test.mockBehavior(comments, test.input)
+
+			w := serveRequest(t, &service.Service{Comments: comments}, http.MethodPost, "/api/items/{id:[0-9]+}/comments", (*Handler).createComment,
+				fmt.Sprintf("/api/items/%d/comments", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getComments(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+		query  string
+		page   domain.Page
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockComments, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				query:  "?limit=10&offset=20",
+				page:   domain.Page{Limit: 10, Offset: 20},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				s.EXPECT().GetByItemId(gomock.Any(), args.userId, args.itemId, args.page).Return([]domain.Comment{
+					{Id: 3, ItemId: 2, AuthorId: 1, AuthorName: "Alice", Body: "Looks good", Replies: []domain.Comment{
+						{Id: 4, ItemId: 2, ParentId: intPointer(3), AuthorId: 5, AuthorName: "Bob", Body: "Agreed"},
+					}},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":3,\"item_id\":2,\"author_id\":1,\"author_name\":\"Alice\",\"body\":\"Looks good\",\"replies\":[{\"id\":4,\"item_id\":2,\"parent_id\":3,\"author_id\":5,\"author_name\":\"Bob\",\"body\":\"Agreed\"}]}]}\n",
+		},
+		{
+			name:             "OK_DefaultPage",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				page:   domain.Page{},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				s.EXPECT().GetByItemId(gomock.Any(), args.userId, args.itemId, args.page).Return([]domain.Comment{}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[]}\n",
+		},
+		{
+			name:             "Malformed Limit",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				query:  "?limit=ten",
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the limit must be between 1 and 100 and the offset must not be negative\"}",
+		},
+		{
+			name:             "Limit Too Large",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				query:  "?limit=500",
+				page:   domain.Page{Limit: 500},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				s.EXPECT().GetByItemId(gomock.Any(), args.userId, args.itemId, args.page).Return(nil, domain.ErrInvalidPage)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the limit must be between 1 and 100 and the offset must not be negative\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				page:   domain.Page{},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				s.EXPECT().GetByItemId(gomock.Any(), args.userId, args.itemId, args.page).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find any comments by item id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			comments := mock_service.NewMockComments(controller)
+			test.mockBehavior(comments, test.input)
+
+			w := serveRequest(t, &service.Service{Comments: comments}, http.MethodGet, "/api/items/{id:[0-9]+}/comments", (*Handler).getComments,
+				fmt.Sprintf("/api/items/%d/comments%s", test.input.itemId, test.input.query), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_updateCommentByID(t *testing.T) {
+	type args struct {
+		userId    int
+		commentId int
+		input     domain.UpdateCommentInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockComments, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"body": "Looks great"}`,
+			input: args{
+				userId:    1,
+				commentId: 3,
+				input:     domain.UpdateCommentInput{Body: "Looks great"},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.input).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.commentId, args.input).Return(nil),
+				)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Empty Body",
+			inputRequestBody: `{"body": ""}`,
+			input: args{
+				userId:    1,
+				commentId: 3,
+				input:     domain.UpdateCommentInput{},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				s.EXPECT().ValidateUpdate(args.input).Return(errors.New("Body: zero value"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"Body: zero value\"}",
+		},
+		{
+			name:             "Invalid JSON",
+			inputRequestBody: `{"body": 1}`,
+			input: args{
+				userId:    1,
+				commentId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: json: cannot unmarshal number into Go struct field UpdateCommentInput.body of type string\"}",
+		},
+		{
+			name:             "Not The Author",
+			inputRequestBody: `{"body": "Looks great"}`,
+			input: args{
+				userId:    5,
+				commentId: 3,
+				input:     domain.UpdateCommentInput{Body: "Looks great"},
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.input).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.commentId, args.input).Return(sql.ErrNoRows),
+				)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to update a comment by id: sql: no rows in result set\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			comments := mock_service.NewMockComments(controller)
+			test.mockBehavior(comments, test.input)
+
+			w := serveRequest(t, &service.Service{Comments: comments}, http.MethodPut, "/api/comments/{id:[0-9]+}", (*Handler).updateCommentByID,
+				fmt.Sprintf("/api/comments/%d", test.input.commentId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteCommentByID(t *testing.T) {
+	type args struct {
+		userId    int
+		commentId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockComments, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:    1,
+				commentId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.commentId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId:    1,
+				commentId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockComments, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.commentId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to delete a comment by id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			comments := mock_service.NewMockComments(controller)
+			test.mockBehavior(comments, test.input)
+
+			w := serveRequest(t, &service.Service{Comments: comments}, http.MethodDelete, "/api/comments/{id:[0-9]+}", (*Handler).deleteCommentByID,
+				fmt.Sprintf("/api/comments/%d", test.input.commentId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// @Summary Add blocker
+// @Security ApiKeyAuth
+// @Tags items
+// @Description make todo-item blocked by another todo-item of the user's lists, a relation closing a cycle is refused
+// @ID add-item-blocker
+// @Accept json
+// @Produce json
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/blockers/:blockerId [put]
+func (h *Handler) addBlocker(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	blockerId, err := strconv.Atoi(vars["blockerId"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a blocker id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TodoItem.AddBlocker(ctx, userId, itemId, blockerId); err != nil {
+		if errors.Is(err, domain.ErrInvalidBlocker) || errors.Is(err, domain.ErrDependencyCycle) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to add a blocker"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Remove blocker
+// @Security ApiKeyAuth
+// @Tags items
+// @Description stop todo-item being blocked by another todo-item
+// @ID remove-item-blocker
+// @Accept json
+// @Produce json
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/blockers/:blockerId [delete]
+func (h *Handler) removeBlocker(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	blockerId, err := strconv.Atoi(vars["blockerId"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a blocker id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TodoItem.RemoveBlocker(ctx, userId, itemId, blockerId); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to remove a blocker"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get Blockers
+// @Security ApiKeyAuth
+// @Tags items
+// @Description get the todo-items the todo-item is blocked by, done or not
+// @ID get-item-blockers
+// @Accept json
+// @Produce json
+// @Success 200 {object} GetTodoItemResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/blockers [get]
+func (h *Handler) getBlockers(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	blockers, err := h.services.TodoItem.GetBlockers(ctx, userId, itemId)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to find the blockers of a todo-item"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(GetTodoItemResponse{Data: blockers}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_addBlocker(t *testing.T) {
+	type args struct {
+		userId    int
+		itemId    int
+		blockerId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTodoItem, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:    1,
+				itemId:    2,
+				blockerId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().AddBlocker(gomock.Any(), args.userId, args.itemId, args.blockerId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Cycle",
+			inputRequestBody: ``,
+			input: args{
+				userId:    1,
+				itemId:    2,
+				blockerId: 4,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().AddBlocker(gomock.Any(), args.userId, args.itemId, args.blockerId).Return(domain.ErrDependencyCycle)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"an item can not be blocked by an item it blocks\"}",
+		},
+		{
+			name:             "Foreign Blocker",
+			inputRequestBody: ``,
+			input: args{
+				userId:    1,
+				itemId:    2,
+				blockerId: 30,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().AddBlocker(gomock.Any(), args.userId, args.itemId, args.blockerId).Return(domain.ErrInvalidBlocker)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"an item can only be blocked by another item of your lists\"}",
+		},
+		{
+			name:             "Foreign Item",
+			inputRequestBody: ``,
+			input: args{
+				userId:    1,
+				itemId:    20,
+				blockerId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().AddBlocker(gomock.Any(), args.userId, args.itemId, args.blockerId).Return(sql.ErrNoRows)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to add a blocker: sql: no rows in result set\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(todoItem, test.input)
+
+			w := serveRequest(t, &service.Service{TodoItem: todoItem}, http.MethodPut, "/api/items/{id:[0-9]+}/blockers/{blockerId:[0-9]+}", (*Handler).addBlocker,
+				fmt.Sprintf("/api/items/%d/blockers/%d", test.input.itemId, test.input.blockerId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_removeBlocker(t *testing.T) {
+	type args struct {
+		userId    int
+		itemId    int
+		blockerId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTodoItem, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:    1,
+				itemId:    2,
+				blockerId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().RemoveBlocker(gomock.Any(), args.userId, args.itemId, args.blockerId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId:    1,
+				itemId:    2,
+				blockerId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().RemoveBlocker(gomock.Any(), args.userId, args.itemId, args.blockerId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to remove a blocker: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(todoItem, test.input)
+
+			w := serveRequest(t, &service.Service{TodoItem: todoItem}, http.MethodDelete, "/api/items/{id:[0-9]+}/blockers/{blockerId:[0-9]+}", (*Handler).removeBlocker,
+				fmt.Sprintf("/api/items/%d/blockers/%d", test.input.itemId, test.input.blockerId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getBlockers(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTodoItem, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetBlockers(gomock.Any(), args.userId, args.itemId).Return([]domain.TodoItem{
+					{Id: 3, ListId: 7, Title: "title3", Done: true},
+					{Id: 4, ListId: 8, Title: "title4"},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":3,\"list_id\":7,\"title\":\"title3\",\"done\":true},{\"id\":4,\"list_id\":8,\"title\":\"title4\"}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetBlockers(gomock.Any(), args.userId, args.itemId).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find the blockers of a todo-item: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(todoItem, test.input)
+
+			w := serveRequest(t, &service.Service{TodoItem: todoItem}, http.MethodGet, "/api/items/{id:[0-9]+}/blockers", (*Handler).getBlockers,
+				fmt.Sprintf("/api/items/%d/blockers", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
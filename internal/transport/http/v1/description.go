@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/pkg/markdown"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// @Summary Set task of description
+// @Security ApiKeyAuth
+// @Tags items
+// @Description check or uncheck a task of the Markdown task lists of the description of todo-item, the tasks are counted from zero
+// @ID set-description-task
+// @Accept json
+// @Produce json
+// @Param input body domain.DescriptionTaskInput true "task state"
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/tasks/:index [put]
+func (h *Handler) setDescriptionTask(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	index, err := strconv.Atoi(vars["index"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a task index"))
+		return
+	}
+
+	var taskInput domain.DescriptionTaskInput
+	if err := json.NewDecoder(r.Body).Decode(&taskInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TodoItem.SetTask(ctx, userId, itemId, index, taskInput); err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) || errors.Is(err, domain.ErrDescriptionChanged) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to set a task of a todo-item"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// renderDescriptions fills in the HTML of the descriptions of the items and of their subtasks.
+func renderDescriptions(items []domain.TodoItem) error {
+
+	for i := range items {
+		if err := renderDescription(&items[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renderDescription(item *domain.TodoItem) error {
+
+	if item.Description != "" {
+		html, err := markdown.Render(item.Description)
+		if err != nil {
+			return err
+		}
+		item.DescriptionHTML = html
+	}
+
+	return renderDescriptions(item.Children)
+}
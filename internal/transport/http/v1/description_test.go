@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_setDescriptionTask(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+		index  int
+		input  domain.DescriptionTaskInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTodoItem, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"done":true}`,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				index:  1,
+				input:  domain.DescriptionTaskInput{Done: true},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().SetTask(gomock.Any(), args.userId, args.itemId, args.index, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "No Such Task",
+			inputRequestBody: `{"done":false}`,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				index:  7,
+				input:  domain.DescriptionTaskInput{},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().SetTask(gomock.Any(), args.userId, args.itemId, args.index, args.input).Return(domain.ErrTaskNotFound)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the description has no such task\"}",
+		},
+		{
+			name:             "Invalid JSON",
+			inputRequestBody: `{"done":`,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				index:  1,
+				input:  domain.DescriptionTaskInput{},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: unexpected EOF\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{"done":true}`,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				index:  1,
+				input:  domain.DescriptionTaskInput{Done: true},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().SetTask(gomock.Any(), args.userId, args.itemId, args.index, args.input).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to set a task of a todo-item: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(todoItem, test.input)
+
+			w := serveRequest(t, &service.Service{TodoItem: todoItem}, http.MethodPut, "/api/items/{id:[0-9]+}/tasks/{index:[0-9]+}", (*Handler).setDescriptionTask,
+				fmt.Sprintf("/api/items/%d/tasks/%d", test.input.itemId, test.input.index), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/config"
+	"github.com/andredubov/todo-backend/internal/service"
+	"github.com/andredubov/todo-backend/pkg/auth"
+	"github.com/gorilla/mux"
+)
+
+// testEnvironment is the environment the handlers are configured with by serveRequest.
+var testEnvironment = map[string]string{
+	config.ApplicationEnvironment: "local",
+	config.HttpHost:               "localhost",
+	config.HttpPort:               "8080",
+	config.PostgresHost:           "localhost",
+	config.PostgresPort:           "5432",
+	config.PostgresDatabaseName:   "postgres",
+	config.PostgresUsername:       "postgres",
+	config.PostgresPassword:       "qwerty",
+	config.PostgresSSLMode:        "disable",
+	config.PasswordSalt:           "salt",
+	config.JwtSigningKey:          "key",
+}
+
+// newTestHandler creates the handler configured by the test environment, the
+// handler uses the given services.
+func newTestHandler(t *testing.T, services *service.Service) (*Handler, *auth.Manager) {
+
+	t.Helper()
+
+	for key, value := range testEnvironment {
+		os.Setenv(key, value)
+	}
+
+	cfg, err := config.Init(configPath)
+	if err != nil {
+		t.Fatalf("config initializing failed: %s", err.Error())
+	}
+
+	tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewHandler(services, tokenManager, cfg.Auth.JWT), tokenManager
+}
+
+// serveRequest routes the pattern to the handler method behind the user
+// identity middleware and serves the request to the target on behalf of the
+// user, the handler uses the given services.
+func serveRequest(t *testing.T, services *service.Service, method, pattern string, handle func(*Handler, http.ResponseWriter, *http.Request),
+	target, body string, userId int) *httptest.ResponseRecorder {
+
+	t.Helper()
+
+	return serveUserRequest(t, services, pattern, handle, httptest.NewRequest(method, target, bytes.NewBufferString(body)), userId)
+}
+
+// serveUserRequest is serveRequest for a request built by the test, e.g. one
+// with headers of its own.
+func serveUserRequest(t *testing.T, services *service.Service, pattern string, handle func(*Handler, http.ResponseWriter, *http.Request),
+	r *http.Request, userId int) *httptest.ResponseRecorder {
+
+	t.Helper()
+
+	h, tokenManager := newTestHandler(t, services)
+
+	token, err := tokenManager.NewJWT(strconv.Itoa(userId), 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	methodRouter := router.Methods(r.Method).Subrouter()
+	methodRouter.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) { handle(h, w, r) })
+	methodRouter.Use(h.userIdentity)
+
+	w := httptest.NewRecorder()
+	r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
+	router.ServeHTTP(w, r)                              // perform request
+
+	return w
+}
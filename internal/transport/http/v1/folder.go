@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// @Summary Create folder
+// @Security ApiKeyAuth
+// @Tags folders
+// @Description create folder
+// @ID create-folder
+// @Accept json
+// @Produce json
+// @Param input body domain.Folder true "folder info"
+// @Success 200 {object} domain.Folder
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/folders [post]
+func (h *Handler) createFolder(w http.ResponseWriter, r *http.Request) {
+
+	userId := h.getUserId(w, r)
+
+	var folder domain.Folder
+	if err := json.NewDecoder(r.Body).Decode(&folder); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	if err := h.services.Folders.Validate(folder); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	folderId, err := h.services.Folders.Create(ctx, userId, folder)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to create a folder"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(domain.Folder{Id: folderId}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get All Folders
+// @Security ApiKeyAuth
+// @Tags folders
+// @Description get all folders
+// @ID get-all-folders
+// @Accept json
+// @Produce json
+// @Success 200 {object} GetFoldersResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/folders [get]
+func (h *Handler) getFolders(w http.ResponseWriter, r *http.Request) {
+
+	userId := h.getUserId(w, r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	folders, err := h.services.Folders.GetByUserId(ctx, userId)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to find any folders by user id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(GetFoldersResponse{Data: folders}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get Folder Tree
+// @Security ApiKeyAuth
+// @Tags folders
+// @Description get nested folders together with the todo-lists kept in them
+// @ID get-folder-tree
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.FolderTree
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/folders/tree [get]
+func (h *Handler) getFolderTree(w http.ResponseWriter, r *http.Request) {
+
+	userId := h.getUserId(w, r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	tree, err := h.services.Folders.GetTree(ctx, userId)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to build a folder tree"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(tree); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get Folder By Id
+// @Security ApiKeyAuth
+// @Tags folders
+// @Description get folder by id
+// @ID get-folder-by-id
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.Folder
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/folders/:id [get]
+func (h *Handler) getFolderByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	folderId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a folder id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	folder, err := h.services.Folders.GetById(ctx, userId, folderId)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to get a folder by id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(folder); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Update folder by Id
+// @Security ApiKeyAuth
+// @Tags folders
+// @Description update folder by id
+// @ID update-folder-by-id
+// @Accept json
+// @Produce json
+// @Param input body domain.UpdateFolderInput true "folder info"
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/folders/:id [put]
+func (h *Handler) updateFolderByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	folderId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a folder id"))
+		return
+	}
+
+	var updateFolderInput domain.UpdateFolderInput
+	if err := json.NewDecoder(r.Body).Decode(&updateFolderInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.Folders.Update(ctx, userId, folderId, updateFolderInput); err != nil {
+		if errors.Is(err, domain.ErrEmptyFolder) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to update a folder by id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Delete folder by Id
+// @Security ApiKeyAuth
+// @Tags folders
+// @Description delete folder with its subfolders, the todo-lists kept in them are moved to the top level
+// @ID delete-folder-by-id
+// @Accept json
+// @Produce json
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/folders/:id [delete]
+func (h *Handler) deleteFolderByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	folderId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a folder id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.Folders.Delete(ctx, userId, folderId); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to delete a folder by id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Move folder
+// @Security ApiKeyAuth
+// @Tags folders
+// @Description move folder into another folder and between the given neighbours
+// @ID move-folder-by-id
+// @Accept json
+// @Produce json
+// @Param input body domain.MoveFolderInput true "destination"
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/folders/:id/move [post]
+func (h *Handler) moveFolderByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	folderId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a folder id"))
+		return
+	}
+
+	var moveInput domain.MoveFolderInput
+	if err := json.NewDecoder(r.Body).Decode(&moveInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.Folders.Move(ctx, userId, folderId, moveInput); err != nil {
+		if errors.Is(err, domain.ErrInvalidMove) || errors.Is(err, domain.ErrFolderCycle) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to move a folder"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
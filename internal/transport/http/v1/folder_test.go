@@ -0,0 +1,498 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_createFolder(t *testing.T) {
+	type args struct {
+		userId int
+		folder domain.Folder
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockFolders, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"name": "work"}`,
+			input: args{
+				userId: 1,
+				folder: domain.Folder{Name: "work"},
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.folder).Return(nil),
+					s.EXPECT().Create(gomock.Any(), args.userId, args.folder).Return(1, nil),
+				)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":1}\n",
+		},
+		{
+			name:             "Empty Name",
+			inputRequestBody: `{"parent_id": 1}`,
+			input: args{
+				userId: 1,
+				folder: domain.Folder{ParentId: intPointer(1)},
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.folder).Return(errors.New("Name: zero value")),
+				)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"Name: zero value\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{"name": "work"}`,
+			input: args{
+				userId: 1,
+				folder: domain.Folder{Name: "work"},
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.folder).Return(nil),
+					s.EXPECT().Create(gomock.Any(), args.userId, args.folder).Return(0, errors.New("service failure")),
+				)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to create a folder: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			folders := mock_service.NewMockFolders(controller)
+			test.mockBehavior(folders, test.input)
+
+			w := serveRequest(t, &service.Service{Folders: folders}, http.MethodPost, "/api/folders", (*Handler).createFolder,
+				"/api/folders", test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getFolders(t *testing.T) {
+	type args struct {
+		userId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockFolders, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId).Return([]domain.Folder{{Id: 1, Name: "work", Position: "V"}, {Id: 2, ParentId: intPointer(1), Name: "projects", Position: "k"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":1,\"name\":\"work\",\"position\":\"V\"},{\"id\":2,\"parent_id\":1,\"name\":\"projects\",\"position\":\"k\"}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find any folders by user id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			folders := mock_service.NewMockFolders(controller)
+			test.mockBehavior(folders, test.input)
+
+			w := serveRequest(t, &service.Service{Folders: folders}, http.MethodGet, "/api/folders", (*Handler).getFolders,
+				"/api/folders", test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getFolderTree(t *testing.T) {
+	type args struct {
+		userId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockFolders, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().GetTree(gomock.Any(), args.userId).Return(domain.FolderTree{
+					Folders: []domain.FolderNode{{Folder: domain.Folder{Id: 1, Name: "work"}, Folders: []domain.FolderNode{}, Lists: []domain.TodoList{{Id: 2, Title: "sprint"}}}},
+					Lists:   []domain.TodoList{{Id: 3, Title: "home"}},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"folders\":[{\"id\":1,\"name\":\"work\",\"folders\":[],\"lists\":[{\"id\":2,\"title\":\"sprint\"}]}],\"lists\":[{\"id\":3,\"title\":\"home\"}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().GetTree(gomock.Any(), args.userId).Return(domain.FolderTree{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to build a folder tree: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			folders := mock_service.NewMockFolders(controller)
+			test.mockBehavior(folders, test.input)
+
+			w := serveRequest(t, &service.Service{Folders: folders}, http.MethodGet, "/api/folders/tree", (*Handler).getFolderTree,
+				"/api/folders/tree", test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getFolderByID(t *testing.T) {
+	type args struct {
+		userId   int
+		folderId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockFolders, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:   1,
+				folderId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().GetById(gomock.Any(), args.userId, args.folderId).Return(domain.Folder{Id: 2, Name: "work", Position: "V"}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":2,\"name\":\"work\",\"position\":\"V\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId:   1,
+				folderId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().GetById(gomock.Any(), args.userId, args.folderId).Return(domain.Folder{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to get a folder by id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			folders := mock_service.NewMockFolders(controller)
+			test.mockBehavior(folders, test.input)
+
+			w := serveRequest(t, &service.Service{Folders: folders}, http.MethodGet, "/api/folders/{id:[0-9]+}", (*Handler).getFolderByID,
+				fmt.Sprintf("/api/folders/%d", test.input.folderId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_updateFolderByID(t *testing.T) {
+	type args struct {
+		userId   int
+		folderId int
+		input    domain.UpdateFolderInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockFolders, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"name": "personal"}`,
+			input: args{
+				userId:   1,
+				folderId: 2,
+				input:    domain.UpdateFolderInput{Name: stringPointer("personal")},
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().Update(gomock.Any(), args.userId, args.folderId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Invalid JSON",
+			inputRequestBody: `{"name": `,
+			input: args{
+				userId:   1,
+				folderId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: unexpected EOF\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{"name": "personal"}`,
+			input: args{
+				userId:   1,
+				folderId: 2,
+				input:    domain.UpdateFolderInput{Name: stringPointer("personal")},
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().Update(gomock.Any(), args.userId, args.folderId, args.input).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to update a folder by id: service failure\"}",
+		},
+		{
+			name:             "Empty Name",
+			inputRequestBody: `{"name": " "}`,
+			input: args{
+				userId:   1,
+				folderId: 2,
+				input:    domain.UpdateFolderInput{Name: stringPointer(" ")},
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().Update(gomock.Any(), args.userId, args.folderId, args.input).Return(domain.ErrEmptyFolder)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the folder name can not be empty\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			folders := mock_service.NewMockFolders(controller)
+			test.mockBehavior(folders, test.input)
+
+			w := serveRequest(t, &service.Service{Folders: folders}, http.MethodPut, "/api/folders/{id:[0-9]+}", (*Handler).updateFolderByID,
+				fmt.Sprintf("/api/folders/%d", test.input.folderId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteFolderByID(t *testing.T) {
+	type args struct {
+		userId   int
+		folderId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockFolders, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:   1,
+				folderId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.folderId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId:   1,
+				folderId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.folderId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to delete a folder by id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			folders := mock_service.NewMockFolders(controller)
+			test.mockBehavior(folders, test.input)
+
+			w := serveRequest(t, &service.Service{Folders: folders}, http.MethodDelete, "/api/folders/{id:[0-9]+}", (*Handler).deleteFolderByID,
+				fmt.Sprintf("/api/folders/%d", test.input.folderId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_moveFolderByID(t *testing.T) {
+	type args struct {
+		userId   int
+		folderId int
+		input    domain.MoveFolderInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockFolders, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"parent_id": 3, "after_id": 4}`,
+			input: args{
+				userId:   1,
+				folderId: 2,
+				input:    domain.MoveFolderInput{ParentId: intPointer(3), MoveInput: domain.MoveInput{AfterId: intPointer(4)}},
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.folderId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Cycle",
+			inputRequestBody: `{"parent_id": 5}`,
+			input: args{
+				userId:   1,
+				folderId: 2,
+				input:    domain.MoveFolderInput{ParentId: intPointer(5)},
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.folderId, args.input).Return(domain.ErrFolderCycle)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"a folder can not be moved into itself or its subfolder\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{}`,
+			input: args{
+				userId:   1,
+				folderId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockFolders, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.folderId, args.input).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to move a folder: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			folders := mock_service.NewMockFolders(controller)
+			test.mockBehavior(folders, test.input)
+
+			w := serveRequest(t, &service.Service{Folders: folders}, http.MethodPost, "/api/folders/{id:[0-9]+}/move", (*Handler).moveFolderByID,
+				fmt.Sprintf("/api/folders/%d/move", test.input.folderId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
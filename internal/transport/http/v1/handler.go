@@ -36,9 +36,34 @@ func (h *Handler) InitRoutes(cfg config.Config) http.Handler {
 	getRouter.HandleFunc("/api/lists", h.getLists)
 	getRouter.HandleFunc("/api/lists/{id:[0-9]+}", h.getListByID)
 	getRouter.HandleFunc("/api/lists/{id:[0-9]+}/items", h.getItems)
+	getRouter.HandleFunc("/api/lists/{id:[0-9]+}/members", h.getMembers)
+	getRouter.HandleFunc("/api/lists/{id:[0-9]+}/time", h.getListTime)
+	getRouter.HandleFunc("/api/lists/{id:[0-9]+}/statuses", h.getStatuses)
+	getRouter.HandleFunc("/api/lists/{id:[0-9]+}/board", h.getBoard)
+	getRouter.HandleFunc("/api/items", h.getAllItems)
 	getRouter.HandleFunc("/api/items/{id:[0-9]+}", h.getItemByID)
+	getRouter.HandleFunc("/api/items/{id:[0-9]+}/reminders", h.getReminders)
+	getRouter.HandleFunc("/api/items/{id:[0-9]+}/comments", h.getComments)
+	getRouter.HandleFunc("/api/items/{id:[0-9]+}/attachments", h.getAttachments)
+	getRouter.HandleFunc("/api/items/{id:[0-9]+}/assignments", h.getAssignments)
+	getRouter.HandleFunc("/api/items/{id:[0-9]+}/history", h.getHistory)
+	getRouter.HandleFunc("/api/items/{id:[0-9]+}/blockers", h.getBlockers)
+	getRouter.HandleFunc("/api/items/{id:[0-9]+}/time-entries", h.getTimeEntries)
+	getRouter.HandleFunc("/api/items/{id:[0-9]+}/time", h.getItemTime)
+	getRouter.HandleFunc("/api/time/report", h.getTimeReport)
+	getRouter.HandleFunc("/api/attachments/{id:[0-9]+}/url", h.getAttachmentURL)
+	getRouter.HandleFunc("/api/folders", h.getFolders)
+	getRouter.HandleFunc("/api/folders/tree", h.getFolderTree)
+	getRouter.HandleFunc("/api/folders/{id:[0-9]+}", h.getFolderByID)
+	getRouter.HandleFunc("/api/tags", h.getTags)
+	getRouter.HandleFunc("/api/tags/{id:[0-9]+}", h.getTagByID)
+	getRouter.HandleFunc("/api/templates", h.getTemplates)
+	getRouter.HandleFunc("/api/trash", h.getTrash)
 	getRouter.Use(h.userIdentity)
 
+	downloadRouter := router.Methods(http.MethodGet).Subrouter()
+	downloadRouter.HandleFunc("/api/attachments/{id:[0-9]+}/download", h.downloadAttachment)
+
 	authRouter := router.Methods(http.MethodPost).Subrouter()
 	authRouter.HandleFunc("/auth/sign-up", h.signUp)
 	authRouter.HandleFunc("/auth/sign-in", h.signIn)
@@ -46,16 +71,62 @@ func (h *Handler) InitRoutes(cfg config.Config) http.Handler {
 	postRouter := router.Methods(http.MethodPost).Subrouter()
 	postRouter.HandleFunc("/api/lists", h.createList)
 	postRouter.HandleFunc("/api/lists/{id:[0-9]+}/items", h.createItem)
+	postRouter.HandleFunc("/api/lists/{id:[0-9]+}/items/quick", h.quickAddItem)
+	postRouter.HandleFunc("/api/lists/{id:[0-9]+}/restore", h.restoreListByID)
+	postRouter.HandleFunc("/api/lists/{id:[0-9]+}/move", h.moveListByID)
+	postRouter.HandleFunc("/api/lists/{id:[0-9]+}/duplicate", h.duplicateListByID)
+	postRouter.HandleFunc("/api/lists/{id:[0-9]+}/members", h.createMember)
+	postRouter.HandleFunc("/api/lists/{id:[0-9]+}/statuses", h.createStatus)
+	postRouter.HandleFunc("/api/statuses/{id:[0-9]+}/move", h.moveStatusByID)
+	postRouter.HandleFunc("/api/templates/{id:[0-9]+}/instantiate", h.instantiateTemplate)
+	postRouter.HandleFunc("/api/folders", h.createFolder)
+	postRouter.HandleFunc("/api/tags", h.createTag)
+	postRouter.HandleFunc("/api/folders/{id:[0-9]+}/move", h.moveFolderByID)
+	postRouter.HandleFunc("/api/items/{id:[0-9]+}/restore", h.restoreItemByID)
+	postRouter.HandleFunc("/api/items/{id:[0-9]+}/snooze", h.snoozeItemByID)
+	postRouter.HandleFunc("/api/items/{id:[0-9]+}/move", h.moveItemByID)
+	postRouter.HandleFunc("/api/items/{id:[0-9]+}/copy", h.copyItemByID)
+	postRouter.HandleFunc("/api/items/{id:[0-9]+}/revert/{revision:[0-9]+}", h.revertItem)
+	postRouter.HandleFunc("/api/items/{id:[0-9]+}/reminders", h.createReminder)
+	postRouter.HandleFunc("/api/reminders/{id:[0-9]+}/snooze", h.snoozeReminderByID)
+	postRouter.HandleFunc("/api/items/{id:[0-9]+}/comments", h.createComment)
+	postRouter.Handle("/api/items/{id:[0-9]+}/attachments", h.limitBody(cfg.Attachments.MaxSize, h.createAttachment))
+	postRouter.HandleFunc("/api/items/{id:[0-9]+}/timer", h.startTimer)
+	postRouter.HandleFunc("/api/timer/stop", h.stopTimer)
+	postRouter.HandleFunc("/api/items/{id:[0-9]+}/time-entries", h.createTimeEntry)
 	postRouter.Use(h.userIdentity)
 
 	putRouter := router.Methods(http.MethodPut).Subrouter()
 	putRouter.HandleFunc("/api/lists/{id:[0-9]+}", h.updateListByID)
+	putRouter.HandleFunc("/api/lists/{id:[0-9]+}/folder", h.setListFolder)
 	putRouter.HandleFunc("/api/items/{id:[0-9]+}", h.updateItemByID)
+	putRouter.HandleFunc("/api/items/{id:[0-9]+}/parent", h.setItemParent)
+	putRouter.HandleFunc("/api/items/{id:[0-9]+}/assignee", h.setItemAssignee)
+	putRouter.HandleFunc("/api/items/{id:[0-9]+}/status", h.setItemStatus)
+	putRouter.HandleFunc("/api/items/{id:[0-9]+}/tasks/{index:[0-9]+}", h.setDescriptionTask)
+	putRouter.HandleFunc("/api/statuses/{id:[0-9]+}", h.updateStatusByID)
+	putRouter.HandleFunc("/api/items/{id:[0-9]+}/blockers/{blockerId:[0-9]+}", h.addBlocker)
+	putRouter.HandleFunc("/api/folders/{id:[0-9]+}", h.updateFolderByID)
+	putRouter.HandleFunc("/api/tags/{id:[0-9]+}", h.updateTagByID)
+	putRouter.HandleFunc("/api/items/{id:[0-9]+}/tags/{tagId:[0-9]+}", h.attachTag)
+	putRouter.HandleFunc("/api/comments/{id:[0-9]+}", h.updateCommentByID)
+	putRouter.HandleFunc("/api/time-entries/{id:[0-9]+}", h.updateTimeEntryByID)
+	putRouter.HandleFunc("/api/users/timezone", h.setTimezone)
 	putRouter.Use(h.userIdentity)
 
 	deleteRouter := router.Methods(http.MethodDelete).Subrouter()
 	deleteRouter.HandleFunc("/api/lists/{id:[0-9]+}", h.deleteListByID)
+	deleteRouter.HandleFunc("/api/lists/{id:[0-9]+}/members/{userId:[0-9]+}", h.deleteMember)
+	deleteRouter.HandleFunc("/api/statuses/{id:[0-9]+}", h.deleteStatusByID)
 	deleteRouter.HandleFunc("/api/items/{id:[0-9]+}", h.deleteItemByID)
+	deleteRouter.HandleFunc("/api/folders/{id:[0-9]+}", h.deleteFolderByID)
+	deleteRouter.HandleFunc("/api/reminders/{id:[0-9]+}", h.deleteReminderByID)
+	deleteRouter.HandleFunc("/api/tags/{id:[0-9]+}", h.deleteTagByID)
+	deleteRouter.HandleFunc("/api/items/{id:[0-9]+}/tags/{tagId:[0-9]+}", h.detachTag)
+	deleteRouter.HandleFunc("/api/items/{id:[0-9]+}/blockers/{blockerId:[0-9]+}", h.removeBlocker)
+	deleteRouter.HandleFunc("/api/comments/{id:[0-9]+}", h.deleteCommentByID)
+	deleteRouter.HandleFunc("/api/attachments/{id:[0-9]+}", h.deleteAttachmentByID)
+	deleteRouter.HandleFunc("/api/time-entries/{id:[0-9]+}", h.deleteTimeEntryByID)
 	deleteRouter.Use(h.userIdentity)
 
 	return router
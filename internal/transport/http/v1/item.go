@@ -3,8 +3,11 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/andredubov/todo-backend/internal/domain"
 	"github.com/gorilla/mux"
@@ -49,6 +52,10 @@ func (h *Handler) createItem(w http.ResponseWriter, r *http.Request) {
 
 	itemId, err := h.services.TodoItem.Create(ctx, listId, todoItem)
 	if err != nil {
+		if isSubtaskError(err) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
 		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to create a todo-item"))
 		return
 	}
@@ -64,10 +71,21 @@ func (h *Handler) createItem(w http.ResponseWriter, r *http.Request) {
 // @Summary Get All Items
 // @Security ApiKeyAuth
 // @Tags items
-// @Description get all todo-items
+// @Description get all todo-items, optionally only the ones due within the given dates or of the given priorities
 // @ID get-all-items
 // @Accept json
 // @Produce json
+// @Param due_from query string false "due on or after the date, YYYY-MM-DD"
+// @Param due_to query string false "due on or before the date, YYYY-MM-DD"
+// @Param priority query string false "comma-separated priorities: none, low, medium, high, urgent"
+// @Param tag query string false "tag name"
+// @Param order_by query string false "position (default) or priority"
+// @Param nested query bool false "put the subtasks into their parents, can not be combined with limit and cursor"
+// @Param assignee query string false "me, none or a user id"
+// @Param include_deferred query bool false "include the items deferred until later"
+// @Param description_html query bool false "render the Markdown descriptions into HTML"
+// @Param limit query int false "page size, 1 to 100, 20 by default when a cursor is given"
+// @Param cursor query string false "next_cursor of the previous page"
 // @Success 200 {object} GetTodoItemResponse
 // @Failure 400,404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -83,15 +101,85 @@ func (h *Handler) getItems(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	page, err := queryCursorPage(r.URL.Query())
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	todoItems, err := h.services.TodoItem.GetAll(ctx, userId, listId)
+	todoItems, nextCursor, err := h.services.TodoItem.GetAll(ctx, userId, listId, itemFilter(r.URL.Query()), page)
 	if err != nil {
+		if isFilterError(err) || errors.Is(err, domain.ErrInvalidCursorPage) || errors.Is(err, domain.ErrNestedPage) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
 		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to find any todolists by user id"))
 		return
 	}
 
+	if r.URL.Query().Get("description_html") == "true" {
+		if err := renderDescriptions(todoItems); err != nil {
+			h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to render the descriptions"))
+			return
+		}
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(GetTodoItemResponse{Data: todoItems, NextCursor: nextCursor}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get Items Of All Lists
+// @Security ApiKeyAuth
+// @Tags items
+// @Description get the todo-items of all todo-lists, optionally only the ones labelled with the given tag or assigned to the given user
+// @ID get-items-of-all-lists
+// @Accept json
+// @Produce json
+// @Param tag query string false "tag name"
+// @Param due_from query string false "due on or after the date, YYYY-MM-DD"
+// @Param due_to query string false "due on or before the date, YYYY-MM-DD"
+// @Param priority query string false "comma-separated priorities: none, low, medium, high, urgent"
+// @Param order_by query string false "position (default) or priority"
+// @Param nested query bool false "put the subtasks into their parents"
+// @Param assignee query string false "me, none or a user id"
+// @Param include_deferred query bool false "include the items deferred until later"
+// @Param description_html query bool false "render the Markdown descriptions into HTML"
+// @Success 200 {object} GetTodoItemResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items [get]
+func (h *Handler) getAllItems(w http.ResponseWriter, r *http.Request) {
+
+	userId := h.getUserId(w, r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	todoItems, err := h.services.TodoItem.GetByUserId(ctx, userId, itemFilter(r.URL.Query()))
+	if err != nil {
+		if isFilterError(err) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to find any todo-items by user id"))
+		return
+	}
+
+	if r.URL.Query().Get("description_html") == "true" {
+		if err := renderDescriptions(todoItems); err != nil {
+			h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to render the descriptions"))
+			return
+		}
+	}
+
 	h.writeResponseHeader(w, http.StatusOK)
 
 	if err := json.NewEncoder(w).Encode(GetTodoItemResponse{Data: todoItems}); err != nil {
@@ -100,6 +188,38 @@ func (h *Handler) getItems(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// itemFilter reads the item filter from the query parameters, the priorities are comma-separated.
+func itemFilter(query url.Values) domain.TodoItemFilter {
+
+	filter := domain.TodoItemFilter{
+		DueFrom:  domain.Date(query.Get("due_from")),
+		DueTo:    domain.Date(query.Get("due_to")),
+		Tag:      query.Get("tag"),
+		OrderBy:  query.Get("order_by"),
+		Nested:   query.Get("nested") == "true",
+		Assignee: query.Get("assignee"),
+
+		IncludeDeferred: query.Get("include_deferred") == "true",
+	}
+
+	if priorities := query.Get("priority"); priorities != "" {
+		for _, priority := range strings.Split(priorities, ",") {
+			filter.Priorities = append(filter.Priorities, domain.Priority(strings.TrimSpace(priority)))
+		}
+	}
+
+	return filter
+}
+
+func isFilterError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidDate) || errors.Is(err, domain.ErrInvalidPriority) || errors.Is(err, domain.ErrInvalidItemOrder) ||
+		errors.Is(err, domain.ErrInvalidAssigneeFilter)
+}
+
+func isSubtaskError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidParent) || errors.Is(err, domain.ErrSubtaskCycle) || errors.Is(err, domain.ErrSubtaskTooDeep)
+}
+
 // @Summary Get todo-item By Id
 // @Security ApiKeyAuth
 // @Tags items
@@ -107,6 +227,7 @@ func (h *Handler) getItems(w http.ResponseWriter, r *http.Request) {
 // @ID get-item-by-id
 // @Accept json
 // @Produce json
+// @Param description_html query bool false "render the Markdown description into HTML"
 // @Success 200 {object} domain.TodoItem
 // @Failure 400,404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -131,6 +252,13 @@ func (h *Handler) getItemByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.URL.Query().Get("description_html") == "true" {
+		if err := renderDescription(&todoItem); err != nil {
+			h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to render the description"))
+			return
+		}
+	}
+
 	h.writeResponseHeader(w, http.StatusOK)
 
 	if err := json.NewEncoder(w).Encode(todoItem); err != nil {
@@ -168,10 +296,19 @@ func (h *Handler) updateItemByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := h.services.TodoItem.ValidateUpdate(updateTodoItemInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	if err := h.services.TodoItem.Update(ctx, userId, itemId, updateTodoItemInput); err != nil {
+		if errors.Is(err, domain.ErrRRuleWithoutDate) || errors.Is(err, domain.ErrItemBlocked) || errors.Is(err, domain.ErrWipLimit) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
 		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to update a todo-item by id"))
 		return
 	}
@@ -221,3 +358,247 @@ func (h *Handler) deleteItemByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// @Summary Restore todo-item by Id
+// @Security ApiKeyAuth
+// @Tags items
+// @Description restore todo-item from the trash
+// @ID restore-item-by-id
+// @Accept json
+// @Produce json
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/restore [post]
+func (h *Handler) restoreItemByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TodoItem.Restore(ctx, userId, itemId); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to restore a todo-item by id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Snooze todo-item
+// @Security ApiKeyAuth
+// @Tags items
+// @Description defer todo-item by the given duration from the time it is deferred until or from now, whichever is later
+// @ID snooze-item-by-id
+// @Accept json
+// @Produce json
+// @Param input body domain.SnoozeTodoItemInput true "snooze duration"
+// @Success 200 {object} domain.TodoItem
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/snooze [post]
+func (h *Handler) snoozeItemByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	var snoozeInput domain.SnoozeTodoItemInput
+	if err := json.NewDecoder(r.Body).Decode(&snoozeInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	deferUntil, err := h.services.TodoItem.Snooze(ctx, userId, itemId, snoozeInput)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidDeferral) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to snooze a todo-item"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(domain.TodoItem{Id: itemId, DeferUntil: &deferUntil}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Move todo-item
+// @Security ApiKeyAuth
+// @Tags items
+// @Description place todo-item between the given neighbours of its own or another todo-list
+// @ID move-item-by-id
+// @Accept json
+// @Produce json
+// @Param input body domain.TransferTodoItemInput true "destination"
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/move [post]
+func (h *Handler) moveItemByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	var moveInput domain.TransferTodoItemInput
+	if err := json.NewDecoder(r.Body).Decode(&moveInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TodoItem.Move(ctx, userId, itemId, moveInput); err != nil {
+		if errors.Is(err, domain.ErrInvalidMove) || errors.Is(err, domain.ErrWipLimit) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, domain.ErrItemNotFound) {
+			h.writeResponseWithError(w, http.StatusNotFound, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to move a todo-item"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Set parent of todo-item
+// @Security ApiKeyAuth
+// @Tags items
+// @Description make todo-item a subtask of another item of its todo-list, without parent_id the item becomes a top-level one
+// @ID set-item-parent
+// @Accept json
+// @Produce json
+// @Param input body domain.TodoItemParentInput true "parent item"
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/parent [put]
+func (h *Handler) setItemParent(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	var parentInput domain.TodoItemParentInput
+	if err := json.NewDecoder(r.Body).Decode(&parentInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TodoItem.SetParent(ctx, userId, itemId, parentInput); err != nil {
+		if isSubtaskError(err) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to set the parent of a todo-item"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Copy todo-item
+// @Security ApiKeyAuth
+// @Tags items
+// @Description copy todo-item into its own or another todo-list
+// @ID copy-item-by-id
+// @Accept json
+// @Produce json
+// @Param input body domain.TransferTodoItemInput false "destination"
+// @Success 200 {object} domain.TodoItem
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/copy [post]
+func (h *Handler) copyItemByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	var copyInput domain.TransferTodoItemInput
+	if err := json.NewDecoder(r.Body).Decode(&copyInput); err != nil && err != io.EOF {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	copyId, err := h.services.TodoItem.Copy(ctx, userId, itemId, copyInput)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidMove) || errors.Is(err, domain.ErrWipLimit) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, domain.ErrItemNotFound) {
+			h.writeResponseWithError(w, http.StatusNotFound, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to copy a todo-item"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(domain.TodoItem{Id: copyId}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
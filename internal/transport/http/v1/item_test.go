@@ -302,6 +302,8 @@ func TestHandler_getItems(t *testing.T) {
 		args struct {
 			userId     int
 			todoListId int
+			query      string
+			filter     domain.TodoItemFilter
 		}
 
 		mockBehavior func(s *mock_service.MockTodoItem, args args)
@@ -359,7 +361,7 @@ func TestHandler_getItems(t *testing.T) {
 				todoItems := []domain.TodoItem{
 					{Id: 1, Title: "title1", Description: "description1", Done: true},
 				}
-				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId).Return(todoItems, nil)
+				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId, args.filter, domain.CursorPage{}).Return(todoItems, "", nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: "{\"data\":[{\"id\":1,\"title\":\"title1\",\"description\":\"description1\",\"done\":true}]}\n",
@@ -387,11 +389,291 @@ func TestHandler_getItems(t *testing.T) {
 			},
 			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
 				todoItems := []domain.TodoItem{}
-				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId).Return(todoItems, nil)
+				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId, args.filter, domain.CursorPage{}).Return(todoItems, "", nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: "{\"data\":[]}\n",
 		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Due Range",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId:     1,
+				todoListId: 1,
+				query:      "?due_from=2023-05-01&due_to=2023-05-07",
+				filter:     domain.TodoItemFilter{DueFrom: "2023-05-01", DueTo: "2023-05-07"},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				dueAt := time.Date(2023, time.May, 3, 7, 30, 0, 0, time.UTC)
+				todoItems := []domain.TodoItem{
+					{Id: 1, Title: "title1", DueDate: "2023-05-03", DueTime: "09:30", DueAt: &dueAt},
+				}
+				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId, args.filter, domain.CursorPage{}).Return(todoItems, "", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":1,\"title\":\"title1\",\"due_date\":\"2023-05-03\",\"due_time\":\"09:30\",\"due_at\":\"2023-05-03T07:30:00Z\"}]}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Include Deferred",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId:     1,
+				todoListId: 1,
+				query:      "?include_deferred=true",
+				filter:     domain.TodoItemFilter{IncludeDeferred: true},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				deferUntil := time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC)
+				todoItems := []domain.TodoItem{
+					{Id: 1, Title: "title1", DeferUntil: &deferUntil},
+				}
+				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId, args.filter, domain.CursorPage{}).Return(todoItems, "", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":1,\"title\":\"title1\",\"defer_until\":\"2030-01-01T09:00:00Z\"}]}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Description HTML",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId:     1,
+				todoListId: 1,
+				query:      "?nested=true&description_html=true",
+				filter:     domain.TodoItemFilter{Nested: true},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				todoItems := []domain.TodoItem{
+					{Id: 1, Title: "title1", Description: "Buy *milk*<script>alert(1)</script>", Children: []domain.TodoItem{
+						{Id: 2, ParentId: intPointer(1), Title: "title2", Description: "- [x] done"},
+					}},
+					{Id: 3, Title: "title3"},
+				}
+				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId, args.filter, domain.CursorPage{}).Return(todoItems, "", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":1,\"title\":\"title1\",\"description\":\"Buy *milk*\\u003cscript\\u003ealert(1)\\u003c/script\\u003e\",\"description_html\":\"\\u003cp\\u003eBuy \\u003cem\\u003emilk\\u003c/em\\u003e\\u003c/p\\u003e\\n\",\"children\":[{\"id\":2,\"parent_id\":1,\"title\":\"title2\",\"description\":\"- [x] done\",\"description_html\":\"\\u003cul\\u003e\\n\\u003cli\\u003e\\u003cinput checked=\\\"\\\" disabled=\\\"\\\" type=\\\"checkbox\\\"\\u003e done\\u003c/li\\u003e\\n\\u003c/ul\\u003e\\n\"}]},{\"id\":3,\"title\":\"title3\"}]}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Invalid Date",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId:     1,
+				todoListId: 1,
+				query:      "?due_from=05/01/2023",
+				filter:     domain.TodoItemFilter{DueFrom: "05/01/2023"},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId, args.filter, domain.CursorPage{}).Return(nil, "", domain.ErrInvalidDate)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the date must be in the YYYY-MM-DD format\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Priorities",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId:     1,
+				todoListId: 1,
+				query:      "?priority=high,urgent&order_by=priority",
+				filter:     domain.TodoItemFilter{Priorities: []domain.Priority{domain.PriorityHigh, domain.PriorityUrgent}, OrderBy: domain.ItemOrderPriority},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				todoItems := []domain.TodoItem{
+					{Id: 2, Title: "title2", Priority: domain.PriorityUrgent},
+					{Id: 1, Title: "title1", Priority: domain.PriorityHigh},
+				}
+				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId, args.filter, domain.CursorPage{}).Return(todoItems, "", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":2,\"title\":\"title2\",\"priority\":\"urgent\"},{\"id\":1,\"title\":\"title1\",\"priority\":\"high\"}]}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Invalid Priority",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId:     1,
+				todoListId: 1,
+				query:      "?priority=asap",
+				filter:     domain.TodoItemFilter{Priorities: []domain.Priority{"asap"}},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId, args.filter, domain.CursorPage{}).Return(nil, "", domain.ErrInvalidPriority)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the priority must be one of none, low, medium, high or urgent\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Paged",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId:     1,
+				todoListId: 1,
+				query:      "?limit=1",
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				todoItems := []domain.TodoItem{
+					{Id: 1, Title: "title1", Description: "description1"},
+				}
+				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId, args.filter, domain.CursorPage{Limit: 1}).Return(todoItems, "eyJpZCI6MX0", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":1,\"title\":\"title1\",\"description\":\"description1\"}],\"next_cursor\":\"eyJpZCI6MX0\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Invalid Cursor",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId:     1,
+				todoListId: 1,
+				query:      "?limit=500&cursor=bogus",
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId, args.filter, domain.CursorPage{Limit: 500, Cursor: "bogus"}).Return(nil, "", domain.ErrInvalidCursorPage)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the limit must be between 1 and 100 and the cursor must come from a previous page\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Nested Page",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId:     1,
+				todoListId: 1,
+				query:      "?nested=true&limit=10",
+				filter:     domain.TodoItemFilter{Nested: true},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetAll(gomock.Any(), args.userId, args.todoListId, args.filter, domain.CursorPage{Limit: 10}).Return(nil, "", domain.ErrNestedPage)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"nested items can not be paged, drop either nested or limit and cursor\"}",
+		},
 		{
 			enviroment: enviroment{
 				appEnv:               "local",
@@ -459,7 +741,7 @@ func TestHandler_getItems(t *testing.T) {
 			getRouter.HandleFunc("/api/lists/{id:[0-9]+}/items", h.getItems)
 			getRouter.Use(h.userIdentity)
 
-			w, endpoint := httptest.NewRecorder(), fmt.Sprintf("/api/lists/%d/items", test.input.todoListId)
+			w, endpoint := httptest.NewRecorder(), fmt.Sprintf("/api/lists/%d/items%s", test.input.todoListId, test.input.query)
 			r := httptest.NewRequest(http.MethodGet, endpoint, bytes.NewBufferString(test.inputRequestBody))
 			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
 			router.ServeHTTP(w, r)                              // perforn request
@@ -736,7 +1018,10 @@ func TestHandler_updateItemByID(t *testing.T) {
 				},
 			},
 			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
-				s.EXPECT().Update(gomock.Any(), args.userId, args.todoItemId, args.updateTodoItemInput).Return(nil)
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoItemInput).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.todoItemId, args.updateTodoItemInput).Return(nil),
+				)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: "{\"status\":\"success\"}\n",
@@ -768,7 +1053,10 @@ func TestHandler_updateItemByID(t *testing.T) {
 				},
 			},
 			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
-				s.EXPECT().Update(gomock.Any(), args.userId, args.todoItemId, args.updateTodoItemInput).Return(nil)
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoItemInput).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.todoItemId, args.updateTodoItemInput).Return(nil),
+				)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: "{\"status\":\"success\"}\n",
@@ -800,7 +1088,10 @@ func TestHandler_updateItemByID(t *testing.T) {
 				},
 			},
 			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
-				s.EXPECT().Update(gomock.Any(), args.userId, args.todoItemId, args.updateTodoItemInput).Return(nil)
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoItemInput).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.todoItemId, args.updateTodoItemInput).Return(nil),
+				)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: "{\"status\":\"success\"}\n",
@@ -832,7 +1123,10 @@ func TestHandler_updateItemByID(t *testing.T) {
 				},
 			},
 			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
-				s.EXPECT().Update(gomock.Any(), args.userId, args.todoItemId, args.updateTodoItemInput).Return(nil)
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoItemInput).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.todoItemId, args.updateTodoItemInput).Return(nil),
+				)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: "{\"status\":\"success\"}\n",
@@ -851,28 +1145,164 @@ func TestHandler_updateItemByID(t *testing.T) {
 				passwordSalt:         "salt",
 				jwtSigningKey:        "key",
 			},
-			name:             "Token Expired",
-			jwtTTL:           time.Duration(5 * time.Millisecond),
-			delay:            time.Duration(1 * time.Second),
-			inputRequestBody: `{"title": "new title", "description": "new description", "done": true}`,
+			name:             "Due Date",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"due_date": "2023-05-10", "due_time": "18:00"}`,
 			input: args{
 				userId:     1,
-				todoItemId: 2,
+				todoItemId: 1,
 				updateTodoItemInput: domain.UpdateTodoItemInput{
-					Title:       stringPointer("new title"),
-					Description: stringPointer("new description"),
-					Done:        boolPointer(true),
+					DueDate: datePointer("2023-05-10"),
+					DueTime: timeOfDayPointer("18:00"),
 				},
 			},
 			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoItemInput).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.todoItemId, args.updateTodoItemInput).Return(nil),
+				)
 			},
-			expectedStatusCode:   http.StatusUnauthorized,
-			expectedResponseBody: "{\"message\": \"Token is expired\"}",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Invalid Due Time",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"due_time": "6pm"}`,
+			input: args{
+				userId:     1,
+				todoItemId: 1,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					DueTime: timeOfDayPointer("6pm"),
+				},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoItemInput).Return(domain.ErrInvalidTimeOfDay),
+				)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the time must be in the HH:MM format\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Token Expired",
+			jwtTTL:           time.Duration(5 * time.Millisecond),
+			delay:            time.Duration(1 * time.Second),
+			inputRequestBody: `{"title": "new title", "description": "new description", "done": true}`,
+			input: args{
+				userId:     1,
+				todoItemId: 2,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Title:       stringPointer("new title"),
+					Description: stringPointer("new description"),
+					Done:        boolPointer(true),
+				},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: "{\"message\": \"Token is expired\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Recurring Without Due Date",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"rrule": "FREQ=WEEKLY;BYDAY=MO"}`,
+			input: args{
+				userId:     1,
+				todoItemId: 2,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					RRule: stringPointer("FREQ=WEEKLY;BYDAY=MO"),
+				},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoItemInput).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.todoItemId, args.updateTodoItemInput).Return(domain.ErrRRuleWithoutDate),
+				)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"a recurring item requires a due date\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Blocked",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"done": true}`,
+			input: args{
+				userId:     1,
+				todoItemId: 2,
+				updateTodoItemInput: domain.UpdateTodoItemInput{
+					Done: boolPointer(true),
+				},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoItemInput).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.todoItemId, args.updateTodoItemInput).Return(domain.ErrItemBlocked),
+				)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the item can not be done while it is blocked by open items\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
 
 			controller := gomock.NewController(t)
 			defer controller.Finish()
@@ -1078,6 +1508,1394 @@ func TestHandler_deleteItemByID(t *testing.T) {
 	}
 }
 
-func boolPointer(s bool) *bool {
-	return &s
+func TestHandler_restoreItemByID(t *testing.T) {
+	type (
+		enviroment struct {
+			appEnv               string
+			httpHost             string
+			httpPort             string
+			postgresHost         string
+			postgresPort         string
+			postgresDatabaseName string
+			postgresUsername     string
+			postgresPassword     string
+			postgressSSLMode     string
+			passwordSalt         string
+			jwtSigningKey        string
+		}
+
+		args struct {
+			userId int
+			itemId int
+		}
+
+		mockBehavior func(s *mock_service.MockTodoItem, args args)
+
+		test struct {
+			enviroment           enviroment
+			name                 string
+			jwtTTL               time.Duration
+			delay                time.Duration
+			inputRequestBody     string
+			input                args
+			mockBehavior         mockBehavior
+			expectedStatusCode   int
+			expectedResponseBody string
+		}
+	)
+
+	setEnv := func(env enviroment) {
+		os.Setenv(config.ApplicationEnvironment, env.appEnv)
+		os.Setenv(config.HttpHost, env.httpHost)
+		os.Setenv(config.HttpPort, env.httpPort)
+		os.Setenv(config.PostgresHost, env.postgresHost)
+		os.Setenv(config.PostgresPort, env.postgresPort)
+		os.Setenv(config.PostgresDatabaseName, env.postgresDatabaseName)
+		os.Setenv(config.PostgresUsername, env.postgresUsername)
+		os.Setenv(config.PostgresPassword, env.postgresPassword)
+		os.Setenv(config.PostgresSSLMode, env.postgressSSLMode)
+		os.Setenv(config.PasswordSalt, env.passwordSalt)
+		os.Setenv(config.JwtSigningKey, env.jwtSigningKey)
+	}
+
+	tests := []test{
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Restore(gomock.Any(), args.userId, args.itemId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Service Failure",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Restore(gomock.Any(), args.userId, args.itemId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to restore a todo-item by id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockTodoItemService := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(mockTodoItemService, test.input)
+
+			setEnv(test.enviroment)
+
+			cfg, err := config.Init(configPath)
+			if err != nil {
+				t.Errorf("config initializing failed: %s", err.Error())
+				return
+			}
+
+			tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			token, err := tokenManager.NewJWT(strconv.Itoa(test.input.userId), test.jwtTTL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			<-time.After(test.delay)
+
+			services := service.Service{TodoItem: mockTodoItemService}
+			h := NewHandler(&services, tokenManager, cfg.Auth.JWT)
+
+			router := mux.NewRouter()
+			postRouter := router.Methods(http.MethodPost).Subrouter()
+			postRouter.HandleFunc("/api/items/{id:[0-9]+}/restore", h.restoreItemByID)
+			postRouter.Use(h.userIdentity)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/items/%d/restore", test.input.itemId), bytes.NewBufferString(test.inputRequestBody))
+			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
+			router.ServeHTTP(w, r)                              // perform request
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_snoozeItemByID(t *testing.T) {
+	type (
+		enviroment struct {
+			appEnv               string
+			httpHost             string
+			httpPort             string
+			postgresHost         string
+			postgresPort         string
+			postgresDatabaseName string
+			postgresUsername     string
+			postgresPassword     string
+			postgressSSLMode     string
+			passwordSalt         string
+			jwtSigningKey        string
+		}
+
+		args struct {
+			userId int
+			itemId int
+			input  domain.SnoozeTodoItemInput
+		}
+
+		mockBehavior func(s *mock_service.MockTodoItem, args args)
+
+		test struct {
+			enviroment           enviroment
+			name                 string
+			jwtTTL               time.Duration
+			delay                time.Duration
+			inputRequestBody     string
+			input                args
+			mockBehavior         mockBehavior
+			expectedStatusCode   int
+			expectedResponseBody string
+		}
+	)
+
+	setEnv := func(env enviroment) {
+		os.Setenv(config.ApplicationEnvironment, env.appEnv)
+		os.Setenv(config.HttpHost, env.httpHost)
+		os.Setenv(config.HttpPort, env.httpPort)
+		os.Setenv(config.PostgresHost, env.postgresHost)
+		os.Setenv(config.PostgresPort, env.postgresPort)
+		os.Setenv(config.PostgresDatabaseName, env.postgresDatabaseName)
+		os.Setenv(config.PostgresUsername, env.postgresUsername)
+		os.Setenv(config.PostgresPassword, env.postgresPassword)
+		os.Setenv(config.PostgresSSLMode, env.postgressSSLMode)
+		os.Setenv(config.PasswordSalt, env.passwordSalt)
+		os.Setenv(config.JwtSigningKey, env.jwtSigningKey)
+	}
+
+	tests := []test{
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"days":1,"hours":2}`,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				input:  domain.SnoozeTodoItemInput{Days: 1, Hours: 2},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Snooze(gomock.Any(), args.userId, args.itemId, args.input).Return(time.Date(2026, time.March, 30, 9, 0, 0, 0, time.UTC), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":3,\"defer_until\":\"2026-03-30T09:00:00Z\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Zero Duration",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{}`,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				input:  domain.SnoozeTodoItemInput{},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Snooze(gomock.Any(), args.userId, args.itemId, args.input).Return(time.Time{}, domain.ErrInvalidDeferral)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"an item can only be snoozed for a positive duration\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Invalid JSON",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"days":`,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				input:  domain.SnoozeTodoItemInput{},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: unexpected EOF\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Service Failure",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"minutes":30}`,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				input:  domain.SnoozeTodoItemInput{Minutes: 30},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Snooze(gomock.Any(), args.userId, args.itemId, args.input).Return(time.Time{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to snooze a todo-item: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockTodoItemService := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(mockTodoItemService, test.input)
+
+			setEnv(test.enviroment)
+
+			cfg, err := config.Init(configPath)
+			if err != nil {
+				t.Errorf("config initializing failed: %s", err.Error())
+				return
+			}
+
+			tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			token, err := tokenManager.NewJWT(strconv.Itoa(test.input.userId), test.jwtTTL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			<-time.After(test.delay)
+
+			services := service.Service{TodoItem: mockTodoItemService}
+			h := NewHandler(&services, tokenManager, cfg.Auth.JWT)
+
+			router := mux.NewRouter()
+			postRouter := router.Methods(http.MethodPost).Subrouter()
+			postRouter.HandleFunc("/api/items/{id:[0-9]+}/snooze", h.snoozeItemByID)
+			postRouter.Use(h.userIdentity)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/items/%d/snooze", test.input.itemId), bytes.NewBufferString(test.inputRequestBody))
+			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
+			router.ServeHTTP(w, r)                              // perform request
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_moveItemByID(t *testing.T) {
+	type (
+		enviroment struct {
+			appEnv               string
+			httpHost             string
+			httpPort             string
+			postgresHost         string
+			postgresPort         string
+			postgresDatabaseName string
+			postgresUsername     string
+			postgresPassword     string
+			postgressSSLMode     string
+			passwordSalt         string
+			jwtSigningKey        string
+		}
+
+		args struct {
+			userId int
+			itemId int
+			input  domain.TransferTodoItemInput
+		}
+
+		mockBehavior func(s *mock_service.MockTodoItem, args args)
+
+		test struct {
+			enviroment           enviroment
+			name                 string
+			jwtTTL               time.Duration
+			delay                time.Duration
+			inputRequestBody     string
+			input                args
+			mockBehavior         mockBehavior
+			expectedStatusCode   int
+			expectedResponseBody string
+		}
+	)
+
+	setEnv := func(env enviroment) {
+		os.Setenv(config.ApplicationEnvironment, env.appEnv)
+		os.Setenv(config.HttpHost, env.httpHost)
+		os.Setenv(config.HttpPort, env.httpPort)
+		os.Setenv(config.PostgresHost, env.postgresHost)
+		os.Setenv(config.PostgresPort, env.postgresPort)
+		os.Setenv(config.PostgresDatabaseName, env.postgresDatabaseName)
+		os.Setenv(config.PostgresUsername, env.postgresUsername)
+		os.Setenv(config.PostgresPassword, env.postgresPassword)
+		os.Setenv(config.PostgresSSLMode, env.postgressSSLMode)
+		os.Setenv(config.PasswordSalt, env.passwordSalt)
+		os.Setenv(config.JwtSigningKey, env.jwtSigningKey)
+	}
+
+	tests := []test{
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"after_id": 3, "before_id": 4}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TransferTodoItemInput{MoveInput: domain.MoveInput{AfterId: intPointer(3), BeforeId: intPointer(4)}},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.itemId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK_ToAnotherList",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"list_id": 5, "after_id": 3}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TransferTodoItemInput{ListId: intPointer(5), MoveInput: domain.MoveInput{AfterId: intPointer(3)}},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.itemId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Service Failure",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.itemId, args.input).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to move a todo-item: service failure\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Trashed Item",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"list_id": 5}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TransferTodoItemInput{ListId: intPointer(5)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.itemId, args.input).Return(domain.ErrItemNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\": \"the item or the target list does not exist or is in the trash\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Full Status",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"list_id": 5}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TransferTodoItemInput{ListId: intPointer(5)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.itemId, args.input).Return(domain.ErrWipLimit)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the status has reached its WIP limit\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockTodoItemService := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(mockTodoItemService, test.input)
+
+			setEnv(test.enviroment)
+
+			cfg, err := config.Init(configPath)
+			if err != nil {
+				t.Errorf("config initializing failed: %s", err.Error())
+				return
+			}
+
+			tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			token, err := tokenManager.NewJWT(strconv.Itoa(test.input.userId), test.jwtTTL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			<-time.After(test.delay)
+
+			services := service.Service{TodoItem: mockTodoItemService}
+			h := NewHandler(&services, tokenManager, cfg.Auth.JWT)
+
+			router := mux.NewRouter()
+			postRouter := router.Methods(http.MethodPost).Subrouter()
+			postRouter.HandleFunc("/api/items/{id:[0-9]+}/move", h.moveItemByID)
+			postRouter.Use(h.userIdentity)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/items/%d/move", test.input.itemId), bytes.NewBufferString(test.inputRequestBody))
+			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
+			router.ServeHTTP(w, r)                              // perform request
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_copyItemByID(t *testing.T) {
+	type (
+		enviroment struct {
+			appEnv               string
+			httpHost             string
+			httpPort             string
+			postgresHost         string
+			postgresPort         string
+			postgresDatabaseName string
+			postgresUsername     string
+			postgresPassword     string
+			postgressSSLMode     string
+			passwordSalt         string
+			jwtSigningKey        string
+		}
+
+		args struct {
+			userId int
+			itemId int
+			input  domain.TransferTodoItemInput
+		}
+
+		mockBehavior func(s *mock_service.MockTodoItem, args args)
+
+		test struct {
+			enviroment           enviroment
+			name                 string
+			jwtTTL               time.Duration
+			delay                time.Duration
+			inputRequestBody     string
+			input                args
+			mockBehavior         mockBehavior
+			expectedStatusCode   int
+			expectedResponseBody string
+		}
+	)
+
+	setEnv := func(env enviroment) {
+		os.Setenv(config.ApplicationEnvironment, env.appEnv)
+		os.Setenv(config.HttpHost, env.httpHost)
+		os.Setenv(config.HttpPort, env.httpPort)
+		os.Setenv(config.PostgresHost, env.postgresHost)
+		os.Setenv(config.PostgresPort, env.postgresPort)
+		os.Setenv(config.PostgresDatabaseName, env.postgresDatabaseName)
+		os.Setenv(config.PostgresUsername, env.postgresUsername)
+		os.Setenv(config.PostgresPassword, env.postgresPassword)
+		os.Setenv(config.PostgresSSLMode, env.postgressSSLMode)
+		os.Setenv(config.PasswordSalt, env.passwordSalt)
+		os.Setenv(config.JwtSigningKey, env.jwtSigningKey)
+	}
+
+	tests := []test{
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"list_id": 5}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TransferTodoItemInput{ListId: intPointer(5)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Copy(gomock.Any(), args.userId, args.itemId, args.input).Return(7, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":7}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK_EmptyBody",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Copy(gomock.Any(), args.userId, args.itemId, args.input).Return(7, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":7}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Invalid Anchors",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"after_id": 3, "before_id": 3}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TransferTodoItemInput{MoveInput: domain.MoveInput{AfterId: intPointer(3), BeforeId: intPointer(3)}},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Copy(gomock.Any(), args.userId, args.itemId, args.input).Return(0, domain.ErrInvalidMove)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"invalid move anchors\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Service Failure",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"list_id": 9}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TransferTodoItemInput{ListId: intPointer(9)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Copy(gomock.Any(), args.userId, args.itemId, args.input).Return(0, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to copy a todo-item: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockTodoItemService := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(mockTodoItemService, test.input)
+
+			setEnv(test.enviroment)
+
+			cfg, err := config.Init(configPath)
+			if err != nil {
+				t.Errorf("config initializing failed: %s", err.Error())
+				return
+			}
+
+			tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			token, err := tokenManager.NewJWT(strconv.Itoa(test.input.userId), test.jwtTTL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			<-time.After(test.delay)
+
+			services := service.Service{TodoItem: mockTodoItemService}
+			h := NewHandler(&services, tokenManager, cfg.Auth.JWT)
+
+			router := mux.NewRouter()
+			postRouter := router.Methods(http.MethodPost).Subrouter()
+			postRouter.HandleFunc("/api/items/{id:[0-9]+}/copy", h.copyItemByID)
+			postRouter.Use(h.userIdentity)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/items/%d/copy", test.input.itemId), bytes.NewBufferString(test.inputRequestBody))
+			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
+			router.ServeHTTP(w, r)                              // perform request
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getAllItems(t *testing.T) {
+	type (
+		enviroment struct {
+			appEnv               string
+			httpHost             string
+			httpPort             string
+			postgresHost         string
+			postgresPort         string
+			postgresDatabaseName string
+			postgresUsername     string
+			postgresPassword     string
+			postgressSSLMode     string
+			passwordSalt         string
+			jwtSigningKey        string
+		}
+
+		args struct {
+			userId int
+			query  string
+			filter domain.TodoItemFilter
+		}
+
+		mockBehavior func(s *mock_service.MockTodoItem, args args)
+
+		test struct {
+			enviroment           enviroment
+			name                 string
+			jwtTTL               time.Duration
+			delay                time.Duration
+			inputRequestBody     string
+			input                args
+			mockBehavior         mockBehavior
+			expectedStatusCode   int
+			expectedResponseBody string
+		}
+	)
+
+	setEnv := func(env enviroment) {
+		os.Setenv(config.ApplicationEnvironment, env.appEnv)
+		os.Setenv(config.HttpHost, env.httpHost)
+		os.Setenv(config.HttpPort, env.httpPort)
+		os.Setenv(config.PostgresHost, env.postgresHost)
+		os.Setenv(config.PostgresPort, env.postgresPort)
+		os.Setenv(config.PostgresDatabaseName, env.postgresDatabaseName)
+		os.Setenv(config.PostgresUsername, env.postgresUsername)
+		os.Setenv(config.PostgresPassword, env.postgresPassword)
+		os.Setenv(config.PostgresSSLMode, env.postgressSSLMode)
+		os.Setenv(config.PasswordSalt, env.passwordSalt)
+		os.Setenv(config.JwtSigningKey, env.jwtSigningKey)
+	}
+
+	tests := []test{
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				query:  "?tag=%40waiting",
+				filter: domain.TodoItemFilter{Tag: "@waiting"},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				todoItems := []domain.TodoItem{
+					{Id: 3, ListId: 1, Title: "title3", Tags: []domain.Tag{{Id: 2, Name: "@waiting"}}},
+				}
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId, args.filter).Return(todoItems, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":3,\"list_id\":1,\"title\":\"title3\",\"tags\":[{\"id\":2,\"name\":\"@waiting\"}]}]}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK_AssignedToMe",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				query:  "?assignee=me",
+				filter: domain.TodoItemFilter{Assignee: domain.AssigneeMe},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				todoItems := []domain.TodoItem{
+					{Id: 4, ListId: 2, Title: "title4", AssigneeId: intPointer(1)},
+				}
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId, args.filter).Return(todoItems, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":4,\"list_id\":2,\"title\":\"title4\",\"assignee_id\":1}]}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Invalid Assignee",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				query:  "?assignee=bob",
+				filter: domain.TodoItemFilter{Assignee: "bob"},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId, args.filter).Return(nil, domain.ErrInvalidAssigneeFilter)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the assignee must be me, none or a user id\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Invalid Order",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				query:  "?order_by=title",
+				filter: domain.TodoItemFilter{OrderBy: "title"},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId, args.filter).Return(nil, domain.ErrInvalidItemOrder)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the items can only be ordered by position or priority\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Service Failure",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId, args.filter).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find any todo-items by user id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockTodoItemService := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(mockTodoItemService, test.input)
+
+			setEnv(test.enviroment)
+
+			cfg, err := config.Init(configPath)
+			if err != nil {
+				t.Errorf("config initializing failed: %s", err.Error())
+				return
+			}
+
+			tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			token, err := tokenManager.NewJWT(strconv.Itoa(test.input.userId), test.jwtTTL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			<-time.After(test.delay)
+
+			services := service.Service{TodoItem: mockTodoItemService}
+			h := NewHandler(&services, tokenManager, cfg.Auth.JWT)
+
+			router := mux.NewRouter()
+			getRouter := router.Methods(http.MethodGet).Subrouter()
+			getRouter.HandleFunc("/api/items", h.getAllItems)
+			getRouter.Use(h.userIdentity)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/items"+test.input.query, bytes.NewBufferString(test.inputRequestBody))
+			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
+			router.ServeHTTP(w, r)                              // perform request
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_setItemParent(t *testing.T) {
+	type (
+		enviroment struct {
+			appEnv               string
+			httpHost             string
+			httpPort             string
+			postgresHost         string
+			postgresPort         string
+			postgresDatabaseName string
+			postgresUsername     string
+			postgresPassword     string
+			postgressSSLMode     string
+			passwordSalt         string
+			jwtSigningKey        string
+		}
+
+		args struct {
+			userId int
+			itemId int
+			input  domain.TodoItemParentInput
+		}
+
+		mockBehavior func(s *mock_service.MockTodoItem, args args)
+
+		test struct {
+			enviroment           enviroment
+			name                 string
+			jwtTTL               time.Duration
+			delay                time.Duration
+			inputRequestBody     string
+			input                args
+			mockBehavior         mockBehavior
+			expectedStatusCode   int
+			expectedResponseBody string
+		}
+	)
+
+	setEnv := func(env enviroment) {
+		os.Setenv(config.ApplicationEnvironment, env.appEnv)
+		os.Setenv(config.HttpHost, env.httpHost)
+		os.Setenv(config.HttpPort, env.httpPort)
+		os.Setenv(config.PostgresHost, env.postgresHost)
+		os.Setenv(config.PostgresPort, env.postgresPort)
+		os.Setenv(config.PostgresDatabaseName, env.postgresDatabaseName)
+		os.Setenv(config.PostgresUsername, env.postgresUsername)
+		os.Setenv(config.PostgresPassword, env.postgresPassword)
+		os.Setenv(config.PostgresSSLMode, env.postgressSSLMode)
+		os.Setenv(config.PasswordSalt, env.passwordSalt)
+		os.Setenv(config.JwtSigningKey, env.jwtSigningKey)
+	}
+
+	tests := []test{
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"parent_id": 3}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemParentInput{ParentId: intPointer(3)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().SetParent(gomock.Any(), args.userId, args.itemId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK_TopLevel",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemParentInput{},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().SetParent(gomock.Any(), args.userId, args.itemId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Cycle",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"parent_id": 4}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemParentInput{ParentId: intPointer(4)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().SetParent(gomock.Any(), args.userId, args.itemId, args.input).Return(domain.ErrSubtaskCycle)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"an item can not be a subtask of itself or its subtask\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Too Deep",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"parent_id": 5}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemParentInput{ParentId: intPointer(5)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().SetParent(gomock.Any(), args.userId, args.itemId, args.input).Return(domain.ErrSubtaskTooDeep)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the subtasks are nested too deep\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Invalid JSON",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"parent_id": "3"}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: json: cannot unmarshal string into Go struct field TodoItemParentInput.parent_id of type int\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Service Failure",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"parent_id": 3}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemParentInput{ParentId: intPointer(3)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().SetParent(gomock.Any(), args.userId, args.itemId, args.input).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to set the parent of a todo-item: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockTodoItemService := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(mockTodoItemService, test.input)
+
+			setEnv(test.enviroment)
+
+			cfg, err := config.Init(configPath)
+			if err != nil {
+				t.Errorf("config initializing failed: %s", err.Error())
+				return
+			}
+
+			tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			token, err := tokenManager.NewJWT(strconv.Itoa(test.input.userId), test.jwtTTL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			<-time.After(test.delay)
+
+			services := service.Service{TodoItem: mockTodoItemService}
+			h := NewHandler(&services, tokenManager, cfg.Auth.JWT)
+
+			router := mux.NewRouter()
+			putRouter := router.Methods(http.MethodPut).Subrouter()
+			putRouter.HandleFunc("/api/items/{id:[0-9]+}/parent", h.setItemParent)
+			putRouter.Use(h.userIdentity)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/items/%d/parent", test.input.itemId), bytes.NewBufferString(test.inputRequestBody))
+			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
+			router.ServeHTTP(w, r)                              // perform request
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func boolPointer(s bool) *bool {
+	return &s
+}
+
+func intPointer(i int) *int {
+	return &i
+}
+
+func datePointer(d domain.Date) *domain.Date {
+	return &d
+}
+
+func timeOfDayPointer(t domain.TimeOfDay) *domain.TimeOfDay {
+	return &t
 }
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -59,10 +60,12 @@ func (h *Handler) createList(w http.ResponseWriter, r *http.Request) {
 // @Summary Get All Lists
 // @Security ApiKeyAuth
 // @Tags lists
-// @Description get all todo-lists
+// @Description get all todo-lists, the pinned ones first, or a page of them
 // @ID get-all-lists
 // @Accept  json
 // @Produce  json
+// @Param limit query int false "page size, 1 to 100, 20 by default when a cursor is given"
+// @Param cursor query string false "next_cursor of the previous page"
 // @Success 200 {object} GetTodoListsResponse
 // @Failure 400,404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -72,18 +75,28 @@ func (h *Handler) getLists(w http.ResponseWriter, r *http.Request) {
 
 	userId := h.getUserId(w, r)
 
+	page, err := queryCursorPage(r.URL.Query())
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	todolists, err := h.services.TodoList.GetByUserId(ctx, userId)
+	todolists, nextCursor, err := h.services.TodoList.GetByUserId(ctx, userId, page)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCursorPage) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
 		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable find any todo list by user id"))
 		return
 	}
 
 	h.writeResponseHeader(w, http.StatusOK)
 
-	if err := json.NewEncoder(w).Encode(GetTodoListsResponse{Data: todolists}); err != nil {
+	if err := json.NewEncoder(w).Encode(GetTodoListsResponse{Data: todolists, NextCursor: nextCursor}); err != nil {
 		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable encode response data"))
 		return
 	}
@@ -157,6 +170,11 @@ func (h *Handler) updateListByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := h.services.TodoList.ValidateUpdate(updateTodoListInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
@@ -210,3 +228,185 @@ func (h *Handler) deleteListByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// @Summary Restore todo-list by Id
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description restore todo-list from the trash
+// @ID restore-list-by-id
+// @Accept json
+// @Produce json
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/lists/:id/restore [post]
+func (h *Handler) restoreListByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	todoListId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todolist id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TodoList.Restore(ctx, userId, todoListId); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to restore a todolist by id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable encode response data"))
+		return
+	}
+}
+
+// @Summary Move todo-list
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description place todo-list between the given neighbours
+// @ID move-list-by-id
+// @Accept json
+// @Produce json
+// @Param input body domain.MoveInput true "anchors"
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/lists/:id/move [post]
+func (h *Handler) moveListByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	todoListId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todolist id"))
+		return
+	}
+
+	var moveInput domain.MoveInput
+	if err := json.NewDecoder(r.Body).Decode(&moveInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TodoList.Move(ctx, userId, todoListId, moveInput); err != nil {
+		if errors.Is(err, domain.ErrInvalidMove) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to move a todolist"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable encode response data"))
+		return
+	}
+}
+
+// @Summary Duplicate todo-list
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description copy todo-list together with all its items
+// @ID duplicate-list-by-id
+// @Accept json
+// @Produce json
+// @Param input body domain.CopyTodoListInput false "copy options"
+// @Success 200 {object} domain.TodoList
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/lists/:id/duplicate [post]
+func (h *Handler) duplicateListByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	todoListId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todolist id"))
+		return
+	}
+
+	var copyInput domain.CopyTodoListInput
+	if err := json.NewDecoder(r.Body).Decode(&copyInput); err != nil && err != io.EOF {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	listId, err := h.services.TodoList.Duplicate(ctx, userId, todoListId, copyInput)
+	if err != nil {
+		if errors.Is(err, domain.ErrWipLimit) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to duplicate a todolist"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(domain.TodoList{Id: listId}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable encode response data"))
+		return
+	}
+}
+
+// @Summary Put todo-list into folder
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description put todo-list into the folder, without folder_id the list is moved to the top level
+// @ID set-list-folder
+// @Accept json
+// @Produce json
+// @Param input body domain.TodoListFolderInput true "folder"
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/lists/:id/folder [put]
+func (h *Handler) setListFolder(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	todoListId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todolist id"))
+		return
+	}
+
+	var folderInput domain.TodoListFolderInput
+	if err := json.NewDecoder(r.Body).Decode(&folderInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TodoList.SetFolder(ctx, userId, todoListId, folderInput); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to put a todolist into the folder"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable encode response data"))
+		return
+	}
+}
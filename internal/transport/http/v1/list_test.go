@@ -264,6 +264,7 @@ func TestHandler_getLists(t *testing.T) {
 
 		args struct {
 			userId int
+			query  string
 		}
 
 		mockBehavior func(s *mock_service.MockTodoList, args args)
@@ -319,7 +320,7 @@ func TestHandler_getLists(t *testing.T) {
 				todoLists := []domain.TodoList{
 					{Id: 1, Title: "title1", Description: "description1"},
 				}
-				s.EXPECT().GetByUserId(gomock.Any(), args.userId).Return(todoLists, nil)
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId, domain.CursorPage{}).Return(todoLists, "", nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: "{\"data\":[{\"id\":1,\"title\":\"title1\",\"description\":\"description1\"}]}\n",
@@ -346,11 +347,95 @@ func TestHandler_getLists(t *testing.T) {
 			},
 			mockBehavior: func(s *mock_service.MockTodoList, args args) {
 				todoLists := []domain.TodoList{}
-				s.EXPECT().GetByUserId(gomock.Any(), args.userId).Return(todoLists, nil)
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId, domain.CursorPage{}).Return(todoLists, "", nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: "{\"data\":[]}\n",
 		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Paged",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId: 1,
+				query:  "?limit=1&cursor=eyJpZCI6MX0",
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				todoLists := []domain.TodoList{
+					{Id: 2, Title: "title2", Description: "description2"},
+				}
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId, domain.CursorPage{Limit: 1, Cursor: "eyJpZCI6MX0"}).Return(todoLists, "eyJpZCI6Mn0", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":2,\"title\":\"title2\",\"description\":\"description2\"}],\"next_cursor\":\"eyJpZCI6Mn0\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Invalid Limit",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId: 1,
+				query:  "?limit=ten",
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the limit must be between 1 and 100 and the cursor must come from a previous page\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:   "Invalid Cursor",
+			jwtTTL: time.Duration(5 * time.Minute),
+			delay:  time.Duration(0 * time.Millisecond),
+			input: args{
+				userId: 1,
+				query:  "?cursor=bogus",
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId, domain.CursorPage{Cursor: "bogus"}).Return(nil, "", domain.ErrInvalidCursorPage)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the limit must be between 1 and 100 and the cursor must come from a previous page\"}",
+		},
 		{
 			enviroment: enviroment{
 				appEnv:               "local",
@@ -419,7 +504,7 @@ func TestHandler_getLists(t *testing.T) {
 			getRouter.Use(h.userIdentity)
 
 			w := httptest.NewRecorder()
-			r := httptest.NewRequest(http.MethodGet, "/api/lists", bytes.NewBufferString(""))
+			r := httptest.NewRequest(http.MethodGet, "/api/lists"+test.input.query, bytes.NewBufferString(""))
 			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
 			router.ServeHTTP(w, r)                              // perforn request
 
@@ -694,7 +779,10 @@ func TestHandler_updateListByID(t *testing.T) {
 				},
 			},
 			mockBehavior: func(s *mock_service.MockTodoList, args args) {
-				s.EXPECT().Update(gomock.Any(), args.userId, args.todoListId, args.updateTodoListInput).Return(nil)
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoListInput).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.todoListId, args.updateTodoListInput).Return(nil),
+				)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: "{\"status\":\"success\"}\n",
@@ -725,7 +813,10 @@ func TestHandler_updateListByID(t *testing.T) {
 				},
 			},
 			mockBehavior: func(s *mock_service.MockTodoList, args args) {
-				s.EXPECT().Update(gomock.Any(), args.userId, args.todoListId, args.updateTodoListInput).Return(nil)
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoListInput).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.todoListId, args.updateTodoListInput).Return(nil),
+				)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: "{\"status\":\"success\"}\n",
@@ -756,11 +847,83 @@ func TestHandler_updateListByID(t *testing.T) {
 				},
 			},
 			mockBehavior: func(s *mock_service.MockTodoList, args args) {
-				s.EXPECT().Update(gomock.Any(), args.userId, args.todoListId, args.updateTodoListInput).Return(nil)
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoListInput).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.todoListId, args.updateTodoListInput).Return(nil),
+				)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Appearance",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"color": "#00ff7f", "icon": "🛒", "pinned": true}`,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				updateTodoListInput: domain.UpdateTodoListInput{
+					Color:  stringPointer("#00ff7f"),
+					Icon:   stringPointer("🛒"),
+					Pinned: boolPointer(true),
+				},
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoListInput).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.todoListId, args.updateTodoListInput).Return(nil),
+				)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: "{\"status\":\"success\"}\n",
 		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Invalid Color",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"color": "red"}`,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				updateTodoListInput: domain.UpdateTodoListInput{
+					Color: stringPointer("red"),
+				},
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.updateTodoListInput).Return(errors.New("Color: regular expression mismatch")),
+				)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"Color: regular expression mismatch\"}",
+		},
 		{
 			enviroment: enviroment{
 				appEnv:               "local",
@@ -1003,6 +1166,762 @@ func TestHandler_deleteListByID(t *testing.T) {
 	}
 }
 
+func TestHandler_restoreListByID(t *testing.T) {
+	type (
+		enviroment struct {
+			appEnv               string
+			httpHost             string
+			httpPort             string
+			postgresHost         string
+			postgresPort         string
+			postgresDatabaseName string
+			postgresUsername     string
+			postgresPassword     string
+			postgressSSLMode     string
+			passwordSalt         string
+			jwtSigningKey        string
+		}
+
+		args struct {
+			userId     int
+			todoListId int
+		}
+
+		mockBehavior func(s *mock_service.MockTodoList, args args)
+
+		test struct {
+			enviroment           enviroment
+			name                 string
+			jwtTTL               time.Duration
+			delay                time.Duration
+			inputRequestBody     string
+			input                args
+			mockBehavior         mockBehavior
+			expectedStatusCode   int
+			expectedResponseBody string
+		}
+	)
+
+	setEnv := func(env enviroment) {
+		os.Setenv(config.ApplicationEnvironment, env.appEnv)
+		os.Setenv(config.HttpHost, env.httpHost)
+		os.Setenv(config.HttpPort, env.httpPort)
+		os.Setenv(config.PostgresHost, env.postgresHost)
+		os.Setenv(config.PostgresPort, env.postgresPort)
+		os.Setenv(config.PostgresDatabaseName, env.postgresDatabaseName)
+		os.Setenv(config.PostgresUsername, env.postgresUsername)
+		os.Setenv(config.PostgresPassword, env.postgresPassword)
+		os.Setenv(config.PostgresSSLMode, env.postgressSSLMode)
+		os.Setenv(config.PasswordSalt, env.passwordSalt)
+		os.Setenv(config.JwtSigningKey, env.jwtSigningKey)
+	}
+
+	tests := []test{
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: ``,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().Restore(gomock.Any(), args.userId, args.todoListId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Service Failure",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: ``,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().Restore(gomock.Any(), args.userId, args.todoListId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to restore a todolist by id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockTodoListService := mock_service.NewMockTodoList(controller)
+			test.mockBehavior(mockTodoListService, test.input)
+
+			setEnv(test.enviroment)
+
+			cfg, err := config.Init(configPath)
+			if err != nil {
+				t.Errorf("config initializing failed: %s", err.Error())
+				return
+			}
+
+			tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			token, err := tokenManager.NewJWT(strconv.Itoa(test.input.userId), test.jwtTTL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			<-time.After(test.delay)
+
+			services := service.Service{TodoList: mockTodoListService}
+			h := NewHandler(&services, tokenManager, cfg.Auth.JWT)
+
+			router := mux.NewRouter()
+			postRouter := router.Methods(http.MethodPost).Subrouter()
+			postRouter.HandleFunc("/api/lists/{id:[0-9]+}/restore", h.restoreListByID)
+			postRouter.Use(h.userIdentity)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/lists/%d/restore", test.input.todoListId), bytes.NewBufferString(test.inputRequestBody))
+			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
+			router.ServeHTTP(w, r)                              // perform request
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_moveListByID(t *testing.T) {
+	type (
+		enviroment struct {
+			appEnv               string
+			httpHost             string
+			httpPort             string
+			postgresHost         string
+			postgresPort         string
+			postgresDatabaseName string
+			postgresUsername     string
+			postgresPassword     string
+			postgressSSLMode     string
+			passwordSalt         string
+			jwtSigningKey        string
+		}
+
+		args struct {
+			userId     int
+			todoListId int
+			input      domain.MoveInput
+		}
+
+		mockBehavior func(s *mock_service.MockTodoList, args args)
+
+		test struct {
+			enviroment           enviroment
+			name                 string
+			jwtTTL               time.Duration
+			delay                time.Duration
+			inputRequestBody     string
+			input                args
+			mockBehavior         mockBehavior
+			expectedStatusCode   int
+			expectedResponseBody string
+		}
+	)
+
+	setEnv := func(env enviroment) {
+		os.Setenv(config.ApplicationEnvironment, env.appEnv)
+		os.Setenv(config.HttpHost, env.httpHost)
+		os.Setenv(config.HttpPort, env.httpPort)
+		os.Setenv(config.PostgresHost, env.postgresHost)
+		os.Setenv(config.PostgresPort, env.postgresPort)
+		os.Setenv(config.PostgresDatabaseName, env.postgresDatabaseName)
+		os.Setenv(config.PostgresUsername, env.postgresUsername)
+		os.Setenv(config.PostgresPassword, env.postgresPassword)
+		os.Setenv(config.PostgresSSLMode, env.postgressSSLMode)
+		os.Setenv(config.PasswordSalt, env.passwordSalt)
+		os.Setenv(config.JwtSigningKey, env.jwtSigningKey)
+	}
+
+	tests := []test{
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"after_id": 3}`,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				input:      domain.MoveInput{AfterId: intPointer(3)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.todoListId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Invalid Anchors",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"after_id": 2}`,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				input:      domain.MoveInput{AfterId: intPointer(2)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.todoListId, args.input).Return(domain.ErrInvalidMove)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"invalid move anchors\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Invalid JSON",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"after_id": "3"}`,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: json: cannot unmarshal string into Go struct field MoveInput.after_id of type int\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockTodoListService := mock_service.NewMockTodoList(controller)
+			test.mockBehavior(mockTodoListService, test.input)
+
+			setEnv(test.enviroment)
+
+			cfg, err := config.Init(configPath)
+			if err != nil {
+				t.Errorf("config initializing failed: %s", err.Error())
+				return
+			}
+
+			tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			token, err := tokenManager.NewJWT(strconv.Itoa(test.input.userId), test.jwtTTL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			<-time.After(test.delay)
+
+			services := service.Service{TodoList: mockTodoListService}
+			h := NewHandler(&services, tokenManager, cfg.Auth.JWT)
+
+			router := mux.NewRouter()
+			postRouter := router.Methods(http.MethodPost).Subrouter()
+			postRouter.HandleFunc("/api/lists/{id:[0-9]+}/move", h.moveListByID)
+			postRouter.Use(h.userIdentity)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/lists/%d/move", test.input.todoListId), bytes.NewBufferString(test.inputRequestBody))
+			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
+			router.ServeHTTP(w, r)                              // perform request
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_duplicateListByID(t *testing.T) {
+	type (
+		enviroment struct {
+			appEnv               string
+			httpHost             string
+			httpPort             string
+			postgresHost         string
+			postgresPort         string
+			postgresDatabaseName string
+			postgresUsername     string
+			postgresPassword     string
+			postgressSSLMode     string
+			passwordSalt         string
+			jwtSigningKey        string
+		}
+
+		args struct {
+			userId     int
+			todoListId int
+			input      domain.CopyTodoListInput
+		}
+
+		mockBehavior func(s *mock_service.MockTodoList, args args)
+
+		test struct {
+			enviroment           enviroment
+			name                 string
+			jwtTTL               time.Duration
+			delay                time.Duration
+			inputRequestBody     string
+			input                args
+			mockBehavior         mockBehavior
+			expectedStatusCode   int
+			expectedResponseBody string
+		}
+	)
+
+	setEnv := func(env enviroment) {
+		os.Setenv(config.ApplicationEnvironment, env.appEnv)
+		os.Setenv(config.HttpHost, env.httpHost)
+		os.Setenv(config.HttpPort, env.httpPort)
+		os.Setenv(config.PostgresHost, env.postgresHost)
+		os.Setenv(config.PostgresPort, env.postgresPort)
+		os.Setenv(config.PostgresDatabaseName, env.postgresDatabaseName)
+		os.Setenv(config.PostgresUsername, env.postgresUsername)
+		os.Setenv(config.PostgresPassword, env.postgresPassword)
+		os.Setenv(config.PostgresSSLMode, env.postgressSSLMode)
+		os.Setenv(config.PasswordSalt, env.passwordSalt)
+		os.Setenv(config.JwtSigningKey, env.jwtSigningKey)
+	}
+
+	tests := []test{
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"title": "copy", "reset_done": true}`,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				input:      domain.CopyTodoListInput{Title: stringPointer("copy"), ResetDone: true},
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().Duplicate(gomock.Any(), args.userId, args.todoListId, args.input).Return(3, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":3}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK_EmptyBody",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: ``,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().Duplicate(gomock.Any(), args.userId, args.todoListId, args.input).Return(3, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":3}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Service Failure",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{}`,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().Duplicate(gomock.Any(), args.userId, args.todoListId, args.input).Return(0, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to duplicate a todolist: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockTodoListService := mock_service.NewMockTodoList(controller)
+			test.mockBehavior(mockTodoListService, test.input)
+
+			setEnv(test.enviroment)
+
+			cfg, err := config.Init(configPath)
+			if err != nil {
+				t.Errorf("config initializing failed: %s", err.Error())
+				return
+			}
+
+			tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			token, err := tokenManager.NewJWT(strconv.Itoa(test.input.userId), test.jwtTTL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			<-time.After(test.delay)
+
+			services := service.Service{TodoList: mockTodoListService}
+			h := NewHandler(&services, tokenManager, cfg.Auth.JWT)
+
+			router := mux.NewRouter()
+			postRouter := router.Methods(http.MethodPost).Subrouter()
+			postRouter.HandleFunc("/api/lists/{id:[0-9]+}/duplicate", h.duplicateListByID)
+			postRouter.Use(h.userIdentity)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/lists/%d/duplicate", test.input.todoListId), bytes.NewBufferString(test.inputRequestBody))
+			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
+			router.ServeHTTP(w, r)                              // perform request
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_setListFolder(t *testing.T) {
+	type (
+		enviroment struct {
+			appEnv               string
+			httpHost             string
+			httpPort             string
+			postgresHost         string
+			postgresPort         string
+			postgresDatabaseName string
+			postgresUsername     string
+			postgresPassword     string
+			postgressSSLMode     string
+			passwordSalt         string
+			jwtSigningKey        string
+		}
+
+		args struct {
+			userId     int
+			todoListId int
+			input      domain.TodoListFolderInput
+		}
+
+		mockBehavior func(s *mock_service.MockTodoList, args args)
+
+		test struct {
+			enviroment           enviroment
+			name                 string
+			jwtTTL               time.Duration
+			delay                time.Duration
+			inputRequestBody     string
+			input                args
+			mockBehavior         mockBehavior
+			expectedStatusCode   int
+			expectedResponseBody string
+		}
+	)
+
+	setEnv := func(env enviroment) {
+		os.Setenv(config.ApplicationEnvironment, env.appEnv)
+		os.Setenv(config.HttpHost, env.httpHost)
+		os.Setenv(config.HttpPort, env.httpPort)
+		os.Setenv(config.PostgresHost, env.postgresHost)
+		os.Setenv(config.PostgresPort, env.postgresPort)
+		os.Setenv(config.PostgresDatabaseName, env.postgresDatabaseName)
+		os.Setenv(config.PostgresUsername, env.postgresUsername)
+		os.Setenv(config.PostgresPassword, env.postgresPassword)
+		os.Setenv(config.PostgresSSLMode, env.postgressSSLMode)
+		os.Setenv(config.PasswordSalt, env.passwordSalt)
+		os.Setenv(config.JwtSigningKey, env.jwtSigningKey)
+	}
+
+	tests := []test{
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"folder_id": 3}`,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				input:      domain.TodoListFolderInput{FolderId: intPointer(3)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().SetFolder(gomock.Any(), args.userId, args.todoListId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "OK_TopLevel",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"folder_id": null}`,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().SetFolder(gomock.Any(), args.userId, args.todoListId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Invalid JSON",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"folder_id": "3"`,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: unexpected EOF\"}",
+		},
+		{
+			enviroment: enviroment{
+				appEnv:               "local",
+				httpHost:             "localhost",
+				httpPort:             "8080",
+				postgresHost:         "localhost",
+				postgresPort:         "5432",
+				postgresDatabaseName: "postgres",
+				postgresUsername:     "postgres",
+				postgresPassword:     "qwerty",
+				postgressSSLMode:     "disable",
+				passwordSalt:         "salt",
+				jwtSigningKey:        "key",
+			},
+			name:             "Service Failure",
+			jwtTTL:           time.Duration(5 * time.Minute),
+			delay:            time.Duration(0 * time.Millisecond),
+			inputRequestBody: `{"folder_id": 3}`,
+			input: args{
+				userId:     1,
+				todoListId: 2,
+				input:      domain.TodoListFolderInput{FolderId: intPointer(3)},
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().SetFolder(gomock.Any(), args.userId, args.todoListId, args.input).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to put a todolist into the folder: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockTodoListService := mock_service.NewMockTodoList(controller)
+			test.mockBehavior(mockTodoListService, test.input)
+
+			setEnv(test.enviroment)
+
+			cfg, err := config.Init(configPath)
+			if err != nil {
+				t.Errorf("config initializing failed: %s", err.Error())
+				return
+			}
+
+			tokenManager, err := auth.NewManager(cfg.Auth.JWT.SigningKey)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			token, err := tokenManager.NewJWT(strconv.Itoa(test.input.userId), test.jwtTTL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			<-time.After(test.delay)
+
+			services := service.Service{TodoList: mockTodoListService}
+			h := NewHandler(&services, tokenManager, cfg.Auth.JWT)
+
+			router := mux.NewRouter()
+			putRouter := router.Methods(http.MethodPut).Subrouter()
+			putRouter.HandleFunc("/api/lists/{id:[0-9]+}/folder", h.setListFolder)
+			putRouter.Use(h.userIdentity)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/lists/%d/folder", test.input.todoListId), bytes.NewBufferString(test.inputRequestBody))
+			r.Header.Set(authorizationHeader, bearer+" "+token) // set jwt token
+			router.ServeHTTP(w, r)                              // perform request
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
 func stringPointer(s string) *string {
 	return &s
 }
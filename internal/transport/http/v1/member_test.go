@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_createMember(t *testing.T) {
+	type args struct {
+		userId int
+		listId int
+		input  domain.ShareTodoListInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockMembers, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"email": "bob@example.com"}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				input:  domain.ShareTodoListInput{Email: "bob@example.com"},
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.input).Return(nil),
+					s.EXPECT().Add(gomock.Any(), args.userId, args.listId, args.input).Return(3, nil),
+				)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"user_id\":3}\n",
+		},
+		{
+			name:             "Unknown Email",
+			inputRequestBody: `{"email": "nobody@example.com"}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				input:  domain.ShareTodoListInput{Email: "nobody@example.com"},
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.input).Return(nil),
+					s.EXPECT().Add(gomock.Any(), args.userId, args.listId, args.input).Return(0, domain.ErrUserNotFound),
+				)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"there is no user with this email\"}",
+		},
+		{
+			name:             "Already A Member",
+			inputRequestBody: `{"email": "bob@example.com"}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				input:  domain.ShareTodoListInput{Email: "bob@example.com"},
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.input).Return(nil),
+					s.EXPECT().Add(gomock.Any(), args.userId, args.listId, args.input).Return(0, domain.ErrAlreadyMember),
+				)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the list is already shared with the user\"}",
+		},
+		{
+			name:             "Empty Email",
+			inputRequestBody: `{"email": ""}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				input:  domain.ShareTodoListInput{},
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+				s.EXPECT().Validate(args.input).Return(errors.New("Email: zero value"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"Email: zero value\"}",
+		},
+		{
+			name:             "Invalid JSON",
+			inputRequestBody: `{"email": 1}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: json: cannot unmarshal number into Go struct field ShareTodoListInput.email of type string\"}",
+		},
+		{
+			name:             "Foreign List",
+			inputRequestBody: `{"email": "bob@example.com"}`,
+			input: args{
+				userId: 1,
+				listId: 70,
+				input:  domain.ShareTodoListInput{Email: "bob@example.com"},
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.input).Return(nil),
+					s.EXPECT().Add(gomock.Any(), args.userId, args.listId, args.input).Return(0, sql.ErrNoRows),
+				)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to share a todo-list: sql: no rows in result set\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			members := mock_service.NewMockMembers(controller)
+			test.mockBehavior(members, test.input)
+
+			w := serveRequest(t, &service.Service{Members: members}, http.MethodPost, "/api/lists/{id:[0-9]+}/members", (*Handler).createMember,
+				fmt.Sprintf("/api/lists/%d/members", test.input.listId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getMembers(t *testing.T) {
+	type args struct {
+		userId int
+		listId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockMembers, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				listId: 7,
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+				s.EXPECT().GetByListId(gomock.Any(), args.userId, args.listId).Return([]domain.Member{
+					{UserId: 1, Name: "Alice", Email: "alice@example.com"},
+					{UserId: 3, Name: "Bob", Email: "bob@example.com"},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"user_id\":1,\"name\":\"Alice\",\"email\":\"alice@example.com\"},{\"user_id\":3,\"name\":\"Bob\",\"email\":\"bob@example.com\"}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				listId: 7,
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+				s.EXPECT().GetByListId(gomock.Any(), args.userId, args.listId).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find the members of a todo-list: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			members := mock_service.NewMockMembers(controller)
+			test.mockBehavior(members, test.input)
+
+			w := serveRequest(t, &service.Service{Members: members}, http.MethodGet, "/api/lists/{id:[0-9]+}/members", (*Handler).getMembers,
+				fmt.Sprintf("/api/lists/%d/members", test.input.listId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteMember(t *testing.T) {
+	type args struct {
+		userId   int
+		listId   int
+		memberId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockMembers, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:   1,
+				listId:   7,
+				memberId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+				s.EXPECT().Remove(gomock.Any(), args.userId, args.listId, args.memberId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "OK_Leave",
+			inputRequestBody: ``,
+			input: args{
+				userId:   3,
+				listId:   7,
+				memberId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+				s.EXPECT().Remove(gomock.Any(), args.userId, args.listId, args.memberId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Last Member",
+			inputRequestBody: ``,
+			input: args{
+				userId:   1,
+				listId:   7,
+				memberId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+				s.EXPECT().Remove(gomock.Any(), args.userId, args.listId, args.memberId).Return(domain.ErrLastMember)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the last member can not leave the list\"}",
+		},
+		{
+			name:             "Not A Member",
+			inputRequestBody: ``,
+			input: args{
+				userId:   1,
+				listId:   7,
+				memberId: 9,
+			},
+			mockBehavior: func(s *mock_service.MockMembers, args args) {
+				s.EXPECT().Remove(gomock.Any(), args.userId, args.listId, args.memberId).Return(sql.ErrNoRows)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to remove a member: sql: no rows in result set\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			members := mock_service.NewMockMembers(controller)
+			test.mockBehavior(members, test.input)
+
+			w := serveRequest(t, &service.Service{Members: members}, http.MethodDelete, "/api/lists/{id:[0-9]+}/members/{userId:[0-9]+}", (*Handler).deleteMember,
+				fmt.Sprintf("/api/lists/%d/members/%d", test.input.listId, test.input.memberId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+)
+
+// queryPage reads the limit and the offset of a page from the query parameters, both are optional.
+func queryPage(query url.Values) (domain.Page, error) {
+
+	var (
+		page domain.Page
+		err  error
+	)
+
+	if limit := query.Get("limit"); limit != "" {
+		if page.Limit, err = strconv.Atoi(limit); err != nil || page.Limit == 0 {
+			return domain.Page{}, domain.ErrInvalidPage
+		}
+	}
+
+	if offset := query.Get("offset"); offset != "" {
+		if page.Offset, err = strconv.Atoi(offset); err != nil {
+			return domain.Page{}, domain.ErrInvalidPage
+		}
+	}
+
+	return page, nil
+}
+
+// queryCursorPage reads the limit and the cursor of a page from the query parameters, both are optional.
+func queryCursorPage(query url.Values) (domain.CursorPage, error) {
+
+	page := domain.CursorPage{Cursor: query.Get("cursor")}
+
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		if page.Limit, err = strconv.Atoi(limit); err != nil || page.Limit == 0 {
+			return domain.CursorPage{}, domain.ErrInvalidCursorPage
+		}
+	}
+
+	return page, nil
+}
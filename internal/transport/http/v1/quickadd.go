@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"gopkg.in/validator.v2"
+)
+
+// @Summary Quick-add todo-item
+// @Security ApiKeyAuth
+// @Tags items
+// @Description create todo-item from a line of text, reading the due date and time in the timezone of the user, #tags, !priority and "every ..." recurrence out of it; a dry run only returns what was recognised
+// @ID quick-add-item
+// @Accept json
+// @Produce json
+// @Param input body domain.QuickAddInput true "item text"
+// @Param dry_run query bool false "preview the item without creating it"
+// @Success 200 {object} domain.QuickAdd
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/lists/:id/items/quick [post]
+func (h *Handler) quickAddItem(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	listId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-list id"))
+		return
+	}
+
+	var dryRun bool
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "dry_run must be true or false"))
+			return
+		}
+	}
+
+	var input domain.QuickAddInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	if err := h.services.QuickAdd.Validate(input); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, err := h.services.QuickAdd.Add(ctx, userId, listId, input, dryRun)
+	if err != nil {
+		if isQuickAddError(err) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to quick-add a todo-item"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// isQuickAddError tells whether the item read out of the text was not valid,
+// for example when nothing but a date was given and the title is empty.
+func isQuickAddError(err error) bool {
+	var invalid validator.ErrorMap
+	return errors.As(err, &invalid) || errors.Is(err, domain.ErrDueTimeWithoutDate) || errors.Is(err, domain.ErrRRuleWithoutDate) ||
+		errors.Is(err, domain.ErrInvalidDate) || errors.Is(err, domain.ErrInvalidTimeOfDay) || errors.Is(err, domain.ErrInvalidPriority)
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+	"gopkg.in/validator.v2"
+)
+
+func TestHandler_quickAddItem(t *testing.T) {
+	type args struct {
+		userId int
+		listId int
+		query  string
+		dryRun bool
+		input  domain.QuickAddInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockQuickAdd, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"text":"Pay rent tomorrow 9am #home"}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				query:  "",
+				dryRun: false,
+				input:  domain.QuickAddInput{Text: "Pay rent tomorrow 9am #home"},
+			},
+			mockBehavior: func(s *mock_service.MockQuickAdd, args args) {
+				s.EXPECT().Validate(args.input).Return(nil)
+				s.EXPECT().Add(gomock.Any(), args.userId, args.listId, args.input, args.dryRun).Return(domain.QuickAdd{
+					Item: domain.TodoItem{Id: 3, ListId: 7, Title: "Pay rent", DueDate: "2024-03-07", DueTime: "09:00", Tags: []domain.Tag{{Id: 2, Name: "home"}}},
+					Recognised: []domain.QuickAddToken{
+						{Text: "tomorrow", Kind: "date", Value: "2024-03-07"},
+						{Text: "9am", Kind: "time", Value: "09:00"},
+						{Text: "#home", Kind: "tag", Value: "home"},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"item\":{\"id\":3,\"list_id\":7,\"title\":\"Pay rent\",\"due_date\":\"2024-03-07\",\"due_time\":\"09:00\",\"tags\":[{\"id\":2,\"name\":\"home\"}]},\"recognised\":[{\"text\":\"tomorrow\",\"kind\":\"date\",\"value\":\"2024-03-07\"},{\"text\":\"9am\",\"kind\":\"time\",\"value\":\"09:00\"},{\"text\":\"#home\",\"kind\":\"tag\",\"value\":\"home\"}]}\n",
+		},
+		{
+			name:             "Dry Run",
+			inputRequestBody: `{"text":"Pay rent tomorrow 9am #home"}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				query:  "?dry_run=true",
+				dryRun: true,
+				input:  domain.QuickAddInput{Text: "Pay rent tomorrow 9am #home"},
+			},
+			mockBehavior: func(s *mock_service.MockQuickAdd, args args) {
+				s.EXPECT().Validate(args.input).Return(nil)
+				s.EXPECT().Add(gomock.Any(), args.userId, args.listId, args.input, args.dryRun).Return(domain.QuickAdd{
+					Item: domain.TodoItem{Id: 0, ListId: 7, Title: "Pay rent", DueDate: "2024-03-07", DueTime: "09:00", Tags: []domain.Tag{{Id: 0, Name: "home"}}},
+					Recognised: []domain.QuickAddToken{
+						{Text: "tomorrow", Kind: "date", Value: "2024-03-07"},
+						{Text: "9am", Kind: "time", Value: "09:00"},
+						{Text: "#home", Kind: "tag", Value: "home"},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"item\":{\"list_id\":7,\"title\":\"Pay rent\",\"due_date\":\"2024-03-07\",\"due_time\":\"09:00\",\"tags\":[{\"name\":\"home\"}]},\"recognised\":[{\"text\":\"tomorrow\",\"kind\":\"date\",\"value\":\"2024-03-07\"},{\"text\":\"9am\",\"kind\":\"time\",\"value\":\"09:00\"},{\"text\":\"#home\",\"kind\":\"tag\",\"value\":\"home\"}]}\n",
+		},
+		{
+			name:             "Invalid Dry Run",
+			inputRequestBody: `{"text":"Pay rent tomorrow 9am #home"}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				query:  "?dry_run=maybe",
+				dryRun: false,
+				input:  domain.QuickAddInput{Text: "Pay rent tomorrow 9am #home"},
+			},
+			mockBehavior: func(s *mock_service.MockQuickAdd, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"dry_run must be true or false: strconv.ParseBool: parsing \"maybe\": invalid syntax\"}",
+		},
+		{
+			name:             "Invalid JSON",
+			inputRequestBody: `{"text":`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				query:  "",
+				dryRun: false,
+				input:  domain.QuickAddInput{Text: "Pay rent tomorrow 9am #home"},
+			},
+			mockBehavior: func(s *mock_service.MockQuickAdd, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: unexpected EOF\"}",
+		},
+		{
+			name:             "Empty Text",
+			inputRequestBody: `{"text":""}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				query:  "",
+				dryRun: false,
+				input:  domain.QuickAddInput{},
+			},
+			mockBehavior: func(s *mock_service.MockQuickAdd, args args) {
+				s.EXPECT().Validate(args.input).Return(validator.ErrorMap{"Text": validator.ErrorArray{validator.ErrZeroValue}})
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"Text: zero value\"}",
+		},
+		{
+			name:             "Only A Date",
+			inputRequestBody: `{"text":"tomorrow"}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				query:  "",
+				dryRun: false,
+				input:  domain.QuickAddInput{Text: "tomorrow"},
+			},
+			mockBehavior: func(s *mock_service.MockQuickAdd, args args) {
+				s.EXPECT().Validate(args.input).Return(nil)
+				s.EXPECT().Add(gomock.Any(), args.userId, args.listId, args.input, args.dryRun).Return(domain.QuickAdd{}, validator.ErrorMap{"Title": validator.ErrorArray{validator.ErrZeroValue}})
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"Title: zero value\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{"text":"Pay rent tomorrow 9am #home"}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				query:  "",
+				dryRun: false,
+				input:  domain.QuickAddInput{Text: "Pay rent tomorrow 9am #home"},
+			},
+			mockBehavior: func(s *mock_service.MockQuickAdd, args args) {
+				s.EXPECT().Validate(args.input).Return(nil)
+				s.EXPECT().Add(gomock.Any(), args.userId, args.listId, args.input, args.dryRun).Return(domain.QuickAdd{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to quick-add a todo-item: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			quickAdd := mock_service.NewMockQuickAdd(controller)
+			test.mockBehavior(quickAdd, test.input)
+
+			w := serveRequest(t, &service.Service{QuickAdd: quickAdd}, http.MethodPost, "/api/lists/{id:[0-9]+}/items/quick", (*Handler).quickAddItem,
+				fmt.Sprintf("/api/lists/%d/items/quick%s", test.input.listId, test.input.query), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_createReminder(t *testing.T) {
+	type args struct {
+		userId   int
+		itemId   int
+		reminder domain.Reminder
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockReminders, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"minutes_before": 30}`,
+			input: args{
+				userId:   1,
+				itemId:   2,
+				reminder: domain.Reminder{MinutesBefore: intPointer(30)},
+			},
+			mockBehavior: func(s *mock_service.MockReminders, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.reminder).Return(nil),
+					s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, args.reminder).Return(3, nil),
+				)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":3}\n",
+		},
+		{
+			name:             "Invalid Reminder",
+			inputRequestBody: `{}`,
+			input: args{
+				userId:   1,
+				itemId:   2,
+				reminder: domain.Reminder{},
+			},
+			mockBehavior: func(s *mock_service.MockReminders, args args) {
+				s.EXPECT().Validate(args.reminder).Return(domain.ErrInvalidReminder)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"a reminder needs either a time or a number of minutes before the due time\"}",
+		},
+		{
+			name:             "Invalid JSON",
+			inputRequestBody: `{"minutes_before": "30"`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockReminders, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: unexpected EOF\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{"minutes_before": 30}`,
+			input: args{
+				userId:   1,
+				itemId:   2,
+				reminder: domain.Reminder{MinutesBefore: intPointer(30)},
+			},
+			mockBehavior: func(s *mock_service.MockReminders, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.reminder).Return(nil),
+					s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, args.reminder).Return(0, errors.New("service failure")),
+				)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to create a reminder: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			reminders := mock_service.NewMockReminders(controller)
+			test.mockBehavior(reminders, test.input)
+
+			w := serveRequest(t, &service.Service{Reminders: reminders}, http.MethodPost, "/api/items/{id:[0-9]+}/reminders", (*Handler).createReminder,
+				fmt.Sprintf("/api/items/%d/reminders", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getReminders(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockReminders, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockReminders, args args) {
+				fireAt := time.Date(2026, time.March, 29, 9, 0, 0, 0, time.UTC)
+				s.EXPECT().GetByItemId(gomock.Any(), args.userId, args.itemId).Return([]domain.Reminder{{Id: 3, ItemId: 2, MinutesBefore: intPointer(30), FireAt: &fireAt}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":3,\"item_id\":2,\"minutes_before\":30,\"fire_at\":\"2026-03-29T09:00:00Z\"}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockReminders, args args) {
+				s.EXPECT().GetByItemId(gomock.Any(), args.userId, args.itemId).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find any reminders by item id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			reminders := mock_service.NewMockReminders(controller)
+			test.mockBehavior(reminders, test.input)
+
+			w := serveRequest(t, &service.Service{Reminders: reminders}, http.MethodGet, "/api/items/{id:[0-9]+}/reminders", (*Handler).getReminders,
+				fmt.Sprintf("/api/items/%d/reminders", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteReminderByID(t *testing.T) {
+	type args struct {
+		userId     int
+		reminderId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockReminders, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:     1,
+				reminderId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockReminders, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.reminderId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId:     1,
+				reminderId: 3,
+			},
+			mockBehavior: func(s *mock_service.MockReminders, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.reminderId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to delete a reminder by id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			reminders := mock_service.NewMockReminders(controller)
+			test.mockBehavior(reminders, test.input)
+
+			w := serveRequest(t, &service.Service{Reminders: reminders}, http.MethodDelete, "/api/reminders/{id:[0-9]+}", (*Handler).deleteReminderByID,
+				fmt.Sprintf("/api/reminders/%d", test.input.reminderId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_snoozeReminderByID(t *testing.T) {
+	type args struct {
+		userId     int
+		reminderId int
+		input      domain.SnoozeReminderInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockReminders, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"minutes": 10}`,
+			input: args{
+				userId:     1,
+				reminderId: 3,
+				input:      domain.SnoozeReminderInput{Minutes: 10},
+			},
+			mockBehavior: func(s *mock_service.MockReminders, args args) {
+				s.EXPECT().Snooze(gomock.Any(), args.userId, args.reminderId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Invalid Snooze",
+			inputRequestBody: `{"minutes": 0}`,
+			input: args{
+				userId:     1,
+				reminderId: 3,
+				input:      domain.SnoozeReminderInput{},
+			},
+			mockBehavior: func(s *mock_service.MockReminders, args args) {
+				s.EXPECT().Snooze(gomock.Any(), args.userId, args.reminderId, args.input).Return(domain.ErrInvalidSnooze)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"a reminder can only be snoozed for a positive number of minutes\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{"minutes": 10}`,
+			input: args{
+				userId:     1,
+				reminderId: 3,
+				input:      domain.SnoozeReminderInput{Minutes: 10},
+			},
+			mockBehavior: func(s *mock_service.MockReminders, args args) {
+				s.EXPECT().Snooze(gomock.Any(), args.userId, args.reminderId, args.input).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to snooze a reminder: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			reminders := mock_service.NewMockReminders(controller)
+			test.mockBehavior(reminders, test.input)
+
+			w := serveRequest(t, &service.Service{Reminders: reminders}, http.MethodPost, "/api/reminders/{id:[0-9]+}/snooze", (*Handler).snoozeReminderByID,
+				fmt.Sprintf("/api/reminders/%d/snooze", test.input.reminderId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -9,11 +9,57 @@ const (
 
 type (
 	GetTodoListsResponse struct {
-		Data []domain.TodoList `json:"data"`
+		Data       []domain.TodoList `json:"data"`
+		NextCursor string            `json:"next_cursor,omitempty"`
 	}
 
 	GetTodoItemResponse struct {
-		Data []domain.TodoItem `json:"data"`
+		Data       []domain.TodoItem `json:"data"`
+		NextCursor string            `json:"next_cursor,omitempty"`
+	}
+
+	GetFoldersResponse struct {
+		Data []domain.Folder `json:"data"`
+	}
+
+	GetTagsResponse struct {
+		Data []domain.Tag `json:"data"`
+	}
+
+	GetRemindersResponse struct {
+		Data []domain.Reminder `json:"data"`
+	}
+
+	GetCommentsResponse struct {
+		Data []domain.Comment `json:"data"`
+	}
+
+	GetAttachmentsResponse struct {
+		Data []domain.Attachment `json:"data"`
+	}
+
+	GetStatusesResponse struct {
+		Data []domain.Status `json:"data"`
+	}
+
+	GetTimeEntriesResponse struct {
+		Data []domain.TimeEntry `json:"data"`
+	}
+
+	GetTimeReportResponse struct {
+		Data []domain.TimeReportRow `json:"data"`
+	}
+
+	GetMembersResponse struct {
+		Data []domain.Member `json:"data"`
+	}
+
+	GetAssignmentsResponse struct {
+		Data []domain.Assignment `json:"data"`
+	}
+
+	GetRevisionsResponse struct {
+		Data []domain.Revision `json:"data"`
 	}
 
 	SignInResponse struct {
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_getHistory(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTodoItem, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetRevisions(gomock.Any(), args.userId, args.itemId).Return([]domain.Revision{
+					{Id: 5, ItemId: 2, AuthorId: intPointer(1), AuthorName: stringPointer("Alice"), Changes: domain.Changes{"description": {Old: "old text", New: "new text"}}, CreatedAt: time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)},
+					{Id: 4, ItemId: 2, Changes: domain.Changes{"done": {Old: false, New: true}}, CreatedAt: time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC)},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":5,\"item_id\":2,\"author_id\":1,\"author_name\":\"Alice\",\"changes\":{\"description\":{\"old\":\"old text\",\"new\":\"new text\"}},\"created_at\":\"2024-03-01T09:00:00Z\"},{\"id\":4,\"item_id\":2,\"changes\":{\"done\":{\"old\":false,\"new\":true}},\"created_at\":\"2024-02-29T09:00:00Z\"}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().GetRevisions(gomock.Any(), args.userId, args.itemId).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find the revisions of a todo-item: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(todoItem, test.input)
+
+			w := serveRequest(t, &service.Service{TodoItem: todoItem}, http.MethodGet, "/api/items/{id:[0-9]+}/history", (*Handler).getHistory,
+				fmt.Sprintf("/api/items/%d/history", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_revertItem(t *testing.T) {
+	type args struct {
+		userId     int
+		itemId     int
+		revisionId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTodoItem, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:     1,
+				itemId:     2,
+				revisionId: 5,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Revert(gomock.Any(), args.userId, args.itemId, args.revisionId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Unknown Revision",
+			inputRequestBody: ``,
+			input: args{
+				userId:     1,
+				itemId:     2,
+				revisionId: 5,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Revert(gomock.Any(), args.userId, args.itemId, args.revisionId).Return(domain.ErrRevisionNotFound)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the item has no such revision\"}",
+		},
+		{
+			name:             "Blocked",
+			inputRequestBody: ``,
+			input: args{
+				userId:     1,
+				itemId:     2,
+				revisionId: 5,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Revert(gomock.Any(), args.userId, args.itemId, args.revisionId).Return(domain.ErrItemBlocked)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the item can not be done while it is blocked by open items\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId:     1,
+				itemId:     2,
+				revisionId: 5,
+			},
+			mockBehavior: func(s *mock_service.MockTodoItem, args args) {
+				s.EXPECT().Revert(gomock.Any(), args.userId, args.itemId, args.revisionId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to revert a todo-item: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(controller)
+			test.mockBehavior(todoItem, test.input)
+
+			w := serveRequest(t, &service.Service{TodoItem: todoItem}, http.MethodPost, "/api/items/{id:[0-9]+}/revert/{revision:[0-9]+}", (*Handler).revertItem,
+				fmt.Sprintf("/api/items/%d/revert/%d", test.input.itemId, test.input.revisionId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,601 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_createStatus(t *testing.T) {
+	type args struct {
+		userId int
+		listId int
+		status domain.Status
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockStatuses, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"name":"review","wip_limit":3}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				status: domain.Status{Name: "review", WipLimit: intPointer(3)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().Validate(args.status).Return(nil)
+				s.EXPECT().Create(gomock.Any(), args.userId, args.listId, args.status).Return(4, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":4}\n",
+		},
+		{
+			name:             "Invalid WIP Limit",
+			inputRequestBody: `{"name":"review","wip_limit":0}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				status: domain.Status{Name: "review", WipLimit: intPointer(0)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().Validate(args.status).Return(domain.ErrInvalidWipLimit)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the WIP limit must be positive\"}",
+		},
+		{
+			name:             "Duplicate Name",
+			inputRequestBody: `{"name":"review","wip_limit":3}`,
+			input: args{
+				userId: 1,
+				listId: 7,
+				status: domain.Status{Name: "review", WipLimit: intPointer(3)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().Validate(args.status).Return(nil)
+				s.EXPECT().Create(gomock.Any(), args.userId, args.listId, args.status).Return(0, domain.ErrStatusExists)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the list already has a status with this name\"}",
+		},
+		{
+			name:             "Invalid JSON",
+			inputRequestBody: `{"name":`,
+			input: args{
+				userId: 1,
+				listId: 7,
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: unexpected EOF\"}",
+		},
+		{
+			name:             "Foreign List",
+			inputRequestBody: `{"name":"review","wip_limit":3}`,
+			input: args{
+				userId: 1,
+				listId: 70,
+				status: domain.Status{Name: "review", WipLimit: intPointer(3)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().Validate(args.status).Return(nil)
+				s.EXPECT().Create(gomock.Any(), args.userId, args.listId, args.status).Return(0, sql.ErrNoRows)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to create a status: sql: no rows in result set\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			statuses := mock_service.NewMockStatuses(controller)
+			test.mockBehavior(statuses, test.input)
+
+			w := serveRequest(t, &service.Service{Statuses: statuses}, http.MethodPost, "/api/lists/{id:[0-9]+}/statuses", (*Handler).createStatus,
+				fmt.Sprintf("/api/lists/%d/statuses", test.input.listId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getStatuses(t *testing.T) {
+	type args struct {
+		userId int
+		listId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockStatuses, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				listId: 7,
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().GetByListId(gomock.Any(), args.userId, args.listId).Return([]domain.Status{
+					{Id: 3, ListId: 7, Name: "to do", Position: "F"},
+					{Id: 4, ListId: 7, Name: "done", Done: true, Position: "V"},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":3,\"list_id\":7,\"name\":\"to do\",\"position\":\"F\"},{\"id\":4,\"list_id\":7,\"name\":\"done\",\"done\":true,\"position\":\"V\"}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				listId: 7,
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().GetByListId(gomock.Any(), args.userId, args.listId).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find any statuses by list id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			statuses := mock_service.NewMockStatuses(controller)
+			test.mockBehavior(statuses, test.input)
+
+			w := serveRequest(t, &service.Service{Statuses: statuses}, http.MethodGet, "/api/lists/{id:[0-9]+}/statuses", (*Handler).getStatuses,
+				fmt.Sprintf("/api/lists/%d/statuses", test.input.listId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_updateStatusByID(t *testing.T) {
+	type args struct {
+		userId   int
+		statusId int
+		input    domain.UpdateStatusInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockStatuses, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"name":"doing","wip_limit":0}`,
+			input: args{
+				userId:   1,
+				statusId: 4,
+				input:    domain.UpdateStatusInput{Name: stringPointer("doing"), WipLimit: intPointer(0)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().ValidateUpdate(args.input).Return(nil)
+				s.EXPECT().Update(gomock.Any(), args.userId, args.statusId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Negative WIP Limit",
+			inputRequestBody: `{"wip_limit":-1}`,
+			input: args{
+				userId:   1,
+				statusId: 4,
+				input:    domain.UpdateStatusInput{WipLimit: intPointer(-1)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().ValidateUpdate(args.input).Return(domain.ErrInvalidWipLimit)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the WIP limit must be positive\"}",
+		},
+		{
+			name:             "Duplicate Name",
+			inputRequestBody: `{"name":"doing","wip_limit":0}`,
+			input: args{
+				userId:   1,
+				statusId: 4,
+				input:    domain.UpdateStatusInput{Name: stringPointer("doing"), WipLimit: intPointer(0)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().ValidateUpdate(args.input).Return(nil)
+				s.EXPECT().Update(gomock.Any(), args.userId, args.statusId, args.input).Return(domain.ErrStatusExists)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the list already has a status with this name\"}",
+		},
+		{
+			name:             "Blocked Item",
+			inputRequestBody: `{"done":true}`,
+			input: args{
+				userId:   1,
+				statusId: 4,
+				input:    domain.UpdateStatusInput{Done: boolPointer(true)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().ValidateUpdate(args.input).Return(nil)
+				s.EXPECT().Update(gomock.Any(), args.userId, args.statusId, args.input).Return(domain.ErrItemBlocked)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the item can not be done while it is blocked by open items\"}",
+		},
+		{
+			name:             "Foreign Status",
+			inputRequestBody: `{"name":"doing","wip_limit":0}`,
+			input: args{
+				userId:   1,
+				statusId: 40,
+				input:    domain.UpdateStatusInput{Name: stringPointer("doing"), WipLimit: intPointer(0)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().ValidateUpdate(args.input).Return(nil)
+				s.EXPECT().Update(gomock.Any(), args.userId, args.statusId, args.input).Return(sql.ErrNoRows)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to update a status by id: sql: no rows in result set\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			statuses := mock_service.NewMockStatuses(controller)
+			test.mockBehavior(statuses, test.input)
+
+			w := serveRequest(t, &service.Service{Statuses: statuses}, http.MethodPut, "/api/statuses/{id:[0-9]+}", (*Handler).updateStatusByID,
+				fmt.Sprintf("/api/statuses/%d", test.input.statusId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_moveStatusByID(t *testing.T) {
+	type args struct {
+		userId   int
+		statusId int
+		input    domain.MoveInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockStatuses, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"after_id":3,"before_id":4}`,
+			input: args{
+				userId:   1,
+				statusId: 5,
+				input:    domain.MoveInput{AfterId: intPointer(3), BeforeId: intPointer(4)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.statusId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Invalid Move",
+			inputRequestBody: `{"after_id":3,"before_id":4}`,
+			input: args{
+				userId:   1,
+				statusId: 5,
+				input:    domain.MoveInput{AfterId: intPointer(3), BeforeId: intPointer(4)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.statusId, args.input).Return(domain.ErrInvalidMove)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"invalid move anchors\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{"after_id":3,"before_id":4}`,
+			input: args{
+				userId:   1,
+				statusId: 5,
+				input:    domain.MoveInput{AfterId: intPointer(3), BeforeId: intPointer(4)},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().Move(gomock.Any(), args.userId, args.statusId, args.input).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to move a status: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			statuses := mock_service.NewMockStatuses(controller)
+			test.mockBehavior(statuses, test.input)
+
+			w := serveRequest(t, &service.Service{Statuses: statuses}, http.MethodPost, "/api/statuses/{id:[0-9]+}/move", (*Handler).moveStatusByID,
+				fmt.Sprintf("/api/statuses/%d/move", test.input.statusId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteStatusByID(t *testing.T) {
+	type args struct {
+		userId   int
+		statusId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockStatuses, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:   1,
+				statusId: 4,
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.statusId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId:   1,
+				statusId: 4,
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.statusId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to delete a status by id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			statuses := mock_service.NewMockStatuses(controller)
+			test.mockBehavior(statuses, test.input)
+
+			w := serveRequest(t, &service.Service{Statuses: statuses}, http.MethodDelete, "/api/statuses/{id:[0-9]+}", (*Handler).deleteStatusByID,
+				fmt.Sprintf("/api/statuses/%d", test.input.statusId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_setItemStatus(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+		input  domain.TodoItemStatusInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockStatuses, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"status_id":4}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemStatusInput{StatusId: 4},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().SetItemStatus(gomock.Any(), args.userId, args.itemId, args.input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "WIP Limit",
+			inputRequestBody: `{"status_id":4}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemStatusInput{StatusId: 4},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().SetItemStatus(gomock.Any(), args.userId, args.itemId, args.input).Return(domain.ErrWipLimit)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the status has reached its WIP limit\"}",
+		},
+		{
+			name:             "Status Of Another List",
+			inputRequestBody: `{"status_id":4}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemStatusInput{StatusId: 4},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().SetItemStatus(gomock.Any(), args.userId, args.itemId, args.input).Return(domain.ErrInvalidStatus)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"an item can only be moved into a status of its list\"}",
+		},
+		{
+			name:             "Blocked",
+			inputRequestBody: `{"status_id":4}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+				input:  domain.TodoItemStatusInput{StatusId: 4},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().SetItemStatus(gomock.Any(), args.userId, args.itemId, args.input).Return(domain.ErrItemBlocked)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the item can not be done while it is blocked by open items\"}",
+		},
+		{
+			name:             "Foreign Item",
+			inputRequestBody: `{"status_id":4}`,
+			input: args{
+				userId: 1,
+				itemId: 20,
+				input:  domain.TodoItemStatusInput{StatusId: 4},
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().SetItemStatus(gomock.Any(), args.userId, args.itemId, args.input).Return(sql.ErrNoRows)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to set the status of a todo-item: sql: no rows in result set\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			statuses := mock_service.NewMockStatuses(controller)
+			test.mockBehavior(statuses, test.input)
+
+			w := serveRequest(t, &service.Service{Statuses: statuses}, http.MethodPut, "/api/items/{id:[0-9]+}/status", (*Handler).setItemStatus,
+				fmt.Sprintf("/api/items/%d/status", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getBoard(t *testing.T) {
+	type args struct {
+		userId int
+		listId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockStatuses, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				listId: 7,
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().GetBoard(gomock.Any(), args.userId, args.listId).Return(domain.Board{
+					Columns: []domain.BoardColumn{
+						{Status: domain.Status{Id: 3, ListId: 7, Name: "to do", Position: "F"}, Items: []domain.TodoItem{{Id: 1, Title: "title1", StatusId: intPointer(3)}}},
+						{Status: domain.Status{Id: 4, ListId: 7, Name: "done", Done: true, Position: "V"}, Items: []domain.TodoItem{}},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"columns\":[{\"status\":{\"id\":3,\"list_id\":7,\"name\":\"to do\",\"position\":\"F\"},\"items\":[{\"id\":1,\"title\":\"title1\",\"status_id\":3}]},{\"status\":{\"id\":4,\"list_id\":7,\"name\":\"done\",\"done\":true,\"position\":\"V\"},\"items\":[]}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				listId: 7,
+			},
+			mockBehavior: func(s *mock_service.MockStatuses, args args) {
+				s.EXPECT().GetBoard(gomock.Any(), args.userId, args.listId).Return(domain.Board{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to build the board of a todo-list: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			statuses := mock_service.NewMockStatuses(controller)
+			test.mockBehavior(statuses, test.input)
+
+			w := serveRequest(t, &service.Service{Statuses: statuses}, http.MethodGet, "/api/lists/{id:[0-9]+}/board", (*Handler).getBoard,
+				fmt.Sprintf("/api/lists/%d/board", test.input.listId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,435 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_createTag(t *testing.T) {
+	type args struct {
+		userId int
+		tag    domain.Tag
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTags, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"name": "@waiting", "color": "#00ff00"}`,
+			input: args{
+				userId: 1,
+				tag:    domain.Tag{Name: "@waiting", Color: "#00ff00"},
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.tag).Return(nil),
+					s.EXPECT().Create(gomock.Any(), args.userId, args.tag).Return(1, nil),
+				)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":1}\n",
+		},
+		{
+			name:             "Invalid Tag",
+			inputRequestBody: `{"name": ""}`,
+			input: args{
+				userId: 1,
+				tag:    domain.Tag{},
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				s.EXPECT().Validate(args.tag).Return(errors.New("Name: zero value"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"Name: zero value\"}",
+		},
+		{
+			name:             "Duplicate Name",
+			inputRequestBody: `{"name": "@waiting"}`,
+			input: args{
+				userId: 1,
+				tag:    domain.Tag{Name: "@waiting"},
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.tag).Return(nil),
+					s.EXPECT().Create(gomock.Any(), args.userId, args.tag).Return(0, domain.ErrTagExists),
+				)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"a tag with the same name already exists\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{"name": "@waiting"}`,
+			input: args{
+				userId: 1,
+				tag:    domain.Tag{Name: "@waiting"},
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				gomock.InOrder(
+					s.EXPECT().Validate(args.tag).Return(nil),
+					s.EXPECT().Create(gomock.Any(), args.userId, args.tag).Return(0, errors.New("service failure")),
+				)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to create a tag: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tags := mock_service.NewMockTags(controller)
+			test.mockBehavior(tags, test.input)
+
+			w := serveRequest(t, &service.Service{Tags: tags}, http.MethodPost, "/api/tags", (*Handler).createTag,
+				"/api/tags", test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getTags(t *testing.T) {
+	type args struct {
+		userId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTags, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId).Return([]domain.Tag{{Id: 2, Name: "#errand", Color: "#ff0000"}, {Id: 1, Name: "@waiting"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":2,\"name\":\"#errand\",\"color\":\"#ff0000\"},{\"id\":1,\"name\":\"@waiting\"}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				s.EXPECT().GetByUserId(gomock.Any(), args.userId).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find any tags by user id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tags := mock_service.NewMockTags(controller)
+			test.mockBehavior(tags, test.input)
+
+			w := serveRequest(t, &service.Service{Tags: tags}, http.MethodGet, "/api/tags", (*Handler).getTags,
+				"/api/tags", test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_updateTagByID(t *testing.T) {
+	type args struct {
+		userId int
+		tagId  int
+		input  domain.UpdateTagInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTags, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"name": "@later"}`,
+			input: args{
+				userId: 1,
+				tagId:  2,
+				input:  domain.UpdateTagInput{Name: stringPointer("@later")},
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.input).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.tagId, args.input).Return(nil),
+				)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Invalid Color",
+			inputRequestBody: `{"color": "red"}`,
+			input: args{
+				userId: 1,
+				tagId:  2,
+				input:  domain.UpdateTagInput{Color: stringPointer("red")},
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				s.EXPECT().ValidateUpdate(args.input).Return(errors.New("Color: regular expression mismatch"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"Color: regular expression mismatch\"}",
+		},
+		{
+			name:             "Duplicate Name",
+			inputRequestBody: `{"name": "#errand"}`,
+			input: args{
+				userId: 1,
+				tagId:  2,
+				input:  domain.UpdateTagInput{Name: stringPointer("#errand")},
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				gomock.InOrder(
+					s.EXPECT().ValidateUpdate(args.input).Return(nil),
+					s.EXPECT().Update(gomock.Any(), args.userId, args.tagId, args.input).Return(domain.ErrTagExists),
+				)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"a tag with the same name already exists\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tags := mock_service.NewMockTags(controller)
+			test.mockBehavior(tags, test.input)
+
+			w := serveRequest(t, &service.Service{Tags: tags}, http.MethodPut, "/api/tags/{id:[0-9]+}", (*Handler).updateTagByID,
+				fmt.Sprintf("/api/tags/%d", test.input.tagId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteTagByID(t *testing.T) {
+	type args struct {
+		userId int
+		tagId  int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTags, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				tagId:  2,
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.tagId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				tagId:  2,
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.tagId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to delete a tag by id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tags := mock_service.NewMockTags(controller)
+			test.mockBehavior(tags, test.input)
+
+			w := serveRequest(t, &service.Service{Tags: tags}, http.MethodDelete, "/api/tags/{id:[0-9]+}", (*Handler).deleteTagByID,
+				fmt.Sprintf("/api/tags/%d", test.input.tagId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_attachTag(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+		tagId  int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTags, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				tagId:  2,
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				s.EXPECT().Attach(gomock.Any(), args.userId, args.itemId, args.tagId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				tagId:  2,
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				s.EXPECT().Attach(gomock.Any(), args.userId, args.itemId, args.tagId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to attach a tag: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tags := mock_service.NewMockTags(controller)
+			test.mockBehavior(tags, test.input)
+
+			w := serveRequest(t, &service.Service{Tags: tags}, http.MethodPut, "/api/items/{id:[0-9]+}/tags/{tagId:[0-9]+}", (*Handler).attachTag,
+				fmt.Sprintf("/api/items/%d/tags/%d", test.input.itemId, test.input.tagId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_detachTag(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+		tagId  int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTags, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				tagId:  2,
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				s.EXPECT().Detach(gomock.Any(), args.userId, args.itemId, args.tagId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 3,
+				tagId:  2,
+			},
+			mockBehavior: func(s *mock_service.MockTags, args args) {
+				s.EXPECT().Detach(gomock.Any(), args.userId, args.itemId, args.tagId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to detach a tag: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tags := mock_service.NewMockTags(controller)
+			test.mockBehavior(tags, test.input)
+
+			w := serveRequest(t, &service.Service{Tags: tags}, http.MethodDelete, "/api/items/{id:[0-9]+}/tags/{tagId:[0-9]+}", (*Handler).detachTag,
+				fmt.Sprintf("/api/items/%d/tags/%d", test.input.itemId, test.input.tagId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// @Summary Get All Templates
+// @Security ApiKeyAuth
+// @Tags templates
+// @Description get todo-lists marked as templates
+// @ID get-all-templates
+// @Accept json
+// @Produce json
+// @Success 200 {object} GetTodoListsResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/templates [get]
+func (h *Handler) getTemplates(w http.ResponseWriter, r *http.Request) {
+
+	userId := h.getUserId(w, r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	templates, err := h.services.TodoList.GetTemplates(ctx, userId)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to get templates"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(GetTodoListsResponse{Data: templates}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Instantiate template
+// @Security ApiKeyAuth
+// @Tags templates
+// @Description create todo-list from the template
+// @ID instantiate-template
+// @Accept json
+// @Produce json
+// @Param input body domain.CopyTodoListInput false "instantiation options"
+// @Success 200 {object} domain.TodoList
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/templates/:id/instantiate [post]
+func (h *Handler) instantiateTemplate(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	templateId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a template id"))
+		return
+	}
+
+	var copyInput domain.CopyTodoListInput
+	if err := json.NewDecoder(r.Body).Decode(&copyInput); err != nil && err != io.EOF {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	listId, err := h.services.TodoList.Instantiate(ctx, userId, templateId, copyInput)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotTemplate) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to instantiate a template"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(domain.TodoList{Id: listId}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
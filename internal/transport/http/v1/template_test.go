@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_getTemplates(t *testing.T) {
+	type args struct {
+		userId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTodoList, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().GetTemplates(gomock.Any(), args.userId).Return([]domain.TodoList{{Id: 1, Title: "release", IsTemplate: true}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":1,\"title\":\"release\",\"is_template\":true}]}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			todoList := mock_service.NewMockTodoList(controller)
+			test.mockBehavior(todoList, test.input)
+
+			w := serveRequest(t, &service.Service{TodoList: todoList}, http.MethodGet, "/api/templates", (*Handler).getTemplates,
+				"/api/templates", test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_instantiateTemplate(t *testing.T) {
+	type args struct {
+		userId     int
+		templateId int
+		input      domain.CopyTodoListInput
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTodoList, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"reset_done": true}`,
+			input: args{
+				userId:     1,
+				templateId: 2,
+				input:      domain.CopyTodoListInput{ResetDone: true},
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().Instantiate(gomock.Any(), args.userId, args.templateId, args.input).Return(3, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":3}\n",
+		},
+		{
+			name:             "Not A Template",
+			inputRequestBody: ``,
+			input: args{
+				userId:     1,
+				templateId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTodoList, args args) {
+				s.EXPECT().Instantiate(gomock.Any(), args.userId, args.templateId, args.input).Return(0, domain.ErrNotTemplate)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the list is not a template\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			todoList := mock_service.NewMockTodoList(controller)
+			test.mockBehavior(todoList, test.input)
+
+			w := serveRequest(t, &service.Service{TodoList: todoList}, http.MethodPost, "/api/templates/{id:[0-9]+}/instantiate", (*Handler).instantiateTemplate,
+				fmt.Sprintf("/api/templates/%d/instantiate", test.input.templateId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
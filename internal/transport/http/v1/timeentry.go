@@ -0,0 +1,396 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// @Summary Start timer
+// @Security ApiKeyAuth
+// @Tags time
+// @Description start a timer on todo-item, the running timer of the user is stopped first
+// @ID start-timer
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.TimeEntry
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/timer [post]
+func (h *Handler) startTimer(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	entryId, err := h.services.TimeEntries.Start(ctx, userId, itemId)
+	if err != nil {
+		if errors.Is(err, domain.ErrTimerRunning) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to start a timer"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(domain.TimeEntry{Id: entryId}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Stop timer
+// @Security ApiKeyAuth
+// @Tags time
+// @Description stop the running timer of the user
+// @ID stop-timer
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.TimeEntry
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/timer/stop [post]
+func (h *Handler) stopTimer(w http.ResponseWriter, r *http.Request) {
+
+	userId := h.getUserId(w, r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	entry, err := h.services.TimeEntries.Stop(ctx, userId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoRunningTimer) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to stop a timer"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(entry); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Create time entry
+// @Security ApiKeyAuth
+// @Tags time
+// @Description add time spent on todo-item by hand
+// @ID create-time-entry
+// @Accept json
+// @Produce json
+// @Param input body domain.TimeEntry true "start and end"
+// @Success 200 {object} domain.TimeEntry
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/time-entries [post]
+func (h *Handler) createTimeEntry(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	var entry domain.TimeEntry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	if err := h.services.TimeEntries.Validate(entry); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	entryId, err := h.services.TimeEntries.Create(ctx, userId, itemId, entry)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to create a time entry"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(domain.TimeEntry{Id: entryId}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get Time Entries
+// @Security ApiKeyAuth
+// @Tags time
+// @Description get the time entries of all the members of the todo-list on todo-item, the latest first
+// @ID get-time-entries
+// @Accept json
+// @Produce json
+// @Success 200 {object} GetTimeEntriesResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/time-entries [get]
+func (h *Handler) getTimeEntries(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	entries, err := h.services.TimeEntries.GetByItemId(ctx, userId, itemId)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to find any time entries by item id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(GetTimeEntriesResponse{Data: entries}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Update time entry
+// @Security ApiKeyAuth
+// @Tags time
+// @Description move the start or the end of a time entry of the user, setting the end of a running timer stops it
+// @ID update-time-entry
+// @Accept json
+// @Produce json
+// @Param input body domain.UpdateTimeEntryInput true "start and end"
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/time-entries/:id [put]
+func (h *Handler) updateTimeEntryByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	entryId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a time entry id"))
+		return
+	}
+
+	var updateInput domain.UpdateTimeEntryInput
+	if err := json.NewDecoder(r.Body).Decode(&updateInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	if err := h.services.TimeEntries.ValidateUpdate(updateInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TimeEntries.Update(ctx, userId, entryId, updateInput); err != nil {
+		if errors.Is(err, domain.ErrInvalidTimeEntry) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to update a time entry by id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Delete time entry
+// @Security ApiKeyAuth
+// @Tags time
+// @Description delete a time entry of the user
+// @ID delete-time-entry
+// @Accept json
+// @Produce json
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/time-entries/:id [delete]
+func (h *Handler) deleteTimeEntryByID(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	entryId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a time entry id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.TimeEntries.Delete(ctx, userId, entryId); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to delete a time entry by id"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get Item Time
+// @Security ApiKeyAuth
+// @Tags time
+// @Description get the time spent on todo-item by all the members of its todo-list and its estimate
+// @ID get-item-time
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.TimeTotal
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/items/:id/time [get]
+func (h *Handler) getItemTime(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	itemId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-item id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	total, err := h.services.TimeEntries.GetItemTotal(ctx, userId, itemId)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to sum up the time of a todo-item"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(total); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get List Time
+// @Security ApiKeyAuth
+// @Tags time
+// @Description get the time spent on the todo-items of todo-list by all its members and their estimates
+// @ID get-list-time
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.TimeTotal
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/lists/:id/time [get]
+func (h *Handler) getListTime(w http.ResponseWriter, r *http.Request) {
+
+	userId, vars := h.getUserId(w, r), mux.Vars(r)
+
+	listId, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to convert a todo-list id"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	total, err := h.services.TimeEntries.GetListTotal(ctx, userId, listId)
+	if err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to sum up the time of a todo-list"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(total); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
+
+// @Summary Get Time Report
+// @Security ApiKeyAuth
+// @Tags time
+// @Description get the time the user spent per todo-list and per day of their timezone
+// @ID get-time-report
+// @Accept json
+// @Produce json
+// @Param from query string true "first day, YYYY-MM-DD"
+// @Param to query string true "last day, YYYY-MM-DD"
+// @Success 200 {object} GetTimeReportResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/time/report [get]
+func (h *Handler) getTimeReport(w http.ResponseWriter, r *http.Request) {
+
+	userId, query := h.getUserId(w, r), r.URL.Query()
+
+	filter := domain.TimeReportFilter{
+		From: domain.Date(query.Get("from")),
+		To:   domain.Date(query.Get("to")),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	rows, err := h.services.TimeEntries.GetReport(ctx, userId, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTimeReport) || errors.Is(err, domain.ErrInvalidDate) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to make a time report"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(GetTimeReportResponse{Data: rows}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
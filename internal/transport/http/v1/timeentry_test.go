@@ -0,0 +1,646 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_startTimer(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTimeEntries, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().Start(gomock.Any(), args.userId, args.itemId).Return(5, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":5,\"started_at\":\"0001-01-01T00:00:00Z\",\"duration\":0}\n",
+		},
+		{
+			name:             "Concurrent Start",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().Start(gomock.Any(), args.userId, args.itemId).Return(0, domain.ErrTimerRunning)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"another timer has just been started\"}",
+		},
+		{
+			name:             "Foreign Item",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 20,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().Start(gomock.Any(), args.userId, args.itemId).Return(0, sql.ErrNoRows)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to start a timer: sql: no rows in result set\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			timeEntries := mock_service.NewMockTimeEntries(controller)
+			test.mockBehavior(timeEntries, test.input)
+
+			w := serveRequest(t, &service.Service{TimeEntries: timeEntries}, http.MethodPost, "/api/items/{id:[0-9]+}/timer", (*Handler).startTimer,
+				fmt.Sprintf("/api/items/%d/timer", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_stopTimer(t *testing.T) {
+	type args struct {
+		userId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTimeEntries, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().Stop(gomock.Any(), args.userId).Return(domain.TimeEntry{Id: 5, ItemId: 2, UserId: 1, StartedAt: time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC)), Duration: 5400}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":5,\"item_id\":2,\"user_id\":1,\"started_at\":\"2026-04-01T09:00:00Z\",\"ended_at\":\"2026-04-01T10:30:00Z\",\"duration\":5400}\n",
+		},
+		{
+			name:             "No Running Timer",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().Stop(gomock.Any(), args.userId).Return(domain.TimeEntry{}, domain.ErrNoRunningTimer)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"there is no running timer\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			timeEntries := mock_service.NewMockTimeEntries(controller)
+			test.mockBehavior(timeEntries, test.input)
+
+			w := serveRequest(t, &service.Service{TimeEntries: timeEntries}, http.MethodPost, "/api/timer/stop", (*Handler).stopTimer,
+				"/api/timer/stop", test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_createTimeEntry(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTimeEntries, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"started_at":"2026-04-01T09:00:00Z","ended_at":"2026-04-01T10:30:00Z"}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().Validate(domain.TimeEntry{StartedAt: time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC))}).Return(nil)
+				s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, domain.TimeEntry{StartedAt: time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC))}).Return(5, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"id\":5,\"started_at\":\"0001-01-01T00:00:00Z\",\"duration\":0}\n",
+		},
+		{
+			name:             "End Before Start",
+			inputRequestBody: `{"started_at":"2026-04-01T10:30:00Z","ended_at":"2026-04-01T09:00:00Z"}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().Validate(domain.TimeEntry{StartedAt: time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC))}).Return(domain.ErrInvalidTimeEntry)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"a time entry has to end after it starts\"}",
+		},
+		{
+			name:             "Invalid JSON",
+			inputRequestBody: `{"started_at":`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the given data was not valid JSON: unexpected EOF\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: `{"started_at":"2026-04-01T09:00:00Z","ended_at":"2026-04-01T10:30:00Z"}`,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().Validate(domain.TimeEntry{StartedAt: time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC))}).Return(nil)
+				s.EXPECT().Create(gomock.Any(), args.userId, args.itemId, domain.TimeEntry{StartedAt: time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC))}).Return(0, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to create a time entry: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			timeEntries := mock_service.NewMockTimeEntries(controller)
+			test.mockBehavior(timeEntries, test.input)
+
+			w := serveRequest(t, &service.Service{TimeEntries: timeEntries}, http.MethodPost, "/api/items/{id:[0-9]+}/time-entries", (*Handler).createTimeEntry,
+				fmt.Sprintf("/api/items/%d/time-entries", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getTimeEntries(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTimeEntries, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().GetByItemId(gomock.Any(), args.userId, args.itemId).Return([]domain.TimeEntry{
+					{Id: 6, ItemId: 2, UserId: 3, StartedAt: time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC), Duration: 600},
+					{Id: 5, ItemId: 2, UserId: 1, StartedAt: time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC)), Duration: 5400},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"id\":6,\"item_id\":2,\"user_id\":3,\"started_at\":\"2026-04-01T10:30:00Z\",\"duration\":600},{\"id\":5,\"item_id\":2,\"user_id\":1,\"started_at\":\"2026-04-01T09:00:00Z\",\"ended_at\":\"2026-04-01T10:30:00Z\",\"duration\":5400}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().GetByItemId(gomock.Any(), args.userId, args.itemId).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to find any time entries by item id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			timeEntries := mock_service.NewMockTimeEntries(controller)
+			test.mockBehavior(timeEntries, test.input)
+
+			w := serveRequest(t, &service.Service{TimeEntries: timeEntries}, http.MethodGet, "/api/items/{id:[0-9]+}/time-entries", (*Handler).getTimeEntries,
+				fmt.Sprintf("/api/items/%d/time-entries", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_updateTimeEntryByID(t *testing.T) {
+	type args struct {
+		userId  int
+		entryId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTimeEntries, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: `{"ended_at":"2026-04-01T10:30:00Z"}`,
+			input: args{
+				userId:  1,
+				entryId: 5,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().ValidateUpdate(domain.UpdateTimeEntryInput{EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC))}).Return(nil)
+				s.EXPECT().Update(gomock.Any(), args.userId, args.entryId, domain.UpdateTimeEntryInput{EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC))}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "End Before Start",
+			inputRequestBody: `{"ended_at":"2026-04-01T10:30:00Z"}`,
+			input: args{
+				userId:  1,
+				entryId: 5,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().ValidateUpdate(domain.UpdateTimeEntryInput{EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC))}).Return(nil)
+				s.EXPECT().Update(gomock.Any(), args.userId, args.entryId, domain.UpdateTimeEntryInput{EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC))}).Return(domain.ErrInvalidTimeEntry)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"a time entry has to end after it starts\"}",
+		},
+		{
+			name:             "Foreign Entry",
+			inputRequestBody: `{"ended_at":"2026-04-01T10:30:00Z"}`,
+			input: args{
+				userId:  1,
+				entryId: 50,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().ValidateUpdate(domain.UpdateTimeEntryInput{EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC))}).Return(nil)
+				s.EXPECT().Update(gomock.Any(), args.userId, args.entryId, domain.UpdateTimeEntryInput{EndedAt: timePointer(time.Date(2026, time.April, 1, 10, 30, 0, 0, time.UTC))}).Return(sql.ErrNoRows)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to update a time entry by id: sql: no rows in result set\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			timeEntries := mock_service.NewMockTimeEntries(controller)
+			test.mockBehavior(timeEntries, test.input)
+
+			w := serveRequest(t, &service.Service{TimeEntries: timeEntries}, http.MethodPut, "/api/time-entries/{id:[0-9]+}", (*Handler).updateTimeEntryByID,
+				fmt.Sprintf("/api/time-entries/%d", test.input.entryId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteTimeEntryByID(t *testing.T) {
+	type args struct {
+		userId  int
+		entryId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTimeEntries, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId:  1,
+				entryId: 5,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.entryId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"success\"}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId:  1,
+				entryId: 5,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().Delete(gomock.Any(), args.userId, args.entryId).Return(errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to delete a time entry by id: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			timeEntries := mock_service.NewMockTimeEntries(controller)
+			test.mockBehavior(timeEntries, test.input)
+
+			w := serveRequest(t, &service.Service{TimeEntries: timeEntries}, http.MethodDelete, "/api/time-entries/{id:[0-9]+}", (*Handler).deleteTimeEntryByID,
+				fmt.Sprintf("/api/time-entries/%d", test.input.entryId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getItemTime(t *testing.T) {
+	type args struct {
+		userId int
+		itemId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTimeEntries, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().GetItemTotal(gomock.Any(), args.userId, args.itemId).Return(domain.TimeTotal{SpentSeconds: 5400, EstimateMinutes: 120}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"spent_seconds\":5400,\"estimate_minutes\":120}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().GetItemTotal(gomock.Any(), args.userId, args.itemId).Return(domain.TimeTotal{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to sum up the time of a todo-item: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			timeEntries := mock_service.NewMockTimeEntries(controller)
+			test.mockBehavior(timeEntries, test.input)
+
+			w := serveRequest(t, &service.Service{TimeEntries: timeEntries}, http.MethodGet, "/api/items/{id:[0-9]+}/time", (*Handler).getItemTime,
+				fmt.Sprintf("/api/items/%d/time", test.input.itemId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getListTime(t *testing.T) {
+	type args struct {
+		userId int
+		listId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTimeEntries, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				listId: 7,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().GetListTotal(gomock.Any(), args.userId, args.listId).Return(domain.TimeTotal{SpentSeconds: 9000, EstimateMinutes: 300}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"spent_seconds\":9000,\"estimate_minutes\":300}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				listId: 7,
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().GetListTotal(gomock.Any(), args.userId, args.listId).Return(domain.TimeTotal{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to sum up the time of a todo-list: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			timeEntries := mock_service.NewMockTimeEntries(controller)
+			test.mockBehavior(timeEntries, test.input)
+
+			w := serveRequest(t, &service.Service{TimeEntries: timeEntries}, http.MethodGet, "/api/lists/{id:[0-9]+}/time", (*Handler).getListTime,
+				fmt.Sprintf("/api/lists/%d/time", test.input.listId), test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getTimeReport(t *testing.T) {
+	type args struct {
+		userId int
+		query  string
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTimeEntries, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				query:  "?from=2026-04-01&to=2026-04-07",
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().GetReport(gomock.Any(), args.userId, domain.TimeReportFilter{From: "2026-04-01", To: "2026-04-07"}).Return([]domain.TimeReportRow{
+					{ListId: 7, ListTitle: "home", Date: "2026-04-01", Seconds: 3600},
+					{ListId: 8, ListTitle: "work", Date: "2026-04-01", Seconds: 1800},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"data\":[{\"list_id\":7,\"list_title\":\"home\",\"date\":\"2026-04-01\",\"seconds\":3600},{\"list_id\":8,\"list_title\":\"work\",\"date\":\"2026-04-01\",\"seconds\":1800}]}\n",
+		},
+		{
+			name:             "Missing Range",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				query:  "?from=2026-04-01",
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().GetReport(gomock.Any(), args.userId, domain.TimeReportFilter{From: "2026-04-01"}).Return(nil, domain.ErrInvalidTimeReport)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the report needs a from and a to date, from being on or before to\"}",
+		},
+		{
+			name:             "Invalid Date",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				query:  "?from=01.04.2026&to=2026-04-07",
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().GetReport(gomock.Any(), args.userId, domain.TimeReportFilter{From: "01.04.2026", To: "2026-04-07"}).Return(nil, domain.ErrInvalidDate)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\": \"the date must be in the YYYY-MM-DD format\"}",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+				query:  "?from=2026-04-01&to=2026-04-07",
+			},
+			mockBehavior: func(s *mock_service.MockTimeEntries, args args) {
+				s.EXPECT().GetReport(gomock.Any(), args.userId, domain.TimeReportFilter{From: "2026-04-01", To: "2026-04-07"}).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to make a time report: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			timeEntries := mock_service.NewMockTimeEntries(controller)
+			test.mockBehavior(timeEntries, test.input)
+
+			w := serveRequest(t, &service.Service{TimeEntries: timeEntries}, http.MethodGet, "/api/time/report", (*Handler).getTimeReport,
+				"/api/time/report"+test.input.query, test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func timePointer(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_getTrash(t *testing.T) {
+	type args struct {
+		userId int
+	}
+
+	tests := []struct {
+		name                 string
+		inputRequestBody     string
+		input                args
+		mockBehavior         func(s *mock_service.MockTrash, args args)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "OK",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockTrash, args args) {
+				deletedAt := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+				s.EXPECT().Get(gomock.Any(), args.userId).Return(domain.Trash{
+					Lists: []domain.TodoList{{Id: 1, Title: "list", DeletedAt: &deletedAt}},
+					Items: []domain.TodoItem{{Id: 2, Title: "item", DeletedAt: &deletedAt}},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"lists\":[{\"id\":1,\"title\":\"list\",\"deleted_at\":\"2023-07-01T12:00:00Z\"}],\"items\":[{\"id\":2,\"title\":\"item\",\"deleted_at\":\"2023-07-01T12:00:00Z\"}]}\n",
+		},
+		{
+			name:             "Service Failure",
+			inputRequestBody: ``,
+			input: args{
+				userId: 1,
+			},
+			mockBehavior: func(s *mock_service.MockTrash, args args) {
+				s.EXPECT().Get(gomock.Any(), args.userId).Return(domain.Trash{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\": \"unable to get the trash: service failure\"}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			trash := mock_service.NewMockTrash(controller)
+			test.mockBehavior(trash, test.input)
+
+			w := serveRequest(t, &service.Service{Trash: trash}, http.MethodGet, "/api/trash", (*Handler).getTrash,
+				"/api/trash", test.inputRequestBody, test.input.userId)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -113,3 +113,46 @@ func (h *Handler) signIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// @Summary Set timezone
+// @Security ApiKeyAuth
+// @Tags users
+// @Description set the timezone the due dates of the user are read in
+// @ID set-timezone
+// @Accept json
+// @Produce json
+// @Param input body domain.TimezoneInput true "IANA timezone name"
+// @Success 200 {object} StatusResponse
+// @Failure 400,404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/users/timezone [put]
+func (h *Handler) setTimezone(w http.ResponseWriter, r *http.Request) {
+
+	userId := h.getUserId(w, r)
+
+	var timezoneInput domain.TimezoneInput
+	if err := json.NewDecoder(r.Body).Decode(&timezoneInput); err != nil {
+		h.writeResponseWithError(w, http.StatusBadRequest, errors.Wrap(err, "the given data was not valid JSON"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := h.services.Users.SetTimezone(ctx, userId, timezoneInput); err != nil {
+		if errors.Is(err, domain.ErrInvalidTimezone) {
+			h.writeResponseWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to set a timezone"))
+		return
+	}
+
+	h.writeResponseHeader(w, http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(StatusResponse{success}); err != nil {
+		h.writeResponseWithError(w, http.StatusInternalServerError, errors.Wrap(err, "unable to encode response data"))
+		return
+	}
+}
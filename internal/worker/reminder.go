@@ -0,0 +1,117 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/config"
+	"github.com/andredubov/todo-backend/internal/domain"
+	"github.com/andredubov/todo-backend/internal/service"
+	"github.com/andredubov/todo-backend/pkg/logger"
+	"github.com/andredubov/todo-backend/pkg/notify"
+)
+
+const dueAtLayout = "Mon, 02 Jan 2006 15:04 MST"
+
+// ReminderDispatcher periodically claims the fired reminders and delivers them
+// through the notifier. A reminder which could not be delivered is released
+// to be claimed once again on the next run. Claiming marks a reminder as sent,
+// so the reminders claimed when the process crashes are not delivered at all.
+type ReminderDispatcher struct {
+	reminders service.Reminders
+	notifier  notify.Notifier
+	interval  time.Duration
+	batchSize int
+}
+
+func NewReminderDispatcher(reminders service.Reminders, notifier notify.Notifier, cfg config.RemindersConfig) *ReminderDispatcher {
+	return &ReminderDispatcher{
+		reminders: reminders,
+		notifier:  notifier,
+		interval:  cfg.DispatchInterval,
+		batchSize: cfg.BatchSize,
+	}
+}
+
+// Run dispatches the fired reminders every interval until ctx is cancelled.
+func (d *ReminderDispatcher) Run(ctx context.Context) {
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch claims batches of fired reminders until a batch comes out empty or incomplete.
+// The released reminders wait for the next run, so a failing notifier does not
+// make the same reminders claimed over and over.
+func (d *ReminderDispatcher) dispatch(ctx context.Context) {
+
+	ctx, cancel := context.WithTimeout(ctx, d.interval)
+	defer cancel()
+
+	for {
+		deliveries, err := d.reminders.Claim(ctx, time.Now(), d.batchSize)
+		if err != nil {
+			logger.Errorf("failed to claim reminders: %s", err.Error())
+			return
+		}
+
+		delivered := 0
+		for _, delivery := range deliveries {
+			if d.deliver(ctx, delivery) {
+				delivered++
+			}
+		}
+
+		if len(deliveries) == 0 || len(deliveries) < d.batchSize || delivered < len(deliveries) {
+			return
+		}
+	}
+}
+
+// deliver notifies the user and reports whether the notification was delivered.
+func (d *ReminderDispatcher) deliver(ctx context.Context, delivery domain.ReminderDelivery) bool {
+
+	err := d.notifier.Notify(ctx, reminderNotification(delivery))
+	if err == nil {
+		return true
+	}
+
+	logger.Errorf("failed to deliver reminder %d: %s", delivery.ReminderId, err.Error())
+
+	if err := d.reminders.Release(ctx, delivery.ReminderId); err != nil {
+		logger.Errorf("failed to release reminder %d: %s", delivery.ReminderId, err.Error())
+	}
+
+	return false
+}
+
+// reminderNotification tells the user about the item, the due time is shown in the timezone of the user.
+func reminderNotification(delivery domain.ReminderDelivery) notify.Notification {
+
+	body := fmt.Sprintf("Hi %s,\n\nthis is a reminder about %q.", delivery.Name, delivery.ItemTitle)
+
+	if delivery.DueAt != nil {
+		location, err := time.LoadLocation(delivery.Timezone)
+		if err != nil {
+			location = time.UTC
+		}
+		body = fmt.Sprintf("Hi %s,\n\n%q is due %s.", delivery.Name, delivery.ItemTitle, delivery.DueAt.In(location).Format(dueAtLayout))
+	}
+
+	return notify.Notification{
+		Recipient: delivery.Email,
+		Subject:   fmt.Sprintf("Reminder: %s", delivery.ItemTitle),
+		Body:      body,
+	}
+}
@@ -0,0 +1,109 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/domain"
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/andredubov/todo-backend/pkg/notify"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+type failingNotifier struct{}
+
+func (failingNotifier) Notify(ctx context.Context, notification notify.Notification) error {
+	return errors.New("connection refused")
+}
+
+func TestReminderDispatcher_dispatch(t *testing.T) {
+
+	dueAt := time.Date(2026, time.March, 29, 7, 30, 0, 0, time.UTC)
+	deliveries := []domain.ReminderDelivery{
+		{ReminderId: 1, ItemId: 10, ItemTitle: "Pay rent", DueAt: &dueAt, Email: "ann@example.com", Name: "Ann", Timezone: "Europe/Berlin"},
+		{ReminderId: 2, ItemId: 11, ItemTitle: "Call mom", Email: "bob@example.com", Name: "Bob", Timezone: "UTC"},
+	}
+
+	tests := []struct {
+		name         string
+		batchSize    int
+		failing      bool
+		mockBehavior func(s *mock_service.MockReminders)
+		want         []notify.Notification
+	}{
+		{
+			name:      "OK",
+			batchSize: 10,
+			mockBehavior: func(s *mock_service.MockReminders) {
+				s.EXPECT().Claim(gomock.Any(), gomock.Any(), 10).Return(deliveries, nil)
+			},
+			want: []notify.Notification{
+				{Recipient: "ann@example.com", Subject: "Reminder: Pay rent", Body: "Hi Ann,\n\n\"Pay rent\" is due Sun, 29 Mar 2026 09:30 CEST."},
+				{Recipient: "bob@example.com", Subject: "Reminder: Call mom", Body: "Hi Bob,\n\nthis is a reminder about \"Call mom\"."},
+			},
+		},
+		{
+			name:      "Full batches",
+			batchSize: 1,
+			mockBehavior: func(s *mock_service.MockReminders) {
+				gomock.InOrder(
+					s.EXPECT().Claim(gomock.Any(), gomock.Any(), 1).Return(deliveries[:1], nil),
+					s.EXPECT().Claim(gomock.Any(), gomock.Any(), 1).Return(deliveries[1:], nil),
+					s.EXPECT().Claim(gomock.Any(), gomock.Any(), 1).Return(nil, nil),
+				)
+			},
+			want: []notify.Notification{
+				{Recipient: "ann@example.com", Subject: "Reminder: Pay rent", Body: "Hi Ann,\n\n\"Pay rent\" is due Sun, 29 Mar 2026 09:30 CEST."},
+				{Recipient: "bob@example.com", Subject: "Reminder: Call mom", Body: "Hi Bob,\n\nthis is a reminder about \"Call mom\"."},
+			},
+		},
+		{
+			name:      "Zero batch size",
+			batchSize: 0,
+			mockBehavior: func(s *mock_service.MockReminders) {
+				s.EXPECT().Claim(gomock.Any(), gomock.Any(), 0).Return(nil, nil)
+			},
+		},
+		{
+			name:      "Claim failed",
+			batchSize: 10,
+			mockBehavior: func(s *mock_service.MockReminders) {
+				s.EXPECT().Claim(gomock.Any(), gomock.Any(), 10).Return(nil, errors.New("connection reset"))
+			},
+		},
+		{
+			name:      "Delivery failed",
+			batchSize: 2,
+			failing:   true,
+			mockBehavior: func(s *mock_service.MockReminders) {
+				s.EXPECT().Claim(gomock.Any(), gomock.Any(), 2).Return(deliveries, nil)
+				s.EXPECT().Release(gomock.Any(), 1).Return(nil)
+				s.EXPECT().Release(gomock.Any(), 2).Return(errors.New("connection reset"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			reminders := mock_service.NewMockReminders(controller)
+			test.mockBehavior(reminders)
+
+			memory := notify.NewMemoryNotifier()
+			dispatcher := &ReminderDispatcher{reminders: reminders, notifier: memory, interval: time.Second, batchSize: test.batchSize}
+			if test.failing {
+				dispatcher.notifier = failingNotifier{}
+			}
+
+			dispatcher.dispatch(context.Background())
+
+			assert.Equal(t, test.want, memory.Sent())
+		})
+	}
+}
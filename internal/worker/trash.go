@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/andredubov/todo-backend/internal/config"
+	"github.com/andredubov/todo-backend/internal/service"
+	"github.com/andredubov/todo-backend/pkg/blob"
+	"github.com/andredubov/todo-backend/pkg/logger"
+)
+
+// TrashPurger periodically removes the lists and items which have been
+// in the trash for longer than the configured retention period, and the
+// files attached to them from the blob store.
+type TrashPurger struct {
+	trash     service.Trash
+	store     blob.BlobStore
+	retention time.Duration
+	interval  time.Duration
+}
+
+func NewTrashPurger(trash service.Trash, store blob.BlobStore, cfg config.TrashConfig) *TrashPurger {
+	return &TrashPurger{
+		trash:     trash,
+		store:     store,
+		retention: cfg.Retention,
+		interval:  cfg.PurgeInterval,
+	}
+}
+
+// Run purges the trash every interval until ctx is cancelled.
+func (p *TrashPurger) Run(ctx context.Context) {
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *TrashPurger) purge(ctx context.Context) {
+
+	ctx, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+
+	purged, keys, err := p.trash.Purge(ctx, time.Now().Add(-p.retention))
+	if err != nil {
+		logger.Errorf("failed to purge the trash: %s", err.Error())
+	}
+
+	if purged > 0 {
+		logger.Infof("purged %d rows from the trash", purged)
+	}
+
+	// the rows of the files are gone even when a later part of the purge failed
+	for _, key := range keys {
+		if err := p.store.Delete(ctx, key); err != nil {
+			logger.Errorf("failed to remove the purged file %s: %s", key, err.Error())
+		}
+	}
+}
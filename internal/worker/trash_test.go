@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	mock_service "github.com/andredubov/todo-backend/internal/service/mocks"
+	"github.com/dvln/testify/assert"
+	"github.com/golang/mock/gomock"
+)
+
+// deletingStore records the keys of the files removed from it.
+type deletingStore struct {
+	deleted []string
+}
+
+func (s *deletingStore) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	return nil
+}
+
+func (s *deletingStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return nil, errors.New("not found")
+}
+
+func (s *deletingStore) Delete(ctx context.Context, key string) error {
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+func TestTrashPurger_purge(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		mockBehavior func(s *mock_service.MockTrash)
+		want         []string
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock_service.MockTrash) {
+				s.EXPECT().Purge(gomock.Any(), gomock.Any()).Return(int64(4), []string{"a1", "b2"}, nil)
+			},
+			want: []string{"a1", "b2"},
+		},
+		{
+			name: "Nothing purged",
+			mockBehavior: func(s *mock_service.MockTrash) {
+				s.EXPECT().Purge(gomock.Any(), gomock.Any()).Return(int64(0), nil, nil)
+			},
+		},
+		{
+			name: "Lists not purged",
+			mockBehavior: func(s *mock_service.MockTrash) {
+				s.EXPECT().Purge(gomock.Any(), gomock.Any()).Return(int64(2), []string{"a1"}, errors.New("connection reset"))
+			},
+			want: []string{"a1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			trash := mock_service.NewMockTrash(controller)
+			test.mockBehavior(trash)
+
+			store := &deletingStore{}
+			purger := &TrashPurger{trash: trash, store: store, retention: time.Hour, interval: time.Second}
+
+			purger.purge(context.Background())
+
+			assert.Equal(t, test.want, store.deleted)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned for a key no blob is stored under.
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore keeps the contents of files under the given keys.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,44 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dvln/testify/assert"
+)
+
+func TestLocalStore(t *testing.T) {
+
+	dir := filepath.Join(t.TempDir(), "attachments")
+	store := NewLocalStore(dir)
+	ctx := context.Background()
+
+	assert.NoError(t, store.Put(ctx, "a1b2", strings.NewReader("hello"), 5, "text/plain"))
+
+	content, err := store.Get(ctx, "a1b2")
+	assert.NoError(t, err)
+	got, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.NoError(t, content.Close())
+	assert.Equal(t, "hello", string(got))
+
+	assert.Error(t, store.Put(ctx, "c3d4", strings.NewReader("hell"), 5, "text/plain"))
+	_, err = store.Get(ctx, "c3d4")
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.NoError(t, store.Delete(ctx, "a1b2"))
+	assert.NoError(t, store.Delete(ctx, "a1b2"))
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(entries))
+
+	for _, key := range []string{"", "..", "../passwd", "a/b", ".upload-1"} {
+		_, err := store.Get(ctx, key)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,137 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// unsignedPayload lets the body of an upload be streamed instead of hashed up front.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Store keeps the blobs as objects of a bucket of Amazon S3 or of an
+// S3-compatible storage such as MinIO. MinIO needs path-style addressing,
+// where the bucket is the first segment of the path rather than a subdomain.
+type S3Store struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	client    *http.Client
+	now       func() time.Time
+}
+
+func NewS3Store(endpoint, region, bucket, accessKey, secretKey string, pathStyle bool, timeout time.Duration) (*S3Store, error) {
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", endpoint)
+	}
+
+	return &S3Store{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		pathStyle: pathStyle,
+		client:    &http.Client{Timeout: timeout},
+		now:       time.Now,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+
+	request, err := s.request(ctx, http.MethodPut, key, io.LimitReader(content, size))
+	if err != nil {
+		return err
+	}
+	request.ContentLength = size
+	request.Header.Set("Content-Type", contentType)
+
+	response, err := s.do(request)
+	if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+
+	request, err := s.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+// Delete removes the object, S3 reports no error for a missing object.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+
+	request, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
+
+func (s *S3Store) request(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+
+	u := *s.endpoint
+	path := "/" + key
+	if s.pathStyle {
+		path = "/" + s.bucket + path
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawPath = ""
+
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+// do signs and sends the request, any response but 2xx is an error.
+func (s *S3Store) do(request *http.Request) (*http.Response, error) {
+
+	request.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	signV4(request, "s3", s.region, s.accessKey, s.secretKey, unsignedPayload, s.now())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+		return response, nil
+	}
+	response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	return nil, fmt.Errorf("s3 responded with status %d", response.StatusCode)
+}
@@ -0,0 +1,113 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dvln/testify/assert"
+)
+
+func TestS3Store(t *testing.T) {
+
+	objects := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/20240301/us-east-1/s3/aws4_request, ") ||
+			r.Header.Get("X-Amz-Content-Sha256") != unsignedPayload {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+			objects[r.URL.Path] = string(body)
+		case http.MethodGet:
+			object, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			io.WriteString(w, object)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewS3Store(server.URL, "us-east-1", "attachments", "access", "secret", true, time.Second)
+	assert.NoError(t, err)
+	store.now = func() time.Time { return time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC) }
+
+	ctx := context.Background()
+
+	assert.NoError(t, store.Put(ctx, "a1b2", strings.NewReader("hello"), 5, "text/plain"))
+	assert.Equal(t, "hello", objects["/attachments/a1b2"])
+
+	content, err := store.Get(ctx, "a1b2")
+	assert.NoError(t, err)
+	got, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.NoError(t, content.Close())
+	assert.Equal(t, "hello", string(got))
+
+	assert.NoError(t, store.Delete(ctx, "a1b2"))
+
+	_, err = store.Get(ctx, "a1b2")
+	assert.Equal(t, ErrNotFound, err)
+
+	store.secretKey, store.accessKey = "secret", "intruder"
+	assert.Error(t, store.Put(ctx, "a1b2", strings.NewReader("hello"), 5, "text/plain"))
+}
+
+func TestS3Store_request(t *testing.T) {
+
+	virtualHosted, err := NewS3Store("https://s3.eu-central-1.amazonaws.com", "eu-central-1", "attachments", "", "", false, time.Second)
+	assert.NoError(t, err)
+
+	request, err := virtualHosted.request(context.Background(), http.MethodGet, "a1b2", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://attachments.s3.eu-central-1.amazonaws.com/a1b2", request.URL.String())
+
+	_, err = NewS3Store("localhost:9000", "us-east-1", "attachments", "", "", true, time.Second)
+	assert.Error(t, err)
+}
+
+// TestS3Store_MinIO runs against a real S3-compatible storage, for example
+// docker run -p 9000:9000 minio/minio server /data with a bucket named
+// attachments, when S3_TEST_ENDPOINT is set.
+func TestS3Store_MinIO(t *testing.T) {
+
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT is not set")
+	}
+
+	store, err := NewS3Store(endpoint, "us-east-1", "attachments", os.Getenv("S3_TEST_ACCESS_KEY"), os.Getenv("S3_TEST_SECRET_KEY"), true, 10*time.Second)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	key := "test-" + time.Now().Format(amzDateLayout)
+
+	assert.NoError(t, store.Put(ctx, key, strings.NewReader("hello"), 5, "text/plain"))
+
+	content, err := store.Get(ctx, key)
+	assert.NoError(t, err)
+	got, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	content.Close()
+	assert.Equal(t, "hello", string(got))
+
+	assert.NoError(t, store.Delete(ctx, key))
+
+	_, err = store.Get(ctx, key)
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,28 @@
+package blob
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dvln/testify/assert"
+)
+
+func TestSignV4(t *testing.T) {
+
+	// the example request of the AWS Signature Version 4 documentation
+	request, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	assert.NoError(t, err)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	signV4(request, "iam", "us-east-1", "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		hashHex(nil), time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "20150830T123600Z", request.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, "+
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7", request.Header.Get("Authorization"))
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	assert.Equal(t, "a=1&b=x%20y&b=z&c=%2A~", canonicalQuery(map[string][]string{"c": {"*~"}, "b": {"z", "x y"}, "a": {"1"}}))
+}
@@ -0,0 +1,81 @@
+// Package markdown renders Markdown texts into HTML safe to embed into a page
+// and edits the task lists of a Markdown text.
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extensionast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+var (
+	// markdown passes the raw HTML of the text through, it is left to the policy.
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM), goldmark.WithRendererOptions(html.WithUnsafe()))
+
+	// policy keeps the formatting of user-generated content and the disabled
+	// checkboxes of the task lists, scripts, styles and event handlers are dropped.
+	policy = func() *bluemonday.Policy {
+		policy := bluemonday.UGCPolicy()
+		policy.AllowAttrs("type").Matching(regexp.MustCompile("^checkbox$")).OnElements("input")
+		policy.AllowAttrs("checked", "disabled").OnElements("input")
+		policy.RequireNoFollowOnLinks(true)
+		return policy
+	}()
+)
+
+// Render turns the Markdown text, GitHub Flavored Markdown, into sanitized HTML.
+func Render(source string) (string, error) {
+
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+
+	return policy.Sanitize(buf.String()), nil
+}
+
+// SetTask checks or unchecks the task of the task lists of the text with the
+// given index, the tasks are counted from zero in the order they appear in.
+// It returns false when the text has fewer tasks.
+func SetTask(source string, index int, checked bool) (string, bool) {
+
+	src := []byte(source)
+	document := markdown.Parser().Parse(text.NewReader(src))
+
+	position, count := -1, 0
+	ast.Walk(document, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || node.Kind() != extensionast.KindTaskCheckBox {
+			return ast.WalkContinue, nil
+		}
+
+		if count == index {
+			// The checkbox opens the first line of the text of its list item.
+			if lines := node.Parent().Lines(); lines.Len() > 0 {
+				position = lines.At(0).Start
+			}
+			return ast.WalkStop, nil
+		}
+
+		count++
+		return ast.WalkContinue, nil
+	})
+
+	if position < 0 || position+2 >= len(src) || src[position] != '[' || src[position+2] != ']' {
+		return source, false
+	}
+
+	if checked {
+		src[position+1] = 'x'
+	} else {
+		src[position+1] = ' '
+	}
+
+	return string(src), true
+}
@@ -0,0 +1,104 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/dvln/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "Formatting",
+			source: "# Plan\n\nBuy *milk* and `bread`, see [the shop](https://example.com).",
+			want:   "<h1>Plan</h1>\n<p>Buy <em>milk</em> and <code>bread</code>, see <a href=\"https://example.com\" rel=\"nofollow\">the shop</a>.</p>\n",
+		},
+		{
+			name:   "Task list",
+			source: "- [ ] milk\n- [x] bread",
+			want:   "<ul>\n<li><input disabled=\"\" type=\"checkbox\"> milk</li>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> bread</li>\n</ul>\n",
+		},
+		{
+			name:   "Script",
+			source: "Hello <script>alert(1)</script>",
+			want:   "<p>Hello </p>\n",
+		},
+		{
+			name:   "Event handler",
+			source: "<img src=\"cat.png\" onerror=\"alert(1)\">",
+			want:   "<img src=\"cat.png\">",
+		},
+		{
+			name:   "Script link",
+			source: "[click](javascript:alert(1))",
+			want:   "<p>click</p>\n",
+		},
+		{
+			name:   "Form input",
+			source: "<input type=\"text\" name=\"password\">",
+			want:   "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Render(test.source)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSetTask(t *testing.T) {
+
+	source := "- [ ] milk\n  - [X] skimmed\n\n```\n- [ ] not a task\n```\n\n1. [ ] bread\n"
+
+	tests := []struct {
+		name    string
+		index   int
+		checked bool
+		want    string
+		wantOk  bool
+	}{
+		{
+			name:    "Check",
+			index:   0,
+			checked: true,
+			want:    "- [x] milk\n  - [X] skimmed\n\n```\n- [ ] not a task\n```\n\n1. [ ] bread\n",
+			wantOk:  true,
+		},
+		{
+			name:    "Uncheck nested",
+			index:   1,
+			checked: false,
+			want:    "- [ ] milk\n  - [ ] skimmed\n\n```\n- [ ] not a task\n```\n\n1. [ ] bread\n",
+			wantOk:  true,
+		},
+		{
+			name:    "Code is skipped",
+			index:   2,
+			checked: true,
+			want:    "- [ ] milk\n  - [X] skimmed\n\n```\n- [ ] not a task\n```\n\n1. [x] bread\n",
+			wantOk:  true,
+		},
+		{
+			name:    "No such task",
+			index:   3,
+			checked: true,
+			want:    source,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := SetTask(source, test.index, test.checked)
+			assert.Equal(t, test.wantOk, ok)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// EmailNotifier sends notifications as plain text emails through an SMTP server.
+// The recipient of a notification is an email address.
+type EmailNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewEmailNotifier creates a notifier which sends emails from the given address.
+// The server is used without authentication when the username is empty.
+func NewEmailNotifier(host string, port int, username, password, from string) *EmailNotifier {
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &EmailNotifier{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth: auth,
+		from: from,
+	}
+}
+
+// Notify sends the notification, net/smtp can not be cancelled,
+// so ctx is only checked before sending.
+func (n *EmailNotifier) Notify(ctx context.Context, notification Notification) error {
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return smtp.SendMail(n.addr, n.auth, n.from, []string{notification.Recipient}, n.message(notification))
+}
+
+func (n *EmailNotifier) message(notification Notification) []byte {
+
+	var message strings.Builder
+
+	fmt.Fprintf(&message, "From: %s\r\n", n.from)
+	fmt.Fprintf(&message, "To: %s\r\n", notification.Recipient)
+	fmt.Fprintf(&message, "Subject: %s\r\n", headerValue(notification.Subject))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(notification.Body)
+
+	return []byte(message.String())
+}
+
+// headerValue keeps a value provided by the users from adding headers of its own.
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
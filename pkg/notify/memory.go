@@ -0,0 +1,36 @@
+package notify
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryNotifier keeps the notifications in memory instead of delivering them.
+// It is meant for the tests, the reminders are never configured to use it.
+type MemoryNotifier struct {
+	mu            sync.Mutex
+	notifications []Notification
+}
+
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+func (n *MemoryNotifier) Notify(ctx context.Context, notification Notification) error {
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.notifications = append(n.notifications, notification)
+
+	return nil
+}
+
+// Sent returns the notifications received so far.
+func (n *MemoryNotifier) Sent() []Notification {
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]Notification(nil), n.notifications...)
+}
@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/dvln/testify/assert"
+)
+
+func TestMemoryNotifier_Notify(t *testing.T) {
+
+	notifier := NewMemoryNotifier()
+	assert.Nil(t, notifier.Sent())
+
+	notifications := []Notification{
+		{Recipient: "ann@example.com", Subject: "Reminder: Pay rent", Body: "Pay rent is due"},
+		{Recipient: "bob@example.com", Subject: "Reminder: Call mom", Body: "Call mom is due"},
+	}
+
+	for _, notification := range notifications {
+		assert.NoError(t, notifier.Notify(context.Background(), notification))
+	}
+
+	sent := notifier.Sent()
+	assert.Equal(t, notifications, sent)
+
+	// the returned slice is a copy, the kept notifications stay untouched
+	sent[0].Recipient = "eve@example.com"
+	assert.Equal(t, notifications, notifier.Sent())
+}
+
+func TestMemoryNotifier_NotifyConcurrently(t *testing.T) {
+
+	notifier := NewMemoryNotifier()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			notifier.Notify(context.Background(), Notification{Recipient: "ann@example.com"})
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, notifier.Sent(), 50)
+}
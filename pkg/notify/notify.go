@@ -0,0 +1,15 @@
+package notify
+
+import "context"
+
+// Notification is a message delivered to a single recipient.
+type Notification struct {
+	Recipient string `json:"recipient"`
+	Subject   string `json:"subject"`
+	Body      string `json:"body"`
+}
+
+// Notifier delivers notifications to the users.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dvln/testify/assert"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+
+	notification := Notification{Recipient: "user@example.com", Subject: "Reminder: Pay rent", Body: "Pay rent is due"}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{name: "OK", statusCode: http.StatusOK},
+		{name: "No content", statusCode: http.StatusNoContent},
+		{name: "Server error", statusCode: http.StatusInternalServerError, wantErr: true},
+		{name: "Redirect", statusCode: http.StatusFound, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			var received Notification
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+				w.WriteHeader(test.statusCode)
+			}))
+			defer server.Close()
+
+			err := NewWebhookNotifier(server.URL, time.Second).Notify(context.Background(), notification)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, notification, received)
+		})
+	}
+}
+
+func TestEmailNotifier_message(t *testing.T) {
+
+	notifier := NewEmailNotifier("localhost", 25, "", "", "todo@example.com")
+
+	got := string(notifier.message(Notification{Recipient: "user@example.com", Subject: "Reminder\r\nBcc: x@example.com", Body: "Pay rent"}))
+
+	assert.Equal(t, "From: todo@example.com\r\nTo: user@example.com\r\nSubject: Reminder  Bcc: x@example.com\r\n"+
+		"MIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\nPay rent", got)
+}
@@ -0,0 +1,524 @@
+// Package quickadd reads an item out of a line of text such as "Pay rent
+// tomorrow 9am #home !high every month": its due date and time, tags, priority
+// and recurrence. The words which are not recognised make up the title. Dates
+// and times are read relative to the given moment in the timezone of its location.
+package quickadd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DateLayout = "2006-01-02"
+	TimeLayout = "15:04"
+)
+
+// Kind of a recognised part of the text.
+type Kind string
+
+const (
+	KindDate       Kind = "date"
+	KindTime       Kind = "time"
+	KindTag        Kind = "tag"
+	KindPriority   Kind = "priority"
+	KindRecurrence Kind = "recurrence"
+)
+
+// Token is a recognised part of the text and the value it was read as.
+type Token struct {
+	Text  string
+	Kind  Kind
+	Value string
+}
+
+// Result is the item read out of the text. The due date and the due time are
+// formatted with DateLayout and TimeLayout and are empty when missing, the
+// recurrence is an RRULE. A due time or a recurrence without a date makes the
+// item due on the next such time or on the first date it recurs on.
+type Result struct {
+	Title    string
+	DueDate  string
+	DueTime  string
+	Tags     []string
+	Priority string
+	RRule    string
+	Tokens   []Token
+}
+
+var priorities = map[string]bool{"none": true, "low": true, "medium": true, "high": true, "urgent": true}
+
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday, "thursday": time.Thursday,
+	"friday": time.Friday, "saturday": time.Saturday, "sunday": time.Sunday,
+}
+
+// shortWeekdays are only read after a word which introduces a weekday,
+// since words like "sun" or "sat" are common in titles.
+var shortWeekdays = map[string]time.Weekday{
+	"mon": time.Monday, "tue": time.Tuesday, "tues": time.Tuesday, "wed": time.Wednesday, "thu": time.Thursday,
+	"thur": time.Thursday, "thurs": time.Thursday, "fri": time.Friday, "sat": time.Saturday, "sun": time.Sunday,
+}
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January, "february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March, "april": time.April, "apr": time.April, "may": time.May,
+	"june": time.June, "jun": time.June, "july": time.July, "jul": time.July, "august": time.August,
+	"aug": time.August, "september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October, "november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+var frequencies = map[string]string{"day": "DAILY", "week": "WEEKLY", "month": "MONTHLY", "year": "YEARLY"}
+
+var adverbs = map[string]string{"daily": "DAILY", "weekly": "WEEKLY", "monthly": "MONTHLY", "yearly": "YEARLY", "annually": "YEARLY"}
+
+var (
+	clock12   = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)$`)
+	clock24   = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+	bareClock = regexp.MustCompile(`^\d{1,2}(:\d{2})?$`)
+	ordinal   = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th)?$`)
+	year      = regexp.MustCompile(`^\d{4}$`)
+)
+
+// clock is a time of day.
+type clock struct {
+	hour, minute int
+}
+
+// on returns the moment of the time of day on the date.
+func (c clock) on(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), c.hour, c.minute, 0, 0, date.Location())
+}
+
+type parser struct {
+	original []string
+	words    []string
+	now      time.Time
+	today    time.Time
+	result   Result
+
+	dueDate time.Time
+	dueTime *clock
+	byDay   []time.Weekday
+	tags    map[string]bool
+}
+
+// Parse reads the item out of the text relative to now. Every kind but the tags
+// is read once, a later word of the same kind stays in the title.
+func Parse(text string, now time.Time) Result {
+
+	p := parser{
+		original: strings.Fields(text),
+		now:      now,
+		today:    time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+		tags:     make(map[string]bool),
+	}
+
+	p.words = make([]string, len(p.original))
+	for i, word := range p.original {
+		p.words[i] = strings.ToLower(strings.TrimRight(word, ",.;"))
+	}
+
+	title := make([]string, 0, len(p.words))
+	for i := 0; i < len(p.words); {
+		if n := p.match(i); n > 0 {
+			i += n
+			continue
+		}
+
+		title = append(title, p.original[i])
+		i++
+	}
+
+	p.result.Title = strings.Join(title, " ")
+	p.complete()
+
+	return p.result
+}
+
+// match reads the part of the text starting at the ith word and returns
+// the number of words it took, zero when nothing is recognised.
+func (p *parser) match(i int) int {
+
+	for _, match := range []func(int) int{p.tag, p.priority, p.recurrence, p.time, p.date} {
+		if n := match(i); n > 0 {
+			return n
+		}
+	}
+
+	return 0
+}
+
+func (p *parser) word(i int) string {
+
+	if i < len(p.words) {
+		return p.words[i]
+	}
+
+	return ""
+}
+
+func (p *parser) add(kind Kind, i, n int, value string) int {
+
+	p.result.Tokens = append(p.result.Tokens, Token{Text: strings.Join(p.original[i:i+n], " "), Kind: kind, Value: value})
+
+	return n
+}
+
+func (p *parser) tag(i int) int {
+
+	name := strings.TrimRight(p.original[i], ",.;")
+	if len(name) < 2 || name[0] != '#' {
+		return 0
+	}
+
+	name = name[1:]
+	if !p.tags[strings.ToLower(name)] {
+		p.tags[strings.ToLower(name)] = true
+		p.result.Tags = append(p.result.Tags, name)
+	}
+
+	return p.add(KindTag, i, 1, name)
+}
+
+func (p *parser) priority(i int) int {
+
+	word := p.word(i)
+	if p.result.Priority != "" || !strings.HasPrefix(word, "!") || !priorities[word[1:]] {
+		return 0
+	}
+
+	p.result.Priority = word[1:]
+
+	return p.add(KindPriority, i, 1, p.result.Priority)
+}
+
+func (p *parser) recurrence(i int) int {
+
+	if p.result.RRule != "" {
+		return 0
+	}
+
+	if freq, ok := adverbs[p.word(i)]; ok {
+		p.result.RRule = "FREQ=" + freq
+		return p.add(KindRecurrence, i, 1, p.result.RRule)
+	}
+
+	if p.word(i) != "every" {
+		return 0
+	}
+
+	if freq, ok := frequencies[p.word(i+1)]; ok {
+		p.result.RRule = "FREQ=" + freq
+		return p.add(KindRecurrence, i, 2, p.result.RRule)
+	}
+
+	if freq, ok := frequencies[p.word(i+2)]; ok && p.word(i+1) == "other" {
+		p.result.RRule = "FREQ=" + freq + ";INTERVAL=2"
+		return p.add(KindRecurrence, i, 3, p.result.RRule)
+	}
+
+	if interval, err := strconv.Atoi(p.word(i + 1)); err == nil && interval > 0 {
+		if freq, ok := frequencies[strings.TrimSuffix(p.word(i+2), "s")]; ok {
+			p.result.RRule = "FREQ=" + freq
+			if interval > 1 {
+				p.result.RRule += fmt.Sprintf(";INTERVAL=%d", interval)
+			}
+			return p.add(KindRecurrence, i, 3, p.result.RRule)
+		}
+	}
+
+	if p.word(i+1) == "weekday" || p.word(i+1) == "weekdays" {
+		p.byDay = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+		p.result.RRule = "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"
+		return p.add(KindRecurrence, i, 2, p.result.RRule)
+	}
+
+	// every monday, thursday and saturday
+	n, seen, codes := 1, make(map[time.Weekday]bool), make([]string, 0)
+	for {
+		weekday, ok := p.weekday(i+n, true)
+		if !ok {
+			break
+		}
+
+		if !seen[weekday] {
+			seen[weekday] = true
+			p.byDay = append(p.byDay, weekday)
+			codes = append(codes, weekdayCodes[weekday])
+		}
+		n++
+
+		if p.word(i+n) == "and" {
+			if _, ok := p.weekday(i+n+1, true); ok {
+				n++
+			}
+		}
+	}
+
+	if len(codes) == 0 {
+		return 0
+	}
+
+	p.result.RRule = "FREQ=WEEKLY;BYDAY=" + strings.Join(codes, ",")
+
+	return p.add(KindRecurrence, i, n, p.result.RRule)
+}
+
+func (p *parser) time(i int) int {
+
+	if p.dueTime != nil {
+		return 0
+	}
+
+	start := i
+	if p.word(i) == "at" {
+		i++
+	}
+
+	at, n := p.clock(i)
+	if n == 0 {
+		return 0
+	}
+
+	p.dueTime = &at
+	p.result.DueTime = fmt.Sprintf("%02d:%02d", at.hour, at.minute)
+
+	return p.add(KindTime, start, i-start+n, p.result.DueTime)
+}
+
+// clock reads a time of day such as 9am, 9:30 pm, 21:00 or noon.
+func (p *parser) clock(i int) (clock, int) {
+
+	word := p.word(i)
+
+	switch word {
+	case "noon":
+		return clock{hour: 12}, 1
+	case "midnight":
+		return clock{}, 1
+	}
+
+	n := 1
+	if next := p.word(i + 1); (next == "am" || next == "pm") && bareClock.MatchString(word) {
+		word, n = word+next, 2
+	}
+
+	if match := clock12.FindStringSubmatch(word); match != nil {
+		hour, _ := strconv.Atoi(match[1])
+		minute, _ := strconv.Atoi("0" + match[2])
+		if hour < 1 || hour > 12 || minute > 59 {
+			return clock{}, 0
+		}
+
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+
+		return clock{hour: hour, minute: minute}, n
+	}
+
+	if match := clock24.FindStringSubmatch(word); match != nil {
+		hour, _ := strconv.Atoi(match[1])
+		minute, _ := strconv.Atoi(match[2])
+		if hour > 23 || minute > 59 {
+			return clock{}, 0
+		}
+
+		return clock{hour: hour, minute: minute}, 1
+	}
+
+	return clock{}, 0
+}
+
+func (p *parser) date(i int) int {
+
+	if !p.dueDate.IsZero() {
+		return 0
+	}
+
+	start := i
+	if p.word(i) == "on" {
+		i++
+	}
+
+	date, n := p.calendar(i, start < i)
+	if n == 0 {
+		return 0
+	}
+
+	p.dueDate = date
+	p.result.DueDate = date.Format(DateLayout)
+
+	return p.add(KindDate, start, i-start+n, p.result.DueDate)
+}
+
+// calendar reads a date: today, tomorrow, a weekday, next week, month or year,
+// in a number of days, weeks, months or years, 2024-05-01, may 5 or 5 may with
+// an optional year. A date without a year is the next such date.
+func (p *parser) calendar(i int, introduced bool) (time.Time, int) {
+
+	word := p.word(i)
+
+	switch word {
+	case "today":
+		return p.today, 1
+	case "tomorrow":
+		return p.today.AddDate(0, 0, 1), 1
+	case "next":
+		switch p.word(i + 1) {
+		case "week":
+			return p.after(time.Monday), 2
+		case "month":
+			return time.Date(p.today.Year(), p.today.Month()+1, 1, 0, 0, 0, 0, p.today.Location()), 2
+		case "year":
+			return time.Date(p.today.Year()+1, time.January, 1, 0, 0, 0, 0, p.today.Location()), 2
+		}
+
+		if weekday, ok := p.weekday(i+1, true); ok {
+			return p.after(weekday), 2
+		}
+
+		return time.Time{}, 0
+	case "in":
+		amount, err := strconv.Atoi(p.word(i + 1))
+		if p.word(i+1) == "a" || p.word(i+1) == "an" {
+			amount, err = 1, nil
+		}
+		if err != nil || amount < 1 {
+			return time.Time{}, 0
+		}
+
+		switch strings.TrimSuffix(p.word(i+2), "s") {
+		case "day":
+			return p.today.AddDate(0, 0, amount), 3
+		case "week":
+			return p.today.AddDate(0, 0, 7*amount), 3
+		case "month":
+			return p.today.AddDate(0, amount, 0), 3
+		case "year":
+			return p.today.AddDate(amount, 0, 0), 3
+		}
+
+		return time.Time{}, 0
+	}
+
+	if weekday, ok := p.weekday(i, introduced); ok {
+		return p.after(weekday), 1
+	}
+
+	if date, err := time.ParseInLocation(DateLayout, word, p.today.Location()); err == nil {
+		return date, 1
+	}
+
+	// may 5, may 5th 2025
+	if month, ok := months[word]; ok {
+		if day, ok := p.day(i + 1); ok {
+			return p.dayOf(month, day, i+2, 2)
+		}
+	}
+
+	// 5 may, 5th may 2025
+	if day, ok := p.day(i); ok {
+		if month, ok := months[p.word(i+1)]; ok {
+			return p.dayOf(month, day, i+2, 2)
+		}
+	}
+
+	return time.Time{}, 0
+}
+
+// dayOf returns the date of the day of the month, in the year read from
+// the ith word or else the next such date, n is the number of words taken so far.
+func (p *parser) dayOf(month time.Month, day, i, n int) (time.Time, int) {
+
+	if year.MatchString(p.word(i)) {
+		y, _ := strconv.Atoi(p.word(i))
+		date := time.Date(y, month, day, 0, 0, 0, 0, p.today.Location())
+		if date.Day() != day {
+			return time.Time{}, 0
+		}
+
+		return date, n + 1
+	}
+
+	for y := p.today.Year(); y <= p.today.Year()+4; y++ {
+		date := time.Date(y, month, day, 0, 0, 0, 0, p.today.Location())
+		if date.Day() == day && !date.Before(p.today) {
+			return date, n
+		}
+	}
+
+	return time.Time{}, 0
+}
+
+func (p *parser) day(i int) (int, bool) {
+
+	match := ordinal.FindStringSubmatch(p.word(i))
+	if match == nil {
+		return 0, false
+	}
+
+	day, _ := strconv.Atoi(match[1])
+
+	return day, day >= 1 && day <= 31
+}
+
+// weekday reads the name of a weekday, the short names only when introduced.
+func (p *parser) weekday(i int, introduced bool) (time.Weekday, bool) {
+
+	if weekday, ok := weekdays[p.word(i)]; ok {
+		return weekday, true
+	}
+
+	if weekday, ok := shortWeekdays[p.word(i)]; ok && introduced {
+		return weekday, true
+	}
+
+	return 0, false
+}
+
+// after returns the next date on the weekday after today.
+func (p *parser) after(weekday time.Weekday) time.Time {
+
+	days := (int(weekday)-int(p.today.Weekday())+6)%7 + 1
+
+	return p.today.AddDate(0, 0, days)
+}
+
+// complete makes the item due when only its time or its recurrence is known:
+// on the next such time or on the first date it recurs on.
+func (p *parser) complete() {
+
+	if !p.dueDate.IsZero() {
+		return
+	}
+
+	switch {
+	case len(p.byDay) > 0:
+		for days := 0; days < 7; days++ {
+			date := p.today.AddDate(0, 0, days)
+			for _, weekday := range p.byDay {
+				if date.Weekday() == weekday {
+					p.result.DueDate = date.Format(DateLayout)
+					return
+				}
+			}
+		}
+	case p.dueTime != nil:
+		date := p.today
+		if !p.dueTime.on(p.today).After(p.now) {
+			date = date.AddDate(0, 0, 1)
+		}
+		p.result.DueDate = date.Format(DateLayout)
+	case p.result.RRule != "":
+		p.result.DueDate = p.today.Format(DateLayout)
+	}
+}
@@ -0,0 +1,130 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dvln/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wednesday
+	now := time.Date(2024, time.March, 6, 10, 30, 0, 0, berlin)
+
+	tests := []struct {
+		name string
+		text string
+		want Result
+	}{
+		{
+			name: "Everything",
+			text: "Pay rent tomorrow 9am #home !high every month",
+			want: Result{Title: "Pay rent", DueDate: "2024-03-07", DueTime: "09:00", Tags: []string{"home"}, Priority: "high", RRule: "FREQ=MONTHLY",
+				Tokens: []Token{
+					{Text: "tomorrow", Kind: KindDate, Value: "2024-03-07"},
+					{Text: "9am", Kind: KindTime, Value: "09:00"},
+					{Text: "#home", Kind: KindTag, Value: "home"},
+					{Text: "!high", Kind: KindPriority, Value: "high"},
+					{Text: "every month", Kind: KindRecurrence, Value: "FREQ=MONTHLY"},
+				}},
+		},
+		{
+			name: "Plain title",
+			text: "Buy milk",
+			want: Result{Title: "Buy milk"},
+		},
+		{
+			name: "Weekday and time with a space",
+			text: "Call mom on friday at 6:30 pm",
+			want: Result{Title: "Call mom", DueDate: "2024-03-08", DueTime: "18:30",
+				Tokens: []Token{{Text: "on friday", Kind: KindDate, Value: "2024-03-08"}, {Text: "at 6:30 pm", Kind: KindTime, Value: "18:30"}}},
+		},
+		{
+			name: "Same weekday is a week later",
+			text: "Team sync next wed 14:00",
+			want: Result{Title: "Team sync", DueDate: "2024-03-13", DueTime: "14:00",
+				Tokens: []Token{{Text: "next wed", Kind: KindDate, Value: "2024-03-13"}, {Text: "14:00", Kind: KindTime, Value: "14:00"}}},
+		},
+		{
+			name: "Short weekday stays in the title",
+			text: "Enjoy the sun",
+			want: Result{Title: "Enjoy the sun"},
+		},
+		{
+			name: "Month and day",
+			text: "Dentist May 5th",
+			want: Result{Title: "Dentist", DueDate: "2024-05-05", Tokens: []Token{{Text: "May 5th", Kind: KindDate, Value: "2024-05-05"}}},
+		},
+		{
+			name: "Past day of the month is next year",
+			text: "File taxes 1 march",
+			want: Result{Title: "File taxes", DueDate: "2025-03-01", Tokens: []Token{{Text: "1 march", Kind: KindDate, Value: "2025-03-01"}}},
+		},
+		{
+			name: "Invalid day",
+			text: "Party feb 30",
+			want: Result{Title: "Party feb 30"},
+		},
+		{
+			name: "Absolute date",
+			text: "Release 2024-04-01",
+			want: Result{Title: "Release", DueDate: "2024-04-01", Tokens: []Token{{Text: "2024-04-01", Kind: KindDate, Value: "2024-04-01"}}},
+		},
+		{
+			name: "Relative date",
+			text: "Renew passport in 2 weeks",
+			want: Result{Title: "Renew passport", DueDate: "2024-03-20", Tokens: []Token{{Text: "in 2 weeks", Kind: KindDate, Value: "2024-03-20"}}},
+		},
+		{
+			name: "Passed time is tomorrow",
+			text: "Stand-up 9:15am",
+			want: Result{Title: "Stand-up", DueDate: "2024-03-07", DueTime: "09:15", Tokens: []Token{{Text: "9:15am", Kind: KindTime, Value: "09:15"}}},
+		},
+		{
+			name: "Coming time is today",
+			text: "Lunch at noon",
+			want: Result{Title: "Lunch", DueDate: "2024-03-06", DueTime: "12:00", Tokens: []Token{{Text: "at noon", Kind: KindTime, Value: "12:00"}}},
+		},
+		{
+			name: "Recurring weekdays start on the next one",
+			text: "Gym every mon, thu and sat",
+			want: Result{Title: "Gym", DueDate: "2024-03-07", RRule: "FREQ=WEEKLY;BYDAY=MO,TH,SA",
+				Tokens: []Token{{Text: "every mon, thu and sat", Kind: KindRecurrence, Value: "FREQ=WEEKLY;BYDAY=MO,TH,SA"}}},
+		},
+		{
+			name: "Recurring interval starts today",
+			text: "Water plants every 3 days",
+			want: Result{Title: "Water plants", DueDate: "2024-03-06", RRule: "FREQ=DAILY;INTERVAL=3",
+				Tokens: []Token{{Text: "every 3 days", Kind: KindRecurrence, Value: "FREQ=DAILY;INTERVAL=3"}}},
+		},
+		{
+			name: "Every other week",
+			text: "Clean windows every other week",
+			want: Result{Title: "Clean windows", DueDate: "2024-03-06", RRule: "FREQ=WEEKLY;INTERVAL=2",
+				Tokens: []Token{{Text: "every other week", Kind: KindRecurrence, Value: "FREQ=WEEKLY;INTERVAL=2"}}},
+		},
+		{
+			name: "Second date stays in the title",
+			text: "Move meeting from monday to tuesday #work #Work",
+			want: Result{Title: "Move meeting from to tuesday", DueDate: "2024-03-11", Tags: []string{"work"},
+				Tokens: []Token{{Text: "monday", Kind: KindDate, Value: "2024-03-11"}, {Text: "#work", Kind: KindTag, Value: "work"}, {Text: "#Work", Kind: KindTag, Value: "Work"}}},
+		},
+		{
+			name: "Unknown priority and lone signs",
+			text: "Fix bug !asap # 9",
+			want: Result{Title: "Fix bug !asap # 9"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, Parse(test.text, now))
+		})
+	}
+}
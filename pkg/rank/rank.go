@@ -0,0 +1,132 @@
+// Package rank generates lexicographic ranks which keep a user-defined order
+// of rows. A new rank can always be placed between two existing ones, so
+// moving a row never requires renumbering its neighbours.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+// digits are ordered the same way by byte comparison, which is what
+// Postgres does for columns with the "C" collation.
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var (
+	ErrInvalidRank = errors.New("rank contains invalid characters")
+	ErrInvalidPair = errors.New("lower rank must be less than upper rank")
+)
+
+// Between returns a rank strictly greater than lower and strictly less than upper.
+// An empty lower rank means "before everything", an empty upper rank means
+// "after everything", so Between("", "") returns the rank of the first row.
+func Between(lower, upper string) (string, error) {
+
+	if !valid(lower) || !valid(upper) {
+		return "", ErrInvalidRank
+	}
+
+	if upper != "" && lower >= upper {
+		return "", ErrInvalidPair
+	}
+
+	// appending and prepending step by one digit instead of halving the
+	// free space, so the ranks grow by a digit only every few dozen rows
+	switch {
+	case lower != "" && upper == "":
+		return after(lower), nil
+	case lower == "" && upper != "":
+		return before(upper), nil
+	}
+
+	return midpoint(lower, upper), nil
+}
+
+// after increments the last digit of the rank which is not the largest one,
+// dropping the digits behind it. A rank made of the largest digits only gets
+// a digit appended.
+func after(lower string) string {
+
+	for i := len(lower) - 1; i >= 0; i-- {
+		if d := strings.IndexByte(digits, lower[i]); d < len(digits)-1 {
+			return lower[:i] + string(digits[d+1])
+		}
+	}
+
+	return lower + digits[1:2]
+}
+
+// before decrements the first digit of the rank which is not the zero digit,
+// dropping the digits behind it. A decremented digit may not become a trailing
+// zero, such a rank is followed by the largest digit instead.
+func before(upper string) string {
+
+	for i := 0; i < len(upper); i++ {
+		switch d := strings.IndexByte(digits, upper[i]); {
+		case d > 1:
+			return upper[:i] + string(digits[d-1])
+		case d == 1 && i < len(upper)-1:
+			return upper[:i+1]
+		case d == 1:
+			return upper[:i] + digits[:1] + digits[len(digits)-1:]
+		}
+	}
+
+	// unreachable for a valid rank, it never ends with the zero digit
+	return midpoint("", upper)
+}
+
+// midpoint expects lower < upper and neither of them ending with the zero digit.
+func midpoint(lower, upper string) string {
+
+	if upper != "" {
+		// skip the common prefix, treating missing lower digits as zeros
+		n := 0
+		for n < len(upper) && digitAt(lower, n) == strings.IndexByte(digits, upper[n]) {
+			n++
+		}
+
+		if n > 0 {
+			return upper[:n] + midpoint(suffix(lower, n), upper[n:])
+		}
+	}
+
+	lo, hi := digitAt(lower, 0), len(digits)
+	if upper != "" {
+		hi = strings.IndexByte(digits, upper[0])
+	}
+
+	if hi-lo > 1 {
+		return string(digits[(lo+hi)/2])
+	}
+
+	// the first digits are consecutive
+	if len(upper) > 1 {
+		return upper[:1]
+	}
+
+	return string(digits[lo]) + midpoint(suffix(lower, 1), "")
+}
+
+func digitAt(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	return strings.IndexByte(digits, s[i])
+}
+
+func suffix(s string, n int) string {
+	if n >= len(s) {
+		return ""
+	}
+	return s[n:]
+}
+
+func valid(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(digits, s[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(s, digits[:1])
+}
@@ -0,0 +1,99 @@
+package rank
+
+import (
+	"testing"
+
+	"github.com/dvln/testify/assert"
+)
+
+func TestBetween(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		lower   string
+		upper   string
+		want    string
+		wantErr error
+	}{
+		{name: "First rank", lower: "", upper: "", want: "V"},
+		{name: "After", lower: "V", upper: "", want: "W"},
+		{name: "After with carry", lower: "Vz", upper: "", want: "W"},
+		{name: "Before", lower: "", upper: "V", want: "U"},
+		{name: "Before the first digit", lower: "", upper: "1", want: "0z"},
+		{name: "Before a longer rank", lower: "", upper: "1V", want: "1"},
+		{name: "Between", lower: "F", upper: "V", want: "N"},
+		{name: "Consecutive digits", lower: "a", upper: "b", want: "aV"},
+		{name: "Common prefix", lower: "aV", upper: "aW", want: "aVV"},
+		{name: "Longer upper", lower: "a", upper: "b1", want: "b"},
+		{name: "Last digit", lower: "z", upper: "", want: "z1"},
+		{name: "Equal ranks", lower: "V", upper: "V", wantErr: ErrInvalidPair},
+		{name: "Reversed ranks", lower: "k", upper: "V", wantErr: ErrInvalidPair},
+		{name: "Invalid characters", lower: "a-b", upper: "", wantErr: ErrInvalidRank},
+		{name: "Trailing zero", lower: "a0", upper: "", wantErr: ErrInvalidRank},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			got, err := Between(test.lower, test.upper)
+			if test.wantErr != nil {
+				assert.Equal(t, test.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+			assert.True(t, test.lower < got)
+			if test.upper != "" {
+				assert.True(t, got < test.upper)
+			}
+		})
+	}
+}
+
+func TestBetween_Repeated(t *testing.T) {
+
+	lower, upper := "", ""
+	for i := 0; i < 1000; i++ {
+		got, err := Between(lower, upper)
+		assert.NoError(t, err)
+		assert.True(t, lower < got)
+		if upper != "" {
+			assert.True(t, got < upper)
+		}
+
+		// alternately squeeze from both sides
+		if i%2 == 0 {
+			lower = got
+		} else {
+			upper = got
+		}
+	}
+}
+
+func TestBetween_Append(t *testing.T) {
+
+	// the ranks are stored in varchar(255) columns
+	last := ""
+	for i := 0; i < 5000; i++ {
+		got, err := Between(last, "")
+		assert.NoError(t, err)
+		assert.True(t, last < got)
+		last = got
+	}
+
+	assert.True(t, len(last) < 100, "rank grew to %d digits", len(last))
+}
+
+func TestBetween_Prepend(t *testing.T) {
+
+	first, _ := Between("", "")
+	for i := 0; i < 5000; i++ {
+		got, err := Between("", first)
+		assert.NoError(t, err)
+		assert.True(t, got < first)
+		first = got
+	}
+
+	assert.True(t, len(first) < 100, "rank grew to %d digits", len(first))
+}
@@ -0,0 +1,495 @@
+// Package rrule parses the recurrence rules of RFC 5545 and finds the dates
+// they recur on. The rules work with calendar dates only: the time of day and
+// the time zone are applied by the caller, so a recurring item keeps its wall
+// clock time across daylight-saving transitions.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the length of the periods the rule repeats in.
+type Frequency int
+
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+// maxPeriods bounds the search for the next date of a rule which may never
+// recur again, e.g. on the 30th of February.
+const maxPeriods = 10000
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// WeekdayNum is a weekday of BYDAY, N picks the nth such weekday of the
+// month or the year, counting from the end when negative. Zero N means
+// every such weekday.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// Rule is a parsed RRULE. A zero Until and a zero Count mean the rule
+// recurs forever.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+var frequencies = map[string]Frequency{
+	"DAILY":   Daily,
+	"WEEKLY":  Weekly,
+	"MONTHLY": Monthly,
+	"YEARLY":  Yearly,
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", the "RRULE:"
+// prefix is optional. The rules recurring more often than daily are not supported.
+func Parse(s string) (Rule, error) {
+
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+
+	rule, seen := Rule{Interval: 1, WeekStart: time.Monday}, make(map[string]bool)
+
+	for _, part := range strings.Split(s, ";") {
+
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Rule{}, invalid("malformed part %q", part)
+		}
+
+		if seen[name] {
+			return Rule{}, invalid("%s is given more than once", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq, ok = frequencies[value]
+			if !ok {
+				err = invalid("unsupported frequency %q", value)
+			}
+		case "INTERVAL":
+			rule.Interval, err = parseNumber(name, value, 1, maxPeriods)
+		case "COUNT":
+			rule.Count, err = parseNumber(name, value, 1, maxPeriods)
+		case "UNTIL":
+			rule.Until, err = parseUntil(value)
+		case "BYDAY":
+			rule.ByDay, err = parseWeekdays(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseNumbers(name, value, 31)
+		case "BYMONTH":
+			rule.ByMonth, err = parseMonths(value)
+		case "BYSETPOS":
+			rule.BySetPos, err = parseNumbers(name, value, 366)
+		case "WKST":
+			rule.WeekStart, ok = weekdays[value]
+			if !ok {
+				err = invalid("unknown weekday %q", value)
+			}
+		default:
+			err = invalid("unsupported part %s", name)
+		}
+
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+
+	if err := rule.validate(seen); err != nil {
+		return Rule{}, err
+	}
+
+	return rule, nil
+}
+
+// validate checks the combinations of the parts RFC 5545 does not allow.
+func (r Rule) validate(seen map[string]bool) error {
+
+	if !seen["FREQ"] {
+		return invalid("FREQ is required")
+	}
+
+	if seen["COUNT"] && seen["UNTIL"] {
+		return invalid("COUNT and UNTIL can not be used together")
+	}
+
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return invalid("BYMONTHDAY can not be used with a weekly rule")
+	}
+
+	if r.Freq == Daily || r.Freq == Weekly {
+		for _, day := range r.ByDay {
+			if day.N != 0 {
+				return invalid("numbered weekdays need a monthly or a yearly rule")
+			}
+		}
+	}
+
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByMonth) == 0 {
+		return invalid("BYSETPOS needs another BY part")
+	}
+
+	return nil
+}
+
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidRule, fmt.Sprintf(format, args...))
+}
+
+func parseNumber(name, value string, min, max int) (int, error) {
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, invalid("%s must be a number from %d to %d", name, min, max)
+	}
+
+	return n, nil
+}
+
+// parseNumbers reads a list of non-zero numbers within -max and max,
+// the negative numbers count from the end.
+func parseNumbers(name, value string, max int) ([]int, error) {
+
+	var numbers []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n < -max || n > max {
+			return nil, invalid("%s must be a list of non-zero numbers from -%d to %d", name, max, max)
+		}
+		numbers = append(numbers, n)
+	}
+
+	return numbers, nil
+}
+
+func parseMonths(value string) ([]time.Month, error) {
+
+	var months []time.Month
+	for _, item := range strings.Split(value, ",") {
+		n, err := parseNumber("BYMONTH", item, 1, 12)
+		if err != nil {
+			return nil, err
+		}
+		months = append(months, time.Month(n))
+	}
+
+	return months, nil
+}
+
+// parseWeekdays reads the weekdays of BYDAY such as MO, 2TU or -1FR.
+func parseWeekdays(value string) ([]WeekdayNum, error) {
+
+	var days []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+
+		if len(item) < 2 {
+			return nil, invalid("unknown weekday %q", item)
+		}
+
+		weekday, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, invalid("unknown weekday %q", item)
+		}
+
+		day := WeekdayNum{Weekday: weekday}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, invalid("unknown weekday %q", item)
+			}
+			day.N = n
+		}
+
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+// parseUntil reads the date of UNTIL, which may be given with or without the time.
+func parseUntil(value string) (time.Time, error) {
+
+	for _, layout := range []string{"20060102", "20060102T150405Z", "20060102T150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return date(t), nil
+		}
+	}
+
+	return time.Time{}, invalid("UNTIL must be a date such as 20240131 or 20240131T235959Z")
+}
+
+// Next returns the first date after the given one the rule starting on start
+// recurs on, ok is false when the rule ends before it. Only the dates matter,
+// the clock and the location of the arguments are ignored. Count is left to
+// the caller, who knows how many times the rule has recurred so far.
+func (r Rule) Next(start, after time.Time) (next time.Time, ok bool) {
+
+	start, after = date(start), date(after)
+
+	period := 0
+	if after.After(start) {
+		period = r.periodIndex(start, after)
+		period -= period % r.Interval
+	}
+
+	for i := 0; i < maxPeriods; i++ {
+
+		for _, day := range r.expand(start, r.periodStart(start, period)) {
+
+			if !r.Until.IsZero() && day.After(r.Until) {
+				return time.Time{}, false
+			}
+
+			if day.After(after) && !day.Before(start) {
+				return day, true
+			}
+		}
+
+		period += r.Interval
+	}
+
+	return time.Time{}, false
+}
+
+// periodIndex counts the periods from the one of start to the one of the day.
+func (r Rule) periodIndex(start, day time.Time) int {
+
+	switch r.Freq {
+	case Weekly:
+		return days(r.weekStart(start), r.weekStart(day)) / 7
+	case Monthly:
+		return (day.Year()-start.Year())*12 + int(day.Month()-start.Month())
+	case Yearly:
+		return day.Year() - start.Year()
+	}
+
+	return days(start, day)
+}
+
+// periodStart returns the first day of the nth period counting from the one of start.
+func (r Rule) periodStart(start time.Time, n int) time.Time {
+
+	switch r.Freq {
+	case Weekly:
+		return r.weekStart(start).AddDate(0, 0, 7*n)
+	case Monthly:
+		return time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	case Yearly:
+		return time.Date(start.Year()+n, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return start.AddDate(0, 0, n)
+}
+
+func (r Rule) weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) - int(r.WeekStart) + 7) % 7))
+}
+
+// expand returns the sorted dates the rule recurs on within the period beginning on the given day.
+func (r Rule) expand(start, period time.Time) []time.Time {
+
+	var candidates []time.Time
+
+	switch r.Freq {
+	case Daily:
+		if r.monthMatches(period) && r.monthDayMatches(period) && r.weekdayMatches(period, false) {
+			candidates = append(candidates, period)
+		}
+	case Weekly:
+		for i := 0; i < 7; i++ {
+			day := period.AddDate(0, 0, i)
+
+			matches := day.Weekday() == start.Weekday()
+			if len(r.ByDay) > 0 {
+				matches = r.weekdayMatches(day, false)
+			}
+
+			if matches && r.monthMatches(day) {
+				candidates = append(candidates, day)
+			}
+		}
+	case Monthly:
+		if r.monthMatches(period) {
+			candidates = r.expandMonth(start, period)
+		}
+	case Yearly:
+		candidates = r.expandYear(start, period)
+	}
+
+	return r.setPositions(candidates)
+}
+
+// expandMonth returns the days of the month the rule recurs on. Without BYDAY and
+// BYMONTHDAY the rule recurs on the day of the month it started on, the months
+// too short for it are skipped.
+func (r Rule) expandMonth(start, month time.Time) []time.Time {
+
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		day := month.AddDate(0, 0, start.Day()-1)
+		if day.Month() != month.Month() {
+			return nil
+		}
+		return []time.Time{day}
+	}
+
+	var candidates []time.Time
+	for day := month; day.Month() == month.Month(); day = day.AddDate(0, 0, 1) {
+		if r.monthDayMatches(day) && r.weekdayMatches(day, false) {
+			candidates = append(candidates, day)
+		}
+	}
+
+	return candidates
+}
+
+// expandYear returns the days of the year the rule recurs on. Numbered weekdays
+// count within the year unless the rule names the months.
+func (r Rule) expandYear(start, year time.Time) []time.Time {
+
+	if len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) > 0 {
+		var candidates []time.Time
+		for day := year; day.Year() == year.Year(); day = day.AddDate(0, 0, 1) {
+			if r.weekdayMatches(day, true) {
+				candidates = append(candidates, day)
+			}
+		}
+		return candidates
+	}
+
+	months := r.ByMonth
+	if len(months) == 0 {
+		months = []time.Month{start.Month()}
+		if len(r.ByMonthDay) > 0 {
+			months = []time.Month{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+		}
+	}
+
+	var candidates []time.Time
+	for _, month := range months {
+		candidates = append(candidates, r.expandMonth(start, time.Date(year.Year(), month, 1, 0, 0, 0, 0, time.UTC))...)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+
+	return candidates
+}
+
+func (r Rule) monthMatches(day time.Time) bool {
+
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+
+	for _, month := range r.ByMonth {
+		if day.Month() == month {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Rule) monthDayMatches(day time.Time) bool {
+
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+
+	last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, n := range r.ByMonthDay {
+		if n == day.Day() || n < 0 && last+n+1 == day.Day() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// weekdayMatches checks the day against BYDAY, numbered weekdays are
+// counted within the month or, when inYear is set, within the year.
+func (r Rule) weekdayMatches(day time.Time, inYear bool) bool {
+
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	index, length := day.Day(), time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if inYear {
+		index, length = day.YearDay(), time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	}
+
+	nth, nthFromEnd := (index-1)/7+1, -((length-index)/7 + 1)
+
+	for _, weekday := range r.ByDay {
+		if weekday.Weekday == day.Weekday() && (weekday.N == 0 || weekday.N == nth || weekday.N == nthFromEnd) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setPositions keeps the candidates at the positions of BYSETPOS, counting from the end when negative.
+func (r Rule) setPositions(candidates []time.Time) []time.Time {
+
+	if len(r.BySetPos) == 0 {
+		return candidates
+	}
+
+	picked := make(map[int]bool)
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(candidates) + pos
+		}
+		if i >= 0 && i < len(candidates) {
+			picked[i] = true
+		}
+	}
+
+	var positions []time.Time
+	for i, day := range candidates {
+		if picked[i] {
+			positions = append(positions, day)
+		}
+	}
+
+	return positions
+}
+
+// date drops the clock and the location, leaving the calendar date in UTC.
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func days(from, to time.Time) int {
+	return int(to.Sub(from) / (24 * time.Hour))
+}
@@ -0,0 +1,128 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dvln/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		rule    string
+		want    Rule
+		wantErr bool
+	}{
+		{
+			name: "Every two weeks on Monday and Thursday",
+			rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			want: Rule{Freq: Weekly, Interval: 2, ByDay: []WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Thursday}}, WeekStart: time.Monday},
+		},
+		{
+			name: "Last weekday of the month",
+			rule: "RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			want: Rule{Freq: Monthly, Interval: 1, ByDay: []WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Tuesday}, {Weekday: time.Wednesday},
+				{Weekday: time.Thursday}, {Weekday: time.Friday}}, BySetPos: []int{-1}, WeekStart: time.Monday},
+		},
+		{
+			name: "Lower case with until",
+			rule: "freq=yearly;bymonth=11;byday=4th;until=20301231T235959Z;wkst=su",
+			want: Rule{Freq: Yearly, Interval: 1, ByMonth: []time.Month{time.November}, ByDay: []WeekdayNum{{Weekday: time.Thursday, N: 4}},
+				Until: time.Date(2030, time.December, 31, 0, 0, 0, 0, time.UTC), WeekStart: time.Sunday},
+		},
+		{name: "Missing frequency", rule: "INTERVAL=2", wantErr: true},
+		{name: "Hourly", rule: "FREQ=HOURLY", wantErr: true},
+		{name: "Zero interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "Count with until", rule: "FREQ=DAILY;COUNT=3;UNTIL=20300101", wantErr: true},
+		{name: "Unknown weekday", rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{name: "Numbered weekday of a weekly rule", rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{name: "Zero month day", rule: "FREQ=MONTHLY;BYMONTHDAY=0", wantErr: true},
+		{name: "Set position alone", rule: "FREQ=MONTHLY;BYSETPOS=1", wantErr: true},
+		{name: "Duplicate part", rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+		{name: "Unsupported part", rule: "FREQ=DAILY;BYHOUR=9", wantErr: true},
+		{name: "Malformed part", rule: "FREQ=DAILY;;", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			got, err := Parse(test.rule)
+			if test.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidRule))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestRule_Next(t *testing.T) {
+
+	day := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	tests := []struct {
+		name   string
+		rule   string
+		start  string
+		after  string
+		want   string
+		wantOk bool
+	}{
+		{name: "Daily", rule: "FREQ=DAILY", start: "2024-01-30", after: "2024-01-30", want: "2024-01-31", wantOk: true},
+		{name: "Every third day from a later date", rule: "FREQ=DAILY;INTERVAL=3", start: "2024-01-01", after: "2024-01-05", want: "2024-01-07", wantOk: true},
+		{name: "Same week", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", start: "2024-01-01", after: "2024-01-01", want: "2024-01-04", wantOk: true},
+		{name: "Skipped week", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", start: "2024-01-01", after: "2024-01-04", want: "2024-01-15", wantOk: true},
+		{name: "Weekly on the start weekday", rule: "FREQ=WEEKLY", start: "2024-03-06", after: "2024-03-06", want: "2024-03-13", wantOk: true},
+		{name: "Week starting on Sunday", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,MO;WKST=SU", start: "2024-01-07", after: "2024-01-08", want: "2024-01-21", wantOk: true},
+		{name: "Last weekday of the month", rule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", start: "2024-05-31", after: "2024-05-31", want: "2024-06-28", wantOk: true},
+		{name: "Monthly on the 31st skips short months", rule: "FREQ=MONTHLY", start: "2024-01-31", after: "2024-01-31", want: "2024-03-31", wantOk: true},
+		{name: "Last day of the month", rule: "FREQ=MONTHLY;BYMONTHDAY=-1", start: "2024-01-31", after: "2024-01-31", want: "2024-02-29", wantOk: true},
+		{name: "Second Tuesday", rule: "FREQ=MONTHLY;BYDAY=2TU", start: "2024-01-09", after: "2024-01-09", want: "2024-02-13", wantOk: true},
+		{name: "Thanksgiving", rule: "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", start: "2023-11-23", after: "2023-11-23", want: "2024-11-28", wantOk: true},
+		{name: "Leap day", rule: "FREQ=YEARLY", start: "2024-02-29", after: "2024-02-29", want: "2028-02-29", wantOk: true},
+		{name: "Last Friday of the year", rule: "FREQ=YEARLY;BYDAY=-1FR", start: "2024-12-27", after: "2024-12-27", want: "2025-12-26", wantOk: true},
+		{name: "Until", rule: "FREQ=WEEKLY;UNTIL=20240110", start: "2024-01-01", after: "2024-01-08", wantOk: false},
+		{name: "Never again", rule: "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", start: "2024-01-01", after: "2024-01-01", wantOk: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			rule, err := Parse(test.rule)
+			assert.NoError(t, err)
+
+			got, ok := rule.Next(day(test.start), day(test.after))
+			assert.Equal(t, test.wantOk, ok)
+			if test.wantOk {
+				assert.Equal(t, day(test.want), got)
+			}
+		})
+	}
+}
+
+func TestRule_NextIgnoresLocation(t *testing.T) {
+
+	location, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	rule, err := Parse("FREQ=DAILY")
+	assert.NoError(t, err)
+
+	// late evening before the spring-forward transition is still the 9th of March in New York
+	after := time.Date(2024, time.March, 9, 23, 30, 0, 0, location)
+
+	got, ok := rule.Next(after, after)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC), got)
+}
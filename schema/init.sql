@@ -3,34 +3,181 @@ CREATE TABLE users
     id serial not null unique,
     name varchar(255) not null,
     email varchar(255) not null unique,
-    password_hash varchar(255) not null
+    password_hash varchar(255) not null,
+    timezone varchar(64) not null default 'UTC',
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now()
 );
 
 CREATE TABLE todo_lists
 (
     id serial not null unique,
     title varchar(255) not null,
-    description varchar(255)
+    description varchar(255),
+    is_template boolean not null default false,
+    color varchar(7) not null default '',
+    icon varchar(64) not null default '',
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    deleted_at timestamp with time zone
+);
+
+CREATE TABLE statuses
+(
+    id serial not null unique,
+    list_id int references todo_lists(id) on delete cascade not null,
+    name varchar(64) not null,
+    done boolean not null default false,
+    wip_limit int check (wip_limit > 0),
+    position varchar(255) collate "C" not null,
+    constraint statuses_list_name unique (list_id, name)
 );
 
 CREATE TABLE todo_items 
 (
     id serial not null unique,
+    parent_id int references todo_items(id) on delete cascade,
     title varchar(255) not null,
-    description varchar(255),
-    done boolean not null default false
+    description text,
+    done boolean not null default false,
+    priority smallint not null default 0 check (priority between 0 and 4),
+    due_date date,
+    due_time time,
+    rrule varchar(255) not null default '',
+    repeat_from_completion boolean not null default false,
+    occurrence int not null default 1,
+    assignee_id int references users(id) on delete set null,
+    estimate_minutes int not null default 0 check (estimate_minutes >= 0),
+    status_id int references statuses(id) on delete set null,
+    defer_until timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    completed_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    check (due_time is null or due_date is not null),
+    constraint recurrence_due_date check (rrule = '' or due_date is not null)
 );
 
 CREATE TABLE lists_items
 (
     id serial not null unique,
     list_id int references todo_lists(id) on delete cascade not null,
-    item_id int references todo_items(id) on delete cascade not null
+    item_id int references todo_items(id) on delete cascade not null,
+    position varchar(255) collate "C" not null
+);
+
+CREATE INDEX lists_items_list_position ON lists_items (list_id, position, item_id);
+
+CREATE TABLE folders
+(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    parent_id int references folders(id) on delete cascade,
+    name varchar(255) not null,
+    position varchar(255) collate "C" not null
 );
 
 CREATE TABLE users_lists
 (
     id serial not null unique,
     user_id int references users(id) on delete cascade not null,
-    list_id int references todo_lists(id) on delete cascade not null
+    list_id int references todo_lists(id) on delete cascade not null,
+    folder_id int references folders(id) on delete set null,
+    pinned boolean not null default false,
+    position varchar(255) collate "C" not null,
+    unique (user_id, list_id)
+);
+
+CREATE INDEX users_lists_user_position ON users_lists (user_id, pinned DESC, position, list_id);
+
+CREATE TABLE reminders
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade not null,
+    remind_at timestamp with time zone,
+    minutes_before int,
+    snoozed_until timestamp with time zone,
+    sent_at timestamp with time zone,
+    check ((remind_at is null) <> (minutes_before is null))
+);
+
+CREATE TABLE tags
+(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    name varchar(64) not null,
+    color varchar(7) not null default '',
+    unique (user_id, name)
+);
+
+CREATE TABLE items_tags
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    tag_id int references tags(id) on delete cascade not null,
+    unique (item_id, tag_id)
+);
+
+CREATE TABLE comments
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade not null,
+    parent_id int,
+    body text not null,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone,
+    unique (id, item_id),
+    constraint comment_parent foreign key (parent_id, item_id) references comments(id, item_id) on delete cascade
+);
+
+CREATE TABLE attachments
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade not null,
+    name varchar(255) not null,
+    content_type varchar(255) not null,
+    size bigint not null,
+    storage_key varchar(255) not null unique,
+    created_at timestamp with time zone not null default now()
+);
+
+CREATE TABLE item_assignments
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    assignee_id int references users(id) on delete set null,
+    assigned_by int references users(id) on delete set null,
+    assigned_at timestamp with time zone not null default now()
+);
+
+CREATE TABLE item_dependencies
+(
+    item_id int references todo_items(id) on delete cascade not null,
+    blocker_id int references todo_items(id) on delete cascade not null,
+    primary key (item_id, blocker_id),
+    check (item_id <> blocker_id)
+);
+
+CREATE TABLE time_entries
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade not null,
+    started_at timestamp with time zone not null,
+    ended_at timestamp with time zone,
+    constraint time_entry_range check (ended_at is null or ended_at > started_at)
+);
+
+CREATE UNIQUE INDEX time_entries_running ON time_entries (user_id) WHERE ended_at IS NULL;
+
+CREATE TABLE item_revisions
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    user_id int references users(id) on delete set null,
+    changes jsonb not null,
+    created_at timestamp with time zone not null default now()
 );
\ No newline at end of file